@@ -0,0 +1,51 @@
+package render
+
+import (
+	"io"
+	"sync"
+)
+
+// textAppender is the encoding.TextAppender interface added in Go 1.24,
+// declared here to support it without requiring Go 1.24.
+type textAppender interface {
+	AppendText(b []byte) ([]byte, error)
+}
+
+// binaryAppender is the encoding.BinaryAppender interface added in Go 1.24,
+// declared here to support it without requiring Go 1.24.
+type binaryAppender interface {
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// appendBufferMaxCap is the largest capacity of buffers returned to
+// appendBufferPool, so rare large values do not stay allocated.
+const appendBufferMaxCap = 64 << 10
+
+var appendBufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 512)
+
+		return &b
+	},
+}
+
+// writeAppended writes the result of calling fn with an empty buffer from a
+// pool to w, avoiding an allocation per value.
+func writeAppended(w io.Writer, fn func(b []byte) ([]byte, error)) error {
+	bp, _ := appendBufferPool.Get().(*[]byte)
+	if bp == nil {
+		bp = new([]byte)
+	}
+
+	b, err := fn((*bp)[:0])
+	if err == nil {
+		_, err = w.Write(b)
+	}
+
+	if cap(b) <= appendBufferMaxCap {
+		*bp = b[:0]
+		appendBufferPool.Put(bp)
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAppended(t *testing.T) {
+	t.Run("writes appended bytes", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := writeAppended(&buf, func(b []byte) ([]byte, error) {
+			assert.Empty(t, b)
+
+			return append(b, "hello"...), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hello", buf.String())
+	})
+
+	t.Run("reuses emptied buffers", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			var buf bytes.Buffer
+
+			err := writeAppended(&buf, func(b []byte) ([]byte, error) {
+				assert.Empty(t, b)
+
+				return append(b, "abc"...), nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "abc", buf.String())
+		}
+	})
+
+	t.Run("large buffers", func(t *testing.T) {
+		var buf bytes.Buffer
+		large := bytes.Repeat([]byte("a"), appendBufferMaxCap+1)
+
+		err := writeAppended(&buf, func(b []byte) ([]byte, error) {
+			return append(b, large...), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, len(large), buf.Len())
+	})
+
+	t.Run("append error", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := writeAppended(&buf, func(b []byte) ([]byte, error) {
+			return append(b, "partial"...), errors.New("append error")
+		})
+		assert.EqualError(t, err, "append error")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		w := &mockWriter{WriteErr: errors.New("write error")}
+
+		err := writeAppended(w, func(b []byte) ([]byte, error) {
+			return append(b, "hello"...), nil
+		})
+		assert.EqualError(t, err, "write error")
+	})
+}
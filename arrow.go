@@ -0,0 +1,300 @@
+package render
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Arrow is a Handler that renders structs, and slices or arrays of structs, as
+// an Apache Arrow IPC stream (https://arrow.apache.org/docs/format/IPC.html),
+// containing a schema and a single record batch.
+//
+// Each exported struct field becomes a column, named by the "arrow" struct tag
+// if present, otherwise by the field name. Fields tagged with `arrow:"-"` are
+// skipped. Column types are based on the Go type of each field:
+//
+//   - bool fields are Bool columns.
+//   - Integer fields are signed or unsigned Int columns of the same width,
+//     with int and uint being 64-bit.
+//   - float32 and float64 fields are single and double precision
+//     FloatingPoint columns.
+//   - time.Time fields are microsecond precision UTC Timestamp columns.
+//   - time.Duration fields are nanosecond precision Duration columns.
+//   - All other fields are Utf8 columns, using the String method of
+//     fmt.Stringer values, and fmt.Sprint otherwise.
+//
+// All columns are nullable, with nil pointers and zero times written as nulls.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type Arrow struct{}
+
+var (
+	_ Handler            = (*Arrow)(nil)
+	_ FormatsHandler     = (*Arrow)(nil)
+	_ DescribedHandler   = (*Arrow)(nil)
+	_ ContentTypeHandler = (*Arrow)(nil)
+)
+
+// Render writes v as an Arrow IPC stream to w.
+func (ar *Arrow) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	fields := structFields(t, "arrow")
+	columns := make([]*arrowColumn, 0, len(fields))
+	for _, f := range fields {
+		columns = append(columns, newArrowColumn(f, rows))
+	}
+
+	schemaFields := make(fbOffsets, 0, len(columns))
+	for _, c := range columns {
+		schemaFields = append(schemaFields, c.field)
+	}
+	schema := (&fbTable{}).
+		int16(0, 0). // endianness: Little
+		ref(1, schemaFields)
+
+	var nodes, buffers []byte
+	var body []byte
+	for _, c := range columns {
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(len(rows)))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(c.nulls))
+
+		for _, b := range c.buffers {
+			buffers = binary.LittleEndian.AppendUint64(
+				buffers, uint64(len(body)),
+			)
+			buffers = binary.LittleEndian.AppendUint64(
+				buffers, uint64(len(b)),
+			)
+			body = append(body, b...)
+			for len(body)%8 != 0 {
+				body = append(body, 0)
+			}
+		}
+	}
+	batch := (&fbTable{}).
+		int64(0, int64(len(rows))).
+		ref(1, fbStructs{len: len(columns), data: nodes}).
+		ref(2, fbStructs{len: len(buffers) / 16, data: buffers})
+
+	var buf []byte
+	buf = appendArrowMessage(buf, arrowHeaderSchema, schema, nil)
+	buf = appendArrowMessage(buf, arrowHeaderRecordBatch, batch, body)
+	buf = binary.LittleEndian.AppendUint32(buf, arrowContinuation)
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+
+	_, err := w.Write(buf)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (ar *Arrow) Formats() []string {
+	return []string{"arrow", "arrows"}
+}
+
+// Description returns a short human-readable description of the format.
+func (ar *Arrow) Description() string {
+	return "Apache Arrow IPC stream"
+}
+
+// ContentType returns the MIME type of the format.
+func (ar *Arrow) ContentType() string {
+	return "application/vnd.apache.arrow.stream"
+}
+
+const (
+	arrowContinuation = 0xFFFFFFFF
+	arrowMetadataV5   = 4
+
+	arrowHeaderSchema      = 1
+	arrowHeaderRecordBatch = 3
+
+	arrowTypeInt           = 2
+	arrowTypeFloatingPoint = 3
+	arrowTypeUtf8          = 5
+	arrowTypeBool          = 6
+	arrowTypeTimestamp     = 10
+	arrowTypeDuration      = 18
+
+	arrowPrecisionSingle = 1
+	arrowPrecisionDouble = 2
+
+	arrowUnitMicrosecond = 2
+	arrowUnitNanosecond  = 3
+)
+
+// appendArrowMessage appends an encapsulated IPC message to buf, with the
+// given header table and body.
+func appendArrowMessage(
+	buf []byte,
+	headerType uint8,
+	header *fbTable,
+	body []byte,
+) []byte {
+	meta := fbFinish((&fbTable{}).
+		int16(0, arrowMetadataV5).
+		uint8(1, headerType).
+		ref(2, header).
+		int64(3, int64(len(body))))
+
+	buf = binary.LittleEndian.AppendUint32(buf, arrowContinuation)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(meta)))
+	buf = append(buf, meta...)
+
+	return append(buf, body...)
+}
+
+var (
+	arrowTimeType     = reflect.TypeOf(time.Time{})
+	arrowDurationType = reflect.TypeOf(time.Duration(0))
+	arrowStringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// arrowColumn is the schema field and data buffers of a single column.
+type arrowColumn struct {
+	field   *fbTable
+	nulls   int
+	buffers [][]byte
+}
+
+func newArrowColumn(f structField, rows []reflect.Value) *arrowColumn {
+	t := f.typ
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var typeID uint8
+	typ := &fbTable{}
+	var width int
+
+	switch {
+	case t == arrowTimeType:
+		typeID, width = arrowTypeTimestamp, 8
+		typ.int16(0, arrowUnitMicrosecond).ref(1, fbString("UTC"))
+	case t == arrowDurationType:
+		typeID, width = arrowTypeDuration, 8
+		typ.int16(0, arrowUnitNanosecond)
+	case t.Implements(arrowStringerType) ||
+		reflect.PointerTo(t).Implements(arrowStringerType):
+		typeID = arrowTypeUtf8
+	default:
+		switch t.Kind() { //nolint:exhaustive
+		case reflect.Bool:
+			typeID = arrowTypeBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			typeID, width = arrowTypeInt, int(t.Size())
+			signed := t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64
+			typ.int32(0, int32(width*8)).bool(1, signed)
+		case reflect.Float32:
+			typeID, width = arrowTypeFloatingPoint, 4
+			typ.int16(0, arrowPrecisionSingle)
+		case reflect.Float64:
+			typeID, width = arrowTypeFloatingPoint, 8
+			typ.int16(0, arrowPrecisionDouble)
+		default:
+			typeID = arrowTypeUtf8
+		}
+	}
+
+	col := &arrowColumn{
+		field: (&fbTable{}).
+			ref(0, fbString(f.name)).
+			bool(1, true).
+			uint8(2, typeID).
+			ref(3, typ).
+			ref(5, fbOffsets{}),
+	}
+
+	validity := make([]byte, (len(rows)+7)/8)
+	values := make([]byte, 0, len(rows)*width)
+	offsets := binary.LittleEndian.AppendUint32(nil, 0)
+	var data []byte
+	if typeID == arrowTypeBool {
+		values = make([]byte, (len(rows)+7)/8)
+	}
+
+	for i, row := range rows {
+		rv := indirect(f.value(row))
+		if rv.IsValid() && rv.Type() == arrowTimeType &&
+			rv.Interface().(time.Time).IsZero() {
+			rv = reflect.Value{}
+		}
+
+		if !rv.IsValid() {
+			col.nulls++
+			values = append(values, make([]byte, width)...)
+			offsets = binary.LittleEndian.AppendUint32(
+				offsets, uint32(len(data)),
+			)
+
+			continue
+		}
+		validity[i/8] |= 1 << (i % 8)
+
+		switch typeID {
+		case arrowTypeBool:
+			if rv.Bool() {
+				values[i/8] |= 1 << (i % 8)
+			}
+		case arrowTypeTimestamp:
+			values = binary.LittleEndian.AppendUint64(
+				values, uint64(rv.Interface().(time.Time).UnixMicro()),
+			)
+		case arrowTypeFloatingPoint:
+			if width == 4 {
+				values = binary.LittleEndian.AppendUint32(
+					values, math.Float32bits(float32(rv.Float())),
+				)
+			} else {
+				values = binary.LittleEndian.AppendUint64(
+					values, math.Float64bits(rv.Float()),
+				)
+			}
+		case arrowTypeInt, arrowTypeDuration:
+			var u uint64
+			if rv.CanInt() {
+				u = uint64(rv.Int())
+			} else {
+				u = rv.Uint()
+			}
+			for b := 0; b < width; b++ {
+				values = append(values, byte(u>>(8*b)))
+			}
+		case arrowTypeUtf8:
+			if s, ok := rv.Interface().(fmt.Stringer); ok {
+				data = append(data, s.String()...)
+			} else if s, ok := addrStringer(rv); ok {
+				data = append(data, s.String()...)
+			} else {
+				data = append(data, fmt.Sprint(rv.Interface())...)
+			}
+			offsets = binary.LittleEndian.AppendUint32(
+				offsets, uint32(len(data)),
+			)
+		}
+	}
+
+	if col.nulls == 0 {
+		validity = nil
+	}
+	if typeID == arrowTypeUtf8 {
+		col.buffers = [][]byte{validity, offsets, data}
+	} else {
+		col.buffers = [][]byte{validity, values}
+	}
+
+	return col
+}
@@ -0,0 +1,384 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type arrowTestLevel int
+
+func (l arrowTestLevel) String() string {
+	return [...]string{"low", "high"}[l]
+}
+
+type arrowTestRow struct {
+	Name   string `arrow:"name"`
+	Age    int
+	Small  int8
+	Count  uint16
+	Score  float32
+	Ratio  float64
+	Active bool
+	Joined time.Time
+	Took   time.Duration
+	Note   *string
+	Level  arrowTestLevel
+	Skip   string `arrow:"-"`
+	hidden string
+}
+
+// readArrowStream decodes an Arrow IPC stream as written by the Arrow
+// handler, returning a description of each schema field in the form
+// "name: type", and the values of each column.
+func readArrowStream(
+	t *testing.T,
+	stream []byte,
+) (fields []string, columns [][]any) {
+	t.Helper()
+
+	var types []string
+	pos := 0
+	for {
+		require.LessOrEqual(t, pos+8, len(stream), "unexpected end of stream")
+		require.Equal(t, uint32(0xFFFFFFFF),
+			binary.LittleEndian.Uint32(stream[pos:]))
+		size := int(binary.LittleEndian.Uint32(stream[pos+4:]))
+		if size == 0 {
+			require.Equal(t, len(stream), pos+8, "data after end of stream")
+
+			return fields, columns
+		}
+		require.Zero(t, size%8, "metadata is not padded")
+
+		r := &fbTestReader{t: t, buf: stream[pos+8 : pos+8+size]}
+		msg := r.root()
+		require.Equal(t, 4, r.fieldI16(msg, 0), "metadata version")
+		header := r.fieldRef(msg, 2)
+		bodyLen := int(r.fieldI64(msg, 3))
+		require.Zero(t, bodyLen%8, "body is not padded")
+		body := stream[pos+8+size : pos+8+size+bodyLen]
+		pos += 8 + size + bodyLen
+
+		switch r.fieldU8(msg, 1) {
+		case 1: // Schema
+			require.Zero(t, bodyLen)
+			fields, types = readArrowSchema(t, r, header)
+		case 3: // RecordBatch
+			columns = readArrowRecordBatch(t, r, header, types, body)
+		default:
+			require.Fail(t, "unexpected message header type")
+		}
+	}
+}
+
+func readArrowSchema(
+	t *testing.T,
+	r *fbTestReader,
+	schema int,
+) (fields []string, types []string) {
+	t.Helper()
+
+	n, start := r.vector(r.fieldRef(schema, 1))
+	for i := 0; i < n; i++ {
+		f := r.deref(start + 4*i)
+		name := r.string(r.fieldRef(f, 0))
+		require.Equal(t, 1, r.fieldU8(f, 1), "field is not nullable")
+		children, _ := r.vector(r.fieldRef(f, 5))
+		require.Zero(t, children)
+
+		typ := r.fieldRef(f, 3)
+		var desc string
+		switch r.fieldU8(f, 2) {
+		case 2:
+			desc = "uint"
+			if r.fieldU8(typ, 1) == 1 {
+				desc = "int"
+			}
+			desc += fmt.Sprint(r.fieldI32(typ, 0))
+		case 3:
+			desc = "float32"
+			if r.fieldI16(typ, 0) == 2 {
+				desc = "float64"
+			}
+		case 5:
+			desc = "utf8"
+		case 6:
+			desc = "bool"
+		case 10:
+			desc = fmt.Sprintf(
+				"timestamp[%d, %s]",
+				r.fieldI16(typ, 0), r.string(r.fieldRef(typ, 1)),
+			)
+		case 18:
+			desc = fmt.Sprintf("duration[%d]", r.fieldI16(typ, 0))
+		}
+
+		fields = append(fields, name+": "+desc)
+		types = append(types, desc)
+	}
+
+	return fields, types
+}
+
+func readArrowRecordBatch(
+	t *testing.T,
+	r *fbTestReader,
+	batch int,
+	types []string,
+	body []byte,
+) [][]any {
+	t.Helper()
+
+	length := int(r.fieldI64(batch, 0))
+	nNodes, nodes := r.vector(r.fieldRef(batch, 1))
+	require.Equal(t, len(types), nNodes)
+	nBuffers, buffers := r.vector(r.fieldRef(batch, 2))
+
+	bi := 0
+	buffer := func() []byte {
+		require.Less(t, bi, nBuffers)
+		off := int(r.i64(buffers + 16*bi))
+		size := int(r.i64(buffers + 16*bi + 8))
+		bi++
+		require.Zero(t, off%8, "buffer is not aligned")
+
+		return body[off : off+size]
+	}
+	bit := func(b []byte, i int) bool {
+		return b[i/8]&(1<<(i%8)) != 0
+	}
+
+	columns := make([][]any, 0, len(types))
+	for c, typ := range types {
+		require.Equal(t, int64(length), r.i64(nodes+16*c))
+		nulls := int(r.i64(nodes + 16*c + 8))
+
+		validity := buffer()
+		if nulls == 0 {
+			assert.Empty(t, validity)
+		}
+		values := buffer()
+		var data []byte
+		if typ == "utf8" {
+			data = buffer()
+		}
+
+		col := make([]any, length)
+		for i := range col {
+			if nulls > 0 && !bit(validity, i) {
+				nulls--
+
+				continue
+			}
+
+			le := binary.LittleEndian
+			switch typ {
+			case "bool":
+				col[i] = bit(values, i)
+			case "utf8":
+				start, end := le.Uint32(values[4*i:]), le.Uint32(values[4*i+4:])
+				col[i] = string(data[start:end])
+			case "int8":
+				col[i] = int64(int8(values[i]))
+			case "int32":
+				col[i] = int64(int32(le.Uint32(values[4*i:])))
+			case "int64":
+				col[i] = int64(le.Uint64(values[8*i:]))
+			case "uint16":
+				col[i] = uint64(le.Uint16(values[2*i:]))
+			case "float32":
+				col[i] = float64(math.Float32frombits(le.Uint32(values[4*i:])))
+			case "float64":
+				col[i] = math.Float64frombits(le.Uint64(values[8*i:]))
+			case "timestamp[2, UTC]":
+				col[i] = time.UnixMicro(int64(le.Uint64(values[8*i:]))).UTC()
+			case "duration[3]":
+				col[i] = time.Duration(le.Uint64(values[8*i:]))
+			default:
+				require.Failf(t, "unexpected column type", "%s", typ)
+			}
+		}
+		require.Zero(t, nulls, "null count does not match validity")
+
+		columns = append(columns, col)
+	}
+	require.Equal(t, nBuffers, bi)
+
+	return columns
+}
+
+func TestArrow_Render(t *testing.T) {
+	joined := time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	note := "hi"
+
+	tests := []struct {
+		name       string
+		value      any
+		writeErr   error
+		wantFields []string
+		want       [][]any
+		wantErr    string
+		wantErrIs  []error
+	}{
+		{
+			name: "slice of structs",
+			value: []*arrowTestRow{
+				{
+					Name:   "John",
+					Age:    42,
+					Small:  -3,
+					Count:  7,
+					Score:  1.5,
+					Ratio:  0.25,
+					Active: true,
+					Joined: joined,
+					Took:   90 * time.Second,
+					Note:   &note,
+					Level:  1,
+					Skip:   "skip",
+				},
+				nil,
+				{Name: "Jane", Age: -1},
+			},
+			wantFields: []string{
+				"name: utf8",
+				"Age: int64",
+				"Small: int8",
+				"Count: uint16",
+				"Score: float32",
+				"Ratio: float64",
+				"Active: bool",
+				"Joined: timestamp[2, UTC]",
+				"Took: duration[3]",
+				"Note: utf8",
+				"Level: utf8",
+			},
+			want: [][]any{
+				{"John", "Jane"},
+				{int64(42), int64(-1)},
+				{int64(-3), int64(0)},
+				{uint64(7), uint64(0)},
+				{1.5, 0.0},
+				{0.25, 0.0},
+				{true, false},
+				{joined, nil},
+				{90 * time.Second, time.Duration(0)},
+				{"hi", nil},
+				{"high", "low"},
+			},
+		},
+		{
+			name:       "single struct",
+			value:      struct{ ID int32 }{ID: 5},
+			wantFields: []string{"ID: int32"},
+			want:       [][]any{{int64(5)}},
+		},
+		{
+			name:       "empty slice",
+			value:      []struct{ OK bool }{},
+			wantFields: []string{"OK: bool"},
+			want:       [][]any{{}},
+		},
+		{
+			name:      "not struct based",
+			value:     []int{1, 2},
+			wantErr:   "render: cannot render: []int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "write error",
+			value:     struct{ ID int }{},
+			writeErr:  assert.AnError,
+			wantErr:   "render: failed: " + assert.AnError.Error(),
+			wantErrIs: []error{Err, ErrFailed, assert.AnError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockWriter{WriteErr: tt.writeErr}
+			h := &Arrow{}
+
+			err := h.Render(w, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				require.NoError(t, err)
+
+				fields, columns := readArrowStream(t, []byte(w.String()))
+				assert.Equal(t, tt.wantFields, fields)
+				assert.Equal(t, tt.want, columns)
+			}
+		})
+	}
+}
+
+// TestArrow_Render_pyarrow compares the output of the Arrow handler with a
+// stream written by pyarrow, so the handler is checked against an independent
+// implementation of the format, and not only the readers in this package.
+func TestArrow_Render_pyarrow(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/arrow/rows.arrows")
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skip("fixture missing, run: python3 testdata/arrow/generate.py")
+	}
+	require.NoError(t, err)
+
+	note := "hi"
+	value := []arrowTestRow{
+		{
+			Name:   "John",
+			Age:    42,
+			Small:  -3,
+			Count:  7,
+			Score:  1.5,
+			Ratio:  0.25,
+			Active: true,
+			Joined: time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC),
+			Took:   90 * time.Second,
+			Note:   &note,
+		},
+		{Name: "Jane", Age: -1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&Arrow{}).Render(&buf, value))
+
+	wantFields, want := readArrowStream(t, fixture)
+	fields, columns := readArrowStream(t, buf.Bytes())
+
+	// The fixture has no column for the Level field, which is rendered as a
+	// Utf8 column of Stringer values, a conversion specific to this package.
+	require.Equal(t, "Level: utf8", fields[len(fields)-1])
+	assert.Equal(t, wantFields, fields[:len(fields)-1])
+	assert.Equal(t, want, columns[:len(columns)-1])
+}
+
+func TestArrow_Render_deterministic(t *testing.T) {
+	value := []arrowTestRow{{Name: "a"}, {Name: "b", Age: 1}}
+
+	var a, b bytes.Buffer
+	require.NoError(t, (&Arrow{}).Render(&a, value))
+	require.NoError(t, (&Arrow{}).Render(&b, value))
+
+	assert.Equal(t, a.Bytes(), b.Bytes())
+}
+
+func TestArrow_Formats(t *testing.T) {
+	h := &Arrow{}
+
+	assert.Equal(t, []string{"arrow", "arrows"}, h.Formats())
+}
@@ -0,0 +1,81 @@
+package render
+
+import "io"
+
+// Auto is a Handler that picks how to render a value based on its type, so
+// quick scripts and tools do not need to choose a format at all.
+//
+// Values which the Text handler can render, like strings, io.Reader, and
+// fmt.Stringer values, are rendered as text. All other values, like structs,
+// maps, and slices, are rendered with the Structured handler, pretty if it
+// supports it.
+type Auto struct {
+	// Text is the Handler used for values it can render. If it does not
+	// implement ProbeHandler, values are test rendered to io.Discard to check
+	// if it can render them. If nil, a Text handler is used.
+	Text Handler
+
+	// Structured is the Handler used for all other values. If nil, a JSON
+	// handler is used.
+	Structured Handler
+}
+
+var (
+	_ Handler          = (*Auto)(nil)
+	_ FormatsHandler   = (*Auto)(nil)
+	_ DescribedHandler = (*Auto)(nil)
+	_ OptionsHandler   = (*Auto)(nil)
+)
+
+// Render renders v as text if the Text handler can render it, and otherwise
+// renders it pretty with the Structured handler.
+func (a *Auto) Render(w io.Writer, v any) error {
+	text := a.Text
+	if text == nil {
+		text = &Text{}
+	}
+
+	if probe(text, v) == nil {
+		return text.Render(w, v)
+	}
+
+	structured := a.Structured
+	if structured == nil {
+		structured = &JSON{}
+	}
+
+	if x, ok := structured.(PrettyHandler); ok {
+		return x.RenderPretty(w, v)
+	}
+
+	return structured.Render(w, v)
+}
+
+// WithOptions returns a copy of the Auto handler, with the options applied to
+// the Text and Structured handlers if they implement OptionsHandler.
+func (a *Auto) WithOptions(opts *Options) Handler {
+	c := *a
+	if x, ok := c.Text.(OptionsHandler); ok {
+		c.Text = x.WithOptions(opts)
+	}
+
+	structured := c.Structured
+	if structured == nil {
+		structured = &JSON{}
+	}
+	if x, ok := structured.(OptionsHandler); ok {
+		c.Structured = x.WithOptions(opts)
+	}
+
+	return &c
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (a *Auto) Formats() []string {
+	return []string{"auto"}
+}
+
+// Description returns a short human-readable description of the format.
+func (a *Auto) Description() string {
+	return "Text or pretty JSON, based on the value"
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuto_Render(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       Handler
+		structured Handler
+		value      any
+		want       string
+		wantErr    string
+		wantErrIs  []error
+	}{
+		{
+			name:  "string",
+			value: "hello world",
+			want:  "hello world",
+		},
+		{
+			name:  "reader",
+			value: strings.NewReader("from reader"),
+			want:  "from reader",
+		},
+		{
+			name:  "error",
+			value: errors.New("oops"),
+			want:  "oops",
+		},
+		{
+			name:  "map",
+			value: map[string]int{"age": 30},
+			want:  "{\n  \"age\": 30\n}\n",
+		},
+		{
+			name: "struct",
+			value: struct {
+				Name string `json:"name"`
+			}{Name: "John"},
+			want: "{\n  \"name\": \"John\"\n}\n",
+		},
+		{
+			name:       "custom structured handler",
+			structured: &YAML{},
+			value:      map[string]int{"age": 30},
+			want:       "age: 30\n",
+		},
+		{
+			name:       "structured handler without pretty support",
+			structured: &mockHandler{output: "mock output"},
+			value:      []int{1, 2},
+			want:       "mock output",
+		},
+		{
+			name:  "custom text handler without probe support",
+			text:  &mockHandler{output: "mock text"},
+			value: []int{1, 2},
+			want:  "mock text",
+		},
+		{
+			name:      "structured handler error",
+			value:     map[string]any{"a": make(chan int)},
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Auto{Text: tt.text, Structured: tt.structured}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestAuto_WithOptions(t *testing.T) {
+	h := &Auto{}
+
+	got := h.WithOptions(&Options{Indent: "\t"})
+
+	assert.Equal(t, &Auto{Structured: &JSON{Indent: "\t"}}, got)
+	assert.Equal(t, &Auto{}, h)
+}
+
+func TestAuto_Formats(t *testing.T) {
+	h := &Auto{}
+
+	assert.Equal(t, []string{"auto"}, h.Formats())
+}
+
+func TestRenderer_Render_auto(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Base.Render(&buf, "auto", false, map[string]int{"age": 30})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"age\": 30\n}\n", buf.String())
+}
@@ -2,41 +2,115 @@ package render
 
 import (
 	"encoding"
+	"encoding/gob"
+	"encoding/hex"
 	"fmt"
 	"io"
 )
 
-// Binary can render values which implment the encoding.BinaryMarshaler
-// interface.
-type Binary struct{}
+// Binary can render values which implment the encoding.BinaryAppender or
+// encoding.BinaryMarshaler interfaces, []byte and string values, which are
+// written as is, as well as io.Reader values. io.ReadSeeker and io.ReaderAt
+// values are read from their start.
+//
+// When pretty rendering, a hex dump of the data is written instead, in the
+// same format as "hexdump -C", with hex and ASCII columns.
+type Binary struct {
+	// Gob encodes values of any other type with encoding/gob, instead of
+	// returning a ErrCannotRender error. This allows rendering arbitrary
+	// structs, which can be decoded with a gob.Decoder.
+	Gob bool
+}
 
 var (
-	_ Handler        = (*Binary)(nil)
-	_ FormatsHandler = (*Binary)(nil)
+	_ Handler            = (*Binary)(nil)
+	_ PrettyHandler      = (*Binary)(nil)
+	_ FormatsHandler     = (*Binary)(nil)
+	_ DescribedHandler   = (*Binary)(nil)
+	_ ContentTypeHandler = (*Binary)(nil)
+	_ OptionsHandler     = (*Binary)(nil)
 )
 
-// Render writes result of calling MarshalBinary() on v. If v does not implment
-// encoding.BinaryMarshaler the ErrCannotRander error will be returned.
+// Render writes result of calling AppendBinary() or MarshalBinary() on v, with
+// AppendBinary() appending into a pooled buffer. If v is a []byte or string, it
+// is written as is, and if it is a io.Reader, it is copied to w, reading
+// io.ReadSeeker and io.ReaderAt values from their start. If v is none of these,
+// it is encoded with encoding/gob if Gob is set, otherwise the ErrCannotRander
+// error will be returned. Partial writes to w are retried until all output has
+// been written.
 func (br *Binary) Render(w io.Writer, v any) error {
-	x, ok := v.(encoding.BinaryMarshaler)
-	if !ok {
-		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	w = &fullWriter{w: w}
+
+	var err error
+	switch x := v.(type) {
+	case binaryAppender:
+		err = writeAppended(w, x.AppendBinary)
+	case encoding.BinaryMarshaler:
+		var b []byte
+		b, err = x.MarshalBinary()
+		if err == nil {
+			_, err = w.Write(b)
+		}
+	case []byte:
+		_, err = w.Write(x)
+	case string:
+		_, err = io.WriteString(w, x)
+	case io.ReadSeeker, io.ReaderAt, io.Reader:
+		_, err = io.Copy(w, readFromStart(x))
+	default:
+		if !br.Gob {
+			return fmt.Errorf("%w: %T", ErrCannotRender, v)
+		}
+		err = gob.NewEncoder(w).Encode(v)
 	}
 
-	b, err := x.MarshalBinary()
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
 
-	_, err = w.Write(b)
-	if err != nil {
+	return nil
+}
+
+// RenderPretty writes a human-readable hex dump of the data Render would write
+// for v, with the offset, hex, and ASCII representation of every 16 bytes.
+func (br *Binary) RenderPretty(w io.Writer, v any) error {
+	d := hex.Dumper(w)
+	if err := br.Render(d, v); err != nil {
+		return err
+	}
+
+	if err := d.Close(); err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
 
 	return nil
 }
 
+// WithOptions returns a copy of the Binary handler with the "gob" parameter
+// applied to the Gob field, if it is a valid boolean.
+func (br *Binary) WithOptions(opts *Options) Handler {
+	useGob, ok := opts.boolParam("gob")
+	if !ok {
+		return br
+	}
+
+	c := *br
+	c.Gob = useGob
+
+	return &c
+}
+
 // Formats returns a list of format strings that this Handler supports.
 func (br *Binary) Formats() []string {
 	return []string{"binary", "bin"}
 }
+
+// Description returns a short human-readable description of the format.
+func (br *Binary) Description() string {
+	return "Raw binary data"
+}
+
+// ContentType returns the MIME type of the format.
+func (br *Binary) ContentType() string {
+	return "application/octet-stream"
+}
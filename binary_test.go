@@ -1,11 +1,15 @@
 package render
 
 import (
+	"bytes"
 	"encoding"
+	"encoding/gob"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockBinaryMarshaler struct {
@@ -19,6 +23,24 @@ func (mbm *mockBinaryMarshaler) MarshalBinary() ([]byte, error) {
 	return mbm.data, mbm.err
 }
 
+type mockBinaryAppender struct {
+	data []byte
+	err  error
+}
+
+var (
+	_ binaryAppender           = (*mockBinaryAppender)(nil)
+	_ encoding.BinaryMarshaler = (*mockBinaryAppender)(nil)
+)
+
+func (mba *mockBinaryAppender) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, mba.data...), mba.err
+}
+
+func (mba *mockBinaryAppender) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("MarshalBinary called")
+}
+
 func TestBinary_Render(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -48,6 +70,62 @@ func TestBinary_Render(t *testing.T) {
 			wantErr:   "render: failed: marshal error!!1",
 			wantErrIs: []error{Err, ErrFailed},
 		},
+		{
+			name:  "implements encoding.BinaryAppender",
+			value: &mockBinaryAppender{data: []byte("appended")},
+			want:  "appended",
+		},
+		{
+			name: "error appending",
+			value: &mockBinaryAppender{
+				data: []byte("appended"),
+				err:  errors.New("append error!!1"),
+			},
+			wantErr:   "render: failed: append error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "implements io.ReadSeeker",
+			value: partiallyRead(bytes.NewReader([]byte("test string")), 5),
+			want:  "test string",
+		},
+		{
+			name:  "implements io.ReaderAt",
+			value: &mockReaderAt{value: "test string"},
+			want:  "test string",
+		},
+		{
+			name:      "io.ReaderAt error",
+			value:     &mockReaderAt{err: errors.New("ReadAt error!!1")},
+			wantErr:   "render: failed: ReadAt error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "implements io.Reader",
+			value: &mockReader{value: "test string"},
+			want:  "test string",
+		},
+		{
+			name:      "io.Reader error",
+			value:     &mockReader{err: errors.New("Read error!!1")},
+			wantErr:   "render: failed: Read error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "byte slice",
+			value: []byte("raw\x00bytes"),
+			want:  "raw\x00bytes",
+		},
+		{
+			name:  "nil byte slice",
+			value: []byte(nil),
+			want:  "",
+		},
+		{
+			name:  "string",
+			value: "raw string",
+			want:  "raw string",
+		},
 		{
 			name:      "error writing to writer",
 			writeErr:  errors.New("write error!!1"),
@@ -79,6 +157,130 @@ func TestBinary_Render(t *testing.T) {
 	}
 }
 
+func TestBinary_RenderPretty(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeErr  error
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "single line",
+			value: &mockBinaryMarshaler{data: []byte("hi\x00\xff")},
+			want: "00000000  68 69 00 ff" +
+				"                                       |hi..|\n",
+		},
+		{
+			name:  "multiple lines",
+			value: bytes.NewReader([]byte("0123456789abcdefXYZ")),
+			want: "00000000  30 31 32 33 34 35 36 37  " +
+				"38 39 61 62 63 64 65 66  |0123456789abcdef|\n" +
+				"00000010  58 59 5a" +
+				"                                          |XYZ|\n",
+		},
+		{
+			name:  "empty",
+			value: &mockBinaryMarshaler{data: []byte{}},
+			want:  "",
+		},
+		{
+			name:      "cannot render",
+			value:     struct{}{},
+			wantErr:   "render: cannot render: struct {}",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "error writing to writer",
+			writeErr:  errors.New("write error!!1"),
+			value:     &mockBinaryMarshaler{data: []byte("test string")},
+			wantErr:   "render: failed: write error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Binary{}
+			w := &mockWriter{WriteErr: tt.writeErr}
+
+			err := b.RenderPretty(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBinary_Gob(t *testing.T) {
+	type point struct {
+		X, Y int
+		Name string
+	}
+
+	t.Run("encodes unsupported types", func(t *testing.T) {
+		b := &Binary{Gob: true}
+		var buf bytes.Buffer
+
+		err := b.Render(&buf, point{X: 1, Y: 2, Name: "a"})
+		require.NoError(t, err)
+
+		var got point
+		err = gob.NewDecoder(&buf).Decode(&got)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 1, Y: 2, Name: "a"}, got)
+	})
+
+	t.Run("supported types are not gob encoded", func(t *testing.T) {
+		b := &Binary{Gob: true}
+		var buf bytes.Buffer
+
+		err := b.Render(&buf, "raw")
+		require.NoError(t, err)
+		assert.Equal(t, "raw", buf.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		b := &Binary{}
+
+		err := b.Render(&bytes.Buffer{}, point{})
+		assert.ErrorIs(t, err, ErrCannotRender)
+	})
+
+	t.Run("gob error", func(t *testing.T) {
+		b := &Binary{Gob: true}
+
+		err := b.Render(&bytes.Buffer{}, make(chan int))
+		assert.EqualError(t, err,
+			"render: failed: gob NewTypeObject can't handle type: chan int",
+		)
+		assert.ErrorIs(t, err, ErrFailed)
+	})
+
+	t.Run("param", func(t *testing.T) {
+		_, err := Base.String("binary", false, point{})
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+		got, err := Base.String("binary;gob", false, point{X: 3})
+		require.NoError(t, err)
+
+		var p point
+		err = gob.NewDecoder(strings.NewReader(got)).Decode(&p)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 3}, p)
+	})
+}
+
 func TestBinary_Formats(t *testing.T) {
 	h := &Binary{}
 
@@ -0,0 +1,59 @@
+// Package clirender integrates the render package with CLI apps built with
+// github.com/urfave/cli, without depending on it.
+//
+// Format flags returned by NewFormat implement the cli.Generic interface, and
+// can be used as the value of a cli.GenericFlag. Render then renders the
+// result of a command to the app's writer in the selected format:
+//
+//	format := clirender.NewFormat(nil, "text")
+//
+//	app := &cli.App{
+//		Flags: []cli.Flag{
+//			&cli.GenericFlag{
+//				Name:  "output",
+//				Value: format,
+//				Usage: format.Usage("output format"),
+//			},
+//		},
+//		Action: func(c *cli.Context) error {
+//			return clirender.Render(c.App.Writer, format, false, result)
+//		},
+//	}
+package clirender
+
+import (
+	"io"
+
+	"github.com/jimeh/go-render"
+)
+
+// Generic is the interface of cli.Generic flag values.
+type Generic interface {
+	Set(value string) error
+	String() string
+}
+
+var _ Generic = (*render.FormatFlag)(nil)
+
+// NewFormat returns a format flag value for formats supported by r, with value
+// as the default format. If r is nil, the render.Default renderer is used.
+func NewFormat(r *render.Renderer, value string) *render.FormatFlag {
+	return render.NewFormatFlag(r, value)
+}
+
+// Render renders v to w in the format selected by the given format flag,
+// using the flag's Renderer, or render.Default if it has none. Typically w is
+// the Writer of the cli.App.
+func Render(
+	w io.Writer,
+	format *render.FormatFlag,
+	pretty bool,
+	v any,
+	opts ...render.Option,
+) error {
+	if format.Renderer == nil {
+		return render.Render(w, format.Value, pretty, v, opts...)
+	}
+
+	return format.Renderer.Render(w, format.Value, pretty, v, opts...)
+}
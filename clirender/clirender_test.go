@@ -0,0 +1,80 @@
+package clirender
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormat(t *testing.T) {
+	var f Generic = NewFormat(nil, "text")
+
+	assert.Equal(t, "text", f.String())
+	require.NoError(t, f.Set("yaml"))
+	assert.Equal(t, "yaml", f.String())
+
+	err := f.Set("xml")
+	assert.ErrorIs(t, err, render.ErrUnsupportedFormat)
+	assert.Equal(t, "yaml", f.String())
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		renderer  *render.Renderer
+		format    string
+		pretty    bool
+		value     any
+		want      string
+		wantErrIs []error
+	}{
+		{
+			name:   "default renderer",
+			format: "json",
+			value:  map[string]int{"a": 1},
+			want:   `{"a":1}` + "\n",
+		},
+		{
+			name:   "pretty",
+			format: "json",
+			pretty: true,
+			value:  map[string]int{"a": 1},
+			want:   "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:     "custom renderer",
+			renderer: render.NewWith("xml"),
+			format:   "xml",
+			value: struct {
+				XMLName struct{} `xml:"a"`
+			}{},
+			want: "<a></a>",
+		},
+		{
+			name:      "unsupported value",
+			format:    "text",
+			value:     map[string]int{"a": 1},
+			wantErrIs: []error{render.ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFormat(tt.renderer, "text")
+			require.NoError(t, f.Set(tt.format))
+			var buf bytes.Buffer
+
+			err := Render(&buf, f, tt.pretty, tt.value)
+
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			if len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package render
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// CloudEventDefaultSource is the default event source used by CloudEvent
+// instances if no Source value is set on the CloudEvent instance itself.
+var CloudEventDefaultSource = "urn:go-render"
+
+// CloudEvent is a Handler that wraps values in a CloudEvents 1.0
+// (https://cloudevents.io) JSON envelope, with the value as the event data.
+type CloudEvent struct {
+	// Source identifies the context in which events happened. If empty,
+	// CloudEventDefaultSource is used.
+	Source string
+
+	// Type is the type of events. If empty, the Go type name of the rendered
+	// value is used, for example "main.User".
+	Type string
+
+	// ID returns the ID of each event. If nil, a random UUID is used.
+	ID func(v any) string
+
+	// Now returns the time of each event. If nil, time.Now is used.
+	Now func() time.Time
+
+	// Prefix is the prefix added to each level of indentation when pretty
+	// rendering.
+	Prefix string
+
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, the Indent of the JSON handler is used.
+	Indent string
+
+	// JSON is the handler events are encoded with, which allows configuring
+	// options like NoEscapeHTML. If nil, a JSON handler with default options
+	// is used.
+	JSON *JSON
+}
+
+var (
+	_ Handler            = (*CloudEvent)(nil)
+	_ PrettyHandler      = (*CloudEvent)(nil)
+	_ FormatsHandler     = (*CloudEvent)(nil)
+	_ DescribedHandler   = (*CloudEvent)(nil)
+	_ ContentTypeHandler = (*CloudEvent)(nil)
+	_ OptionsHandler     = (*CloudEvent)(nil)
+)
+
+type cloudEventEnvelope struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            any    `json:"data"`
+}
+
+// Render wraps v in a CloudEvents envelope, and marshals it to JSON.
+func (ce *CloudEvent) Render(w io.Writer, v any) error {
+	env, err := ce.envelope(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(ce.JSON, ce.Prefix, ce.Indent).Render(w, env)
+}
+
+// RenderPretty wraps v in a CloudEvents envelope, and marshals it to JSON with
+// line breaks and indentation.
+func (ce *CloudEvent) RenderPretty(w io.Writer, v any) error {
+	env, err := ce.envelope(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(ce.JSON, ce.Prefix, ce.Indent).RenderPretty(w, env)
+}
+
+// WithOptions returns a copy of the CloudEvent handler, with the options
+// applied to its JSON handler.
+func (ce *CloudEvent) WithOptions(opts *Options) Handler {
+	jr := jsonWith(ce.JSON, ce.Prefix, ce.Indent)
+
+	c := *ce
+	c.JSON, _ = jr.WithOptions(opts).(*JSON)
+	c.Prefix = ""
+	c.Indent = ""
+
+	return &c
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (ce *CloudEvent) Formats() []string {
+	return []string{"cloudevent", "cloudevents"}
+}
+
+// Description returns a short human-readable description of the format.
+func (ce *CloudEvent) Description() string {
+	return "CloudEvents 1.0 JSON envelope"
+}
+
+// ContentType returns the MIME type of the format.
+func (ce *CloudEvent) ContentType() string {
+	return "application/cloudevents+json"
+}
+
+func (ce *CloudEvent) envelope(v any) (*cloudEventEnvelope, error) {
+	source := ce.Source
+	if source == "" {
+		source = CloudEventDefaultSource
+	}
+
+	typ := ce.Type
+	if typ == "" {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil {
+			return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+		}
+		typ = t.String()
+	}
+
+	var id string
+	if ce.ID != nil {
+		id = ce.ID(v)
+	} else {
+		var err error
+		id, err = newUUID()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	}
+
+	now := time.Now
+	if ce.Now != nil {
+		now = ce.Now
+	}
+
+	return &cloudEventEnvelope{
+		SpecVersion:     "1.0",
+		ID:              id,
+		Source:          source,
+		Type:            typ,
+		Time:            now().UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            v,
+	}, nil
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf(
+		"%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:],
+	), nil
+}
@@ -0,0 +1,231 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cloudEventTestUser struct {
+	Name string `json:"name"`
+}
+
+func TestCloudEvent_Render(t *testing.T) {
+	now := func() time.Time {
+		return time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	}
+	id := func(any) string { return "abc-123" }
+
+	tests := []struct {
+		name      string
+		handler   *CloudEvent
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:    "default source and type",
+			handler: &CloudEvent{ID: id, Now: now},
+			value:   &cloudEventTestUser{Name: "John"},
+			want: `{"specversion":"1.0","id":"abc-123",` +
+				`"source":"urn:go-render",` +
+				`"type":"render.cloudEventTestUser",` +
+				`"time":"2024-03-01T12:30:00Z",` +
+				`"datacontenttype":"application/json",` +
+				`"data":{"name":"John"}}` + "\n",
+		},
+		{
+			name: "custom source and type",
+			handler: &CloudEvent{
+				Source: "/cli/users",
+				Type:   "com.example.user.created",
+				ID:     id,
+				Now:    now,
+			},
+			value: []string{"a", "b"},
+			want: `{"specversion":"1.0","id":"abc-123",` +
+				`"source":"/cli/users",` +
+				`"type":"com.example.user.created",` +
+				`"time":"2024-03-01T12:30:00Z",` +
+				`"datacontenttype":"application/json",` +
+				`"data":["a","b"]}` + "\n",
+		},
+		{
+			name: "id from value",
+			handler: &CloudEvent{
+				ID: func(v any) string {
+					return v.(*cloudEventTestUser).Name
+				},
+				Now: now,
+			},
+			value: &cloudEventTestUser{Name: "Jane"},
+			want: `{"specversion":"1.0","id":"Jane",` +
+				`"source":"urn:go-render",` +
+				`"type":"render.cloudEventTestUser",` +
+				`"time":"2024-03-01T12:30:00Z",` +
+				`"datacontenttype":"application/json",` +
+				`"data":{"name":"Jane"}}` + "\n",
+		},
+		{
+			name:    "pretty",
+			handler: &CloudEvent{Type: "t", ID: id, Now: now},
+			pretty:  true,
+			value:   42,
+			want: `{
+  "specversion": "1.0",
+  "id": "abc-123",
+  "source": "urn:go-render",
+  "type": "t",
+  "time": "2024-03-01T12:30:00Z",
+  "datacontenttype": "application/json",
+  "data": 42
+}
+`,
+		},
+		{
+			name:      "nil without type",
+			handler:   &CloudEvent{ID: id, Now: now},
+			value:     nil,
+			wantErr:   "render: cannot render: <nil>",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = tt.handler.RenderPretty(&buf, tt.value)
+			} else {
+				err = tt.handler.Render(&buf, tt.value)
+			}
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCloudEvent_Render_generatedID(t *testing.T) {
+	h := &CloudEvent{}
+	ids := map[string]bool{}
+
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		err := h.Render(&buf, "hello")
+		require.NoError(t, err)
+
+		var env map[string]any
+		err = json.Unmarshal(buf.Bytes(), &env)
+		require.NoError(t, err)
+
+		id, _ := env["id"].(string)
+		assert.Regexp(t, regexp.MustCompile(
+			`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-`+
+				`[0-9a-f]{12}$`,
+		), id)
+		ids[id] = true
+	}
+
+	assert.Len(t, ids, 2)
+}
+
+func TestCloudEvent_Formats(t *testing.T) {
+	h := &CloudEvent{}
+
+	assert.Equal(t, []string{"cloudevent", "cloudevents"}, h.Formats())
+}
+
+func TestCloudEvent_WithOptions(t *testing.T) {
+	h := &CloudEvent{Source: "/cli", Type: "t", Indent: "\t"}
+
+	got := h.WithOptions(&Options{
+		Prefix: "//",
+		Params: map[string]string{"escape_html": "false"},
+	})
+
+	assert.Equal(t,
+		&CloudEvent{
+			Source: "/cli",
+			Type:   "t",
+			JSON:   &JSON{Prefix: "//", Indent: "\t", NoEscapeHTML: true},
+		},
+		got,
+	)
+	assert.Equal(t, &CloudEvent{Source: "/cli", Type: "t", Indent: "\t"}, h)
+}
+
+func TestRenderer_Render_cloudEventOptions(t *testing.T) {
+	r := New(map[string]Handler{
+		"cloudevent": &CloudEvent{
+			Type: "t",
+			ID:   func(any) string { return "1" },
+			Now: func() time.Time {
+				return time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+			},
+		},
+	})
+	value := map[string]string{"q": "a&b"}
+
+	tests := []struct {
+		name   string
+		format string
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "escapes HTML by default",
+			format: "cloudevent",
+			want: `{"specversion":"1.0","id":"1","source":"urn:go-render",` +
+				`"type":"t","time":"2024-03-01T12:30:00Z",` +
+				`"datacontenttype":"application/json",` +
+				`"data":{"q":"a\u0026b"}}` + "\n",
+		},
+		{
+			name:   "format parameters",
+			format: "cloudevent;escape_html=false;sort_keys",
+			want: `{"data":{"q":"a&b"},` +
+				`"datacontenttype":"application/json","id":"1",` +
+				`"source":"urn:go-render","specversion":"1.0",` +
+				`"time":"2024-03-01T12:30:00Z","type":"t"}` + "\n",
+		},
+		{
+			name:   "WithIndent",
+			format: "cloudevent",
+			opts:   []Option{WithPretty(), WithIndent(" ")},
+			want: "{\n \"specversion\": \"1.0\",\n \"id\": \"1\",\n" +
+				" \"source\": \"urn:go-render\",\n \"type\": \"t\",\n" +
+				" \"time\": \"2024-03-01T12:30:00Z\",\n" +
+				" \"datacontenttype\": \"application/json\",\n" +
+				" \"data\": {\n  \"q\": \"a\\u0026b\"\n }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, value, tt.opts...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
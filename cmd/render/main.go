@@ -0,0 +1,97 @@
+// Command render reads a document from stdin in one format, and renders it to
+// stdout in another format, using the handlers of the render package.
+//
+// Usage:
+//
+//	render [-f format] [-t format] [-pretty] [-q query] [-list]
+//
+// For example, to convert YAML to pretty JSON:
+//
+//	render -f yaml -t json --pretty < config.yaml
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jimeh/go-render"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments, returning the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(),
+			"Usage: render [-f format] [-t format] [-pretty] [-q query] "+
+				"[-list]\n\n"+
+				"Reads a document from stdin in one format, and renders it "+
+				"to stdout in another.\n\n",
+		)
+		fs.PrintDefaults()
+	}
+
+	var from, to, query string
+	var pretty, list bool
+	fs.StringVar(&from, "f", "json", "format to read from stdin")
+	fs.StringVar(&from, "from", "json", "alias for -f")
+	fs.StringVar(&to, "t", "json", "format to render to stdout")
+	fs.StringVar(&to, "to", "json", "alias for -t")
+	fs.BoolVar(&pretty, "pretty", false, "render pretty output")
+	fs.StringVar(&query, "q", "", "jq style query to apply before rendering")
+	fs.StringVar(&query, "query", "", "alias for -q")
+	fs.BoolVar(&list, "list", false, "list supported formats and exit")
+
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	if list {
+		listFormats(stdout)
+
+		return 0
+	}
+
+	var opts []render.Option
+	if pretty {
+		opts = append(opts, render.WithPretty())
+	}
+	if query != "" {
+		opts = append(opts, render.WithQuery(query))
+	}
+
+	err = render.Base.Convert(stdout, to, stdin, from, opts...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return 1
+	}
+
+	return 0
+}
+
+// listFormats writes all formats supported by the Base renderer to w.
+func listFormats(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, f := range render.Base.Formats() {
+		name := f.Name
+		if len(f.Aliases) > 0 {
+			name += " (" + strings.Join(f.Aliases, ", ") + ")"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\n", name, f.Description)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_run(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "defaults to json",
+			stdin:      `{"b": 2, "a": 1}`,
+			wantStdout: `{"a":1,"b":2}` + "\n",
+		},
+		{
+			name:       "yaml to pretty json",
+			args:       []string{"-f", "yaml", "-t", "json", "--pretty"},
+			stdin:      "a: [1, 2]\n",
+			wantStdout: "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n",
+		},
+		{
+			name:       "long flags",
+			args:       []string{"--from", "json", "--to", "yml"},
+			stdin:      `{"a": 1}`,
+			wantStdout: "a: 1\n",
+		},
+		{
+			name:       "query",
+			args:       []string{"-q", ".items[].name"},
+			stdin:      `{"items": [{"name": "a"}, {"name": "b"}]}`,
+			wantStdout: `["a","b"]` + "\n",
+		},
+		{
+			name:     "invalid query",
+			args:     []string{"--query", "items"},
+			stdin:    `{}`,
+			wantCode: 1,
+			wantStderr: "render: failed: query: \"items\": " +
+				"expected '.' at position 1\n",
+		},
+		{
+			name:       "unsupported format",
+			args:       []string{"-t", "toml"},
+			stdin:      `{}`,
+			wantCode:   1,
+			wantStderr: "render: unsupported format: toml\n",
+		},
+		{
+			name:       "invalid input",
+			args:       []string{"-f", "json", "-t", "yaml"},
+			stdin:      `{`,
+			wantCode:   1,
+			wantStderr: "render: failed: unexpected EOF\n",
+		},
+		{
+			name:     "invalid flag",
+			args:     []string{"-x"},
+			wantCode: 2,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			if tt.wantStderr != "" {
+				assert.Equal(t, tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func Test_run_list(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-list"}, strings.NewReader(""), &stdout, &stderr)
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "json ")
+	assert.Contains(t, stdout.String(), "yaml (yml)")
+	assert.Empty(t, stderr.String())
+}
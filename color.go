@@ -0,0 +1,275 @@
+package render
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences used to syntax-highlight pretty rendered output.
+const (
+	colorReset  = "\x1b[0m"
+	colorKey    = "\x1b[1;34m"
+	colorString = "\x1b[32m"
+	colorNumber = "\x1b[36m"
+	colorBool   = "\x1b[33m"
+	colorNull   = "\x1b[90m"
+)
+
+// writeColored writes s to buf wrapped in the given ANSI color sequence.
+func writeColored(buf *bytes.Buffer, color string, s []byte) {
+	buf.WriteString(color)
+	buf.Write(s)
+	buf.WriteString(colorReset)
+}
+
+// jsonColorize returns a copy of the JSON document b with object keys,
+// strings, numbers, booleans, and nulls highlighted with ANSI colors. All
+// other bytes, like punctuation and whitespace, are copied as is.
+func jsonColorize(b []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(b) * 2)
+
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c == '"':
+			j := i + 1
+			for j < len(b) && b[j] != '"' {
+				if b[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j++; j > len(b) {
+				j = len(b)
+			}
+
+			color := colorString
+			if rest := bytes.TrimLeft(b[j:], " \t\r\n"); len(rest) > 0 &&
+				rest[0] == ':' {
+				color = colorKey
+			}
+			writeColored(&buf, color, b[i:j])
+			i = j
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(b) && strings.IndexByte("+-.eE0123456789", b[j]) >= 0 {
+				j++
+			}
+			writeColored(&buf, colorNumber, b[i:j])
+			i = j
+		case bytes.HasPrefix(b[i:], []byte("true")):
+			writeColored(&buf, colorBool, b[i:i+4])
+			i += 4
+		case bytes.HasPrefix(b[i:], []byte("false")):
+			writeColored(&buf, colorBool, b[i:i+5])
+			i += 5
+		case bytes.HasPrefix(b[i:], []byte("null")):
+			writeColored(&buf, colorNull, b[i:i+4])
+			i += 4
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// yamlColorize returns a copy of the block style YAML document b with mapping
+// keys, strings, numbers, booleans, and nulls highlighted with ANSI colors.
+// The contents of literal and folded block scalars are highlighted as
+// strings. Indicators, anchors, aliases, tags, and comments are copied as is.
+func yamlColorize(b []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(b) * 2)
+
+	// block is the indentation of the node which started a block scalar, if
+	// the current line may be within one, or -1 otherwise.
+	block := -1
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		content := bytes.TrimSuffix(line, []byte("\n"))
+		eol := line[len(content):]
+		text := bytes.TrimLeft(content, " ")
+		col := len(content) - len(text)
+
+		if block >= 0 {
+			if len(text) == 0 || col > block {
+				buf.Write(content[:col])
+				if len(text) > 0 {
+					writeColored(&buf, colorString, text)
+				}
+				buf.Write(eol)
+
+				continue
+			}
+			block = -1
+		}
+
+		buf.Write(content[:col])
+		if bytes.Equal(text, []byte("---")) ||
+			bytes.Equal(text, []byte("...")) ||
+			bytes.HasPrefix(text, []byte("#")) {
+			buf.Write(text)
+			buf.Write(eol)
+
+			continue
+		}
+
+		for bytes.HasPrefix(text, []byte("- ")) ||
+			bytes.Equal(text, []byte("-")) {
+			block = col
+			n := len(text)
+			if n > 2 {
+				n = 2
+			}
+			buf.Write(text[:n])
+			text = text[n:]
+			col += n
+		}
+
+		if key, value, ok := yamlKey(text); ok {
+			writeColored(&buf, colorKey, key)
+			buf.WriteByte(':')
+			text = value
+			block = col
+		}
+
+		if !yamlColorizeValue(&buf, text) {
+			block = -1
+		}
+		buf.Write(eol)
+	}
+
+	return buf.Bytes()
+}
+
+// yamlKey splits the mapping key from the start of the line text s, returning
+// the key and everything after its ":" indicator. If s does not start with a
+// mapping key, ok is false.
+func yamlKey(s []byte) (key, rest []byte, ok bool) {
+	end := 0
+	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+		end = yamlQuotedEnd(s)
+		if end >= len(s) || s[end] != ':' {
+			return nil, nil, false
+		}
+	} else {
+		end = bytes.Index(s, []byte(": "))
+		if end < 0 {
+			if !bytes.HasSuffix(s, []byte(":")) {
+				return nil, nil, false
+			}
+			end = len(s) - 1
+		}
+	}
+	if end == 0 || (end+1 < len(s) && s[end+1] != ' ') {
+		return nil, nil, false
+	}
+
+	return s[:end], s[end+1:], true
+}
+
+// yamlQuotedEnd returns the index just after the closing quote of the quoted
+// scalar at the start of s, or len(s) if it is not closed.
+func yamlQuotedEnd(s []byte) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+// yamlColorizeValue writes the value s which follows a mapping key or sequence
+// indicator to buf, highlighted by its type. It reports if s starts a block
+// scalar, so following more indented lines are part of it.
+func yamlColorizeValue(buf *bytes.Buffer, s []byte) bool {
+	text := bytes.TrimLeft(s, " ")
+	buf.Write(s[:len(s)-len(text)])
+	if len(text) == 0 {
+		return false
+	}
+
+	switch text[0] {
+	case '&', '*', '!':
+		// Anchors, aliases, and tags are followed by the value, if any.
+		i := bytes.IndexByte(text, ' ')
+		if i < 0 {
+			buf.Write(text)
+
+			return false
+		}
+		buf.Write(text[:i])
+
+		return yamlColorizeValue(buf, text[i:])
+	case '|', '>':
+		buf.Write(text)
+
+		return true
+	case '{', '[', '#':
+		buf.Write(text)
+
+		return false
+	case '"', '\'':
+		writeColored(buf, colorString, text)
+
+		return false
+	}
+
+	writeColored(buf, yamlScalarColor(string(text)), text)
+
+	return false
+}
+
+// yamlScalarColor returns the color of the plain YAML scalar s, based on the
+// type it resolves to.
+func yamlScalarColor(s string) string {
+	switch s {
+	case "null", "Null", "NULL", "~":
+		return colorNull
+	case "true", "True", "TRUE", "false", "False", "FALSE":
+		return colorBool
+	}
+
+	if yamlIsNumber(s) {
+		return colorNumber
+	}
+
+	return colorString
+}
+
+// yamlIsNumber reports if the plain YAML scalar s resolves to an integer or
+// float.
+func yamlIsNumber(s string) bool {
+	switch strings.ToLower(s) {
+	case ".inf", "+.inf", "-.inf", ".nan":
+		return true
+	}
+	if s == "" || strings.IndexByte("+-.0123456789", s[0]) < 0 {
+		return false
+	}
+
+	s = strings.ReplaceAll(s, "_", "")
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return true
+	}
+	lower := strings.ToLower(s)
+	if strings.Contains(lower, "inf") || strings.Contains(lower, "nan") {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+
+	return err == nil
+}
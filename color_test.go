@@ -0,0 +1,119 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONColorize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "object",
+			in:   "{\n  \"a\": \"b\",\n  \"n\": -1.5e3\n}\n",
+			want: "{\n  \x1b[1;34m\"a\"\x1b[0m: \x1b[32m\"b\"\x1b[0m,\n" +
+				"  \x1b[1;34m\"n\"\x1b[0m: \x1b[36m-1.5e3\x1b[0m\n}\n",
+		},
+		{
+			name: "array of literals",
+			in:   "[true, false, null]",
+			want: "[\x1b[33mtrue\x1b[0m, \x1b[33mfalse\x1b[0m, " +
+				"\x1b[90mnull\x1b[0m]",
+		},
+		{
+			name: "escaped quotes",
+			in:   `{"a\"": "b\\"}`,
+			want: "{\x1b[1;34m\"a\\\"\"\x1b[0m: \x1b[32m\"b\\\\\"\x1b[0m}",
+		},
+		{
+			name: "string with colon",
+			in:   `["a", ":"]`,
+			want: "[\x1b[32m\"a\"\x1b[0m, \x1b[32m\":\"\x1b[0m]",
+		},
+		{
+			name: "unterminated string",
+			in:   `"abc`,
+			want: "\x1b[32m\"abc\x1b[0m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jsonColorize([]byte(tt.in))
+
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestYAMLColorize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "scalars",
+			in: "a: b\nn: 1.5\nok: true\nnil: null\ns: \"123\"\n" +
+				"q: 'a: b'\ninf: .inf\nw: inf\n",
+			want: "\x1b[1;34ma\x1b[0m: \x1b[32mb\x1b[0m\n" +
+				"\x1b[1;34mn\x1b[0m: \x1b[36m1.5\x1b[0m\n" +
+				"\x1b[1;34mok\x1b[0m: \x1b[33mtrue\x1b[0m\n" +
+				"\x1b[1;34mnil\x1b[0m: \x1b[90mnull\x1b[0m\n" +
+				"\x1b[1;34ms\x1b[0m: \x1b[32m\"123\"\x1b[0m\n" +
+				"\x1b[1;34mq\x1b[0m: \x1b[32m'a: b'\x1b[0m\n" +
+				"\x1b[1;34minf\x1b[0m: \x1b[36m.inf\x1b[0m\n" +
+				"\x1b[1;34mw\x1b[0m: \x1b[32minf\x1b[0m\n",
+		},
+		{
+			name: "nested",
+			in:   "list:\n  - x\n  - k: 0x1F\n    e: []\n",
+			want: "\x1b[1;34mlist\x1b[0m:\n" +
+				"  - \x1b[32mx\x1b[0m\n" +
+				"  - \x1b[1;34mk\x1b[0m: \x1b[36m0x1F\x1b[0m\n" +
+				"    \x1b[1;34me\x1b[0m: []\n",
+		},
+		{
+			name: "quoted key",
+			in:   "\"n\": 1\n'a: b': c\n",
+			want: "\x1b[1;34m\"n\"\x1b[0m: \x1b[36m1\x1b[0m\n" +
+				"\x1b[1;34m'a: b'\x1b[0m: \x1b[32mc\x1b[0m\n",
+		},
+		{
+			name: "block scalars",
+			in:   "a:\n  - |-\n    x: 1\n  - y\nb: >-\n  z\n\n  w\nc: d\n",
+			want: "\x1b[1;34ma\x1b[0m:\n" +
+				"  - |-\n" +
+				"    \x1b[32mx: 1\x1b[0m\n" +
+				"  - \x1b[32my\x1b[0m\n" +
+				"\x1b[1;34mb\x1b[0m: >-\n" +
+				"  \x1b[32mz\x1b[0m\n" +
+				"\n" +
+				"  \x1b[32mw\x1b[0m\n" +
+				"\x1b[1;34mc\x1b[0m: \x1b[32md\x1b[0m\n",
+		},
+		{
+			name: "anchors and aliases",
+			in:   "a: &a1\n  b: 1\nc: *a1\nd: !!binary aGk=\n",
+			want: "\x1b[1;34ma\x1b[0m: &a1\n" +
+				"  \x1b[1;34mb\x1b[0m: \x1b[36m1\x1b[0m\n" +
+				"\x1b[1;34mc\x1b[0m: *a1\n" +
+				"\x1b[1;34md\x1b[0m: !!binary \x1b[32maGk=\x1b[0m\n",
+		},
+		{
+			name: "documents and comments",
+			in:   "# head\n1\n---\n- ~\n",
+			want: "# head\n\x1b[36m1\x1b[0m\n---\n- \x1b[90m~\x1b[0m\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := yamlColorize([]byte(tt.in))
+
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// contentLineMaxOctets is the maximum length of a content line in octets, as
+// defined by RFC 5545 and RFC 6350, excluding the line break.
+const contentLineMaxOctets = 75
+
+// writeContentLine writes a single content line in the form used by iCalendar
+// and vCard documents, folding it across multiple lines if needed, and
+// terminating it with CRLF.
+//
+// The value is written as is, and must already be escaped if needed.
+func writeContentLine(buf *strings.Builder, name, value string) {
+	line := name + ":" + value
+
+	limit := contentLineMaxOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		buf.WriteString(line[:i])
+		buf.WriteString("\r\n ")
+		line = line[i:]
+
+		// Continuation lines start with a space, which counts towards the
+		// line length.
+		limit = contentLineMaxOctets - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// contentLineEscaper escapes TEXT values in iCalendar and vCard documents.
+var contentLineEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes s for use as a TEXT value in iCalendar and vCard
+// documents.
+func escapeText(s string) string {
+	return contentLineEscaper.Replace(s)
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeContentLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		prop  string
+		value string
+		want  string
+	}{
+		{
+			name:  "short line",
+			prop:  "SUMMARY",
+			value: "Hello",
+			want:  "SUMMARY:Hello\r\n",
+		},
+		{
+			name:  "exactly 75 octets",
+			prop:  "SUMMARY",
+			value: strings.Repeat("a", 67),
+			want:  "SUMMARY:" + strings.Repeat("a", 67) + "\r\n",
+		},
+		{
+			name:  "folded line",
+			prop:  "SUMMARY",
+			value: strings.Repeat("a", 150),
+			want: "SUMMARY:" + strings.Repeat("a", 67) + "\r\n" +
+				" " + strings.Repeat("a", 74) + "\r\n" +
+				" " + strings.Repeat("a", 9) + "\r\n",
+		},
+		{
+			name:  "does not split multi-byte characters",
+			prop:  "SUMMARY",
+			value: strings.Repeat("a", 66) + "ééé",
+			want: "SUMMARY:" + strings.Repeat("a", 66) + "\r\n" +
+				" ééé\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+
+			writeContentLine(&buf, tt.prop, tt.value)
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func Test_escapeText(t *testing.T) {
+	got := escapeText("a\\b;c,d\ne\r\nf")
+
+	assert.Equal(t, `a\\b\;c\,d\ne\nf`, got)
+}
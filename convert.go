@@ -0,0 +1,43 @@
+package render
+
+import "io"
+
+// Convert decodes a value in srcFormat from src, and renders it to dst in
+// dstFormat, for example to convert JSON input to YAML. Options are applied to
+// the rendering of dstFormat as with Render, with WithPretty enabling pretty
+// rendering.
+//
+// The value is decoded into a plain any value, made up of maps, slices, and
+// scalar values. The Handler of srcFormat must implement ParseHandler,
+// otherwise a ErrUnsupportedFormat error is returned.
+func (r *Renderer) Convert(
+	dst io.Writer,
+	dstFormat string,
+	src io.Reader,
+	srcFormat string,
+	opts ...Option,
+) error {
+	if _, err := r.lookup(dstFormat); err != nil {
+		return err
+	}
+
+	var v any
+	err := r.Parse(src, srcFormat, &v)
+	if err != nil {
+		return err
+	}
+
+	return r.Render(dst, dstFormat, false, v, opts...)
+}
+
+// Convert is a convenience function that calls the Default renderer's Convert
+// method.
+func Convert(
+	dst io.Writer,
+	dstFormat string,
+	src io.Reader,
+	srcFormat string,
+	opts ...Option,
+) error {
+	return defaultRenderer().Convert(dst, dstFormat, src, srcFormat, opts...)
+}
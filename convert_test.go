@@ -0,0 +1,147 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Convert(t *testing.T) {
+	r := NewWith("json", "yaml", "text", "xml", "binary")
+
+	tests := []struct {
+		name      string
+		dstFormat string
+		src       string
+		srcFormat string
+		opts      []Option
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:      "json to yaml",
+			dstFormat: "yaml",
+			src:       `{"b":[1,2.5,"x"],"a":{"c":true,"d":null}}`,
+			srcFormat: "json",
+			want:      "a:\n  c: true\n  d: null\nb:\n  - 1\n  - 2.5\n  - x\n",
+		},
+		{
+			name:      "yaml to json",
+			dstFormat: "json",
+			src:       "a: 1\nb:\n  - x\n  - true\n",
+			srcFormat: "yml",
+			want:      `{"a":1,"b":["x",true]}` + "\n",
+		},
+		{
+			name:      "yaml with non-string keys to json",
+			dstFormat: "json",
+			src:       "1: one\ntrue: yes\n",
+			srcFormat: "yaml",
+			want:      `{"1":"one","true":"yes"}` + "\n",
+		},
+		{
+			name:      "yaml to pretty json",
+			dstFormat: "json",
+			src:       "a: 1\n",
+			srcFormat: "yaml",
+			opts:      []Option{WithPretty(), WithIndent("\t")},
+			want:      "{\n\t\"a\": 1\n}\n",
+		},
+		{
+			name:      "json to json",
+			dstFormat: "JSON",
+			src:       `[1, 2]`,
+			srcFormat: "json",
+			want:      "[1,2]\n",
+		},
+		{
+			name:      "unsupported destination format",
+			dstFormat: "toml",
+			src:       `{}`,
+			srcFormat: "json",
+			wantErr:   "render: unsupported format: toml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "unsupported source format",
+			dstFormat: "json",
+			src:       `{}`,
+			srcFormat: "toml",
+			wantErr:   "render: unsupported format: toml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "source format without parse support",
+			dstFormat: "json",
+			src:       "hello",
+			srcFormat: "binary",
+			wantErr:   "render: unsupported format: binary",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "source format which cannot decode into any",
+			dstFormat: "json",
+			src:       "<a>1</a>",
+			srcFormat: "xml",
+			wantErr:   "render: unsupported format: xml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "text to json",
+			dstFormat: "json",
+			src:       "hello",
+			srcFormat: "text",
+			want:      `"hello"` + "\n",
+		},
+		{
+			name:      "invalid source",
+			dstFormat: "yaml",
+			src:       `{"a":`,
+			srcFormat: "json",
+			wantErr:   "render: failed: unexpected EOF",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "value not supported by destination format",
+			dstFormat: "text",
+			src:       `{"a":1}`,
+			srcFormat: "json",
+			wantErr:   "render: unsupported format: text",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Convert(
+				&buf, tt.dstFormat, strings.NewReader(tt.src), tt.srcFormat,
+				tt.opts...,
+			)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Convert(&buf, "json", strings.NewReader("- 1\n- 2\n"), "yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]\n", buf.String())
+}
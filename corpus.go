@@ -0,0 +1,87 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// WriteCorpus renders each value in corpus with every format supported by the
+// Renderer, writing the output into a directory tree within dir. This enables
+// reviewing changes to rendered output across all formats, for example when
+// upgrading handlers or their dependencies.
+//
+// Each corpus value gets its own directory named after its key in corpus,
+// containing a "<format>.golden" file with compact output, and for handlers
+// which support pretty rendering, a "<format>.pretty.golden" file with pretty
+// output. Only the canonical name of each format is used, aliases are skipped.
+//
+// Formats which do not support a given value, failing with a
+// ErrUnsupportedFormat error, are skipped, as most formats only support some
+// kinds of values. Any existing file for a skipped format is removed, so it
+// does not keep stale output. All other errors, including ErrLint errors from
+// Linters rejecting the output, are returned. Existing files are overwritten.
+func (r *Renderer) WriteCorpus(dir string, corpus map[string]any) error {
+	names := make([]string, 0, len(corpus))
+	for name := range corpus {
+		if name == "" || name == "." || name == ".." ||
+			filepath.Base(name) != name {
+			return fmt.Errorf("%w: invalid corpus name: %q", ErrFailed, name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := r.formatGroups()
+
+	for _, name := range names {
+		valueDir := filepath.Join(dir, name)
+		//nolint:gosec // golden files are intended to be readable.
+		if err := os.MkdirAll(valueDir, 0o755); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+
+		for _, g := range groups {
+			modes := []bool{false}
+			if _, ok := g.handler.(PrettyHandler); ok {
+				modes = append(modes, true)
+			}
+
+			for _, pretty := range modes {
+				file := g.name + ".golden"
+				if pretty {
+					file = g.name + ".pretty.golden"
+				}
+
+				path := filepath.Join(valueDir, file)
+
+				var buf bytes.Buffer
+				err := r.Render(&buf, g.name, pretty, corpus[name])
+				if errors.Is(err, ErrUnsupportedFormat) ||
+					errors.Is(err, ErrCannotRender) {
+					err = os.Remove(path)
+					if err != nil && !errors.Is(err, os.ErrNotExist) {
+						return fmt.Errorf("%w: %w", ErrFailed, err)
+					}
+
+					continue
+				} else if err != nil {
+					return fmt.Errorf(
+						"%w: corpus %s/%s", err, name, file,
+					)
+				}
+
+				//nolint:gosec // golden files are intended to be readable.
+				err = os.WriteFile(path, buf.Bytes(), 0o644)
+				if err != nil {
+					return fmt.Errorf("%w: %w", ErrFailed, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package render
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readCorpusDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	walk := func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(b)
+
+		return nil
+	}
+	require.NoError(t, filepath.WalkDir(dir, walk))
+
+	return files
+}
+
+func TestRenderer_WriteCorpus(t *testing.T) {
+	r := Base.NewWith("json", "text", "yaml")
+	dir := t.TempDir()
+
+	err := r.WriteCorpus(dir, map[string]any{
+		"map":    map[string]int{"age": 30},
+		"string": "hello",
+	})
+	require.NoError(t, err)
+
+	got := readCorpusDir(t, dir)
+
+	want := map[string]string{
+		"map/json.golden":           "{\"age\":30}\n",
+		"map/json.pretty.golden":    "{\n  \"age\": 30\n}\n",
+		"map/yaml.golden":           "age: 30\n",
+		"map/yaml.pretty.golden":    "age: 30\n",
+		"string/json.golden":        "\"hello\"\n",
+		"string/json.pretty.golden": "\"hello\"\n",
+		"string/text.golden":        "hello",
+		"string/text.pretty.golden": "hello",
+		"string/yaml.golden":        "hello\n",
+		"string/yaml.pretty.golden": "hello\n",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestRenderer_WriteCorpus_skipsUnsupported(t *testing.T) {
+	r := Base.NewWith("json", "ics", "table")
+	dir := t.TempDir()
+
+	stale := filepath.Join(dir, "name", "table.golden")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o755))
+	require.NoError(t, os.WriteFile(stale, []byte("stale"), 0o600))
+
+	err := r.WriteCorpus(dir, map[string]any{"name": "jim"})
+	require.NoError(t, err)
+
+	got := readCorpusDir(t, dir)
+
+	want := map[string]string{
+		"name/json.golden":        "\"jim\"\n",
+		"name/json.pretty.golden": "\"jim\"\n",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestRenderer_WriteCorpus_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		renderer  *Renderer
+		corpus    map[string]any
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:      "invalid name with path separator",
+			renderer:  Base.NewWith("json"),
+			corpus:    map[string]any{"../foo": "bar"},
+			wantErr:   `render: failed: invalid corpus name: "../foo"`,
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "empty name",
+			renderer:  Base.NewWith("json"),
+			corpus:    map[string]any{"": "bar"},
+			wantErr:   `render: failed: invalid corpus name: ""`,
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name: "render error",
+			renderer: New(map[string]Handler{
+				"mock": &mockHandler{err: errors.New("boom")},
+			}),
+			corpus:    map[string]any{"foo": "bar"},
+			wantErr:   "render: failed: boom: corpus foo/mock.golden",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:     "value not supported by handler",
+			renderer: Base.NewWith("json"),
+			corpus:   map[string]any{"foo": func() {}},
+			wantErr: "render: failed: json: unsupported type: func(): " +
+				"corpus foo/json.golden",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name: "lint error",
+			renderer: func() *Renderer {
+				r := Base.NewWith("json")
+				r.Linters = []Linter{func(string, []byte) error {
+					return errors.New("boom")
+				}}
+
+				return r
+			}(),
+			corpus:    map[string]any{"foo": "bar"},
+			wantErr:   "render: failed: lint: boom: corpus foo/json.golden",
+			wantErrIs: []error{Err, ErrFailed, ErrLint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.renderer.WriteCorpus(t.TempDir(), tt.corpus)
+
+			assert.EqualError(t, err, tt.wantErr)
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSV is a Handler that renders structs, and slices or arrays of structs, as
+// comma-separated values, with a header row followed by one record per struct.
+//
+// Each exported field is rendered as a column, with the header taken from the
+// "csv" struct tag if present, or the field name otherwise. Fields tagged with
+// `csv:"-"` are skipped. Values are rendered with their String method if
+// available, time.Time values as RFC 3339, and all other values with
+// fmt.Sprint. Nil values are rendered as empty fields.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type CSV struct {
+	// Delimiter is the field delimiter. If zero, a comma is used. A CSV
+	// handler with a tab delimiter supports the "tsv" format instead of
+	// "csv".
+	Delimiter rune
+
+	// CRLF ends records with "\r\n" instead of "\n", as expected by some
+	// spreadsheets and legacy importers.
+	CRLF bool
+
+	// NoHeader omits the header row.
+	NoHeader bool
+
+	// Quote controls when fields are quoted. By default they are only quoted
+	// when required.
+	Quote CSVQuote
+}
+
+// CSVQuote is a style for quoting fields with the CSV handler.
+type CSVQuote int
+
+const (
+	// CSVQuoteMinimal only quotes fields which contain the delimiter, quotes,
+	// or line breaks, or start with a space.
+	CSVQuoteMinimal CSVQuote = iota
+
+	// CSVQuoteAll quotes all fields.
+	CSVQuoteAll
+)
+
+var csvQuoteParams = map[string]CSVQuote{
+	"minimal": CSVQuoteMinimal,
+	"all":     CSVQuoteAll,
+}
+
+var (
+	_ Handler            = (*CSV)(nil)
+	_ FormatsHandler     = (*CSV)(nil)
+	_ DescribedHandler   = (*CSV)(nil)
+	_ ContentTypeHandler = (*CSV)(nil)
+	_ OptionsHandler     = (*CSV)(nil)
+)
+
+// Render writes v as CSV records to w. Partial writes to w are retried until
+// all output has been written.
+func (c *CSV) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	delim := c.delimiter()
+	if !csvValidDelimiter(delim) {
+		return fmt.Errorf("%w: invalid csv delimiter: %q", ErrFailed, delim)
+	}
+
+	fields := structFields(t, "csv")
+	records := make([][]string, 0, len(rows)+1)
+	if !c.NoHeader {
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = f.name
+		}
+		records = append(records, header)
+	}
+	for _, row := range rows {
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i] = fieldText(f.value(row))
+		}
+		records = append(records, record)
+	}
+
+	w = &fullWriter{w: w}
+
+	var err error
+	if c.Quote == CSVQuoteAll {
+		err = c.writeQuoted(w, records)
+	} else {
+		cw := csv.NewWriter(w)
+		cw.Comma = delim
+		cw.UseCRLF = c.CRLF
+		err = cw.WriteAll(records)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// writeQuoted writes records to w with all fields quoted.
+func (c *CSV) writeQuoted(w io.Writer, records [][]string) error {
+	eol := "\n"
+	if c.CRLF {
+		eol = "\r\n"
+	}
+
+	var b strings.Builder
+	for _, record := range records {
+		for i, field := range record {
+			if i > 0 {
+				b.WriteRune(c.delimiter())
+			}
+			if c.CRLF {
+				field = strings.ReplaceAll(field, "\r\n", "\n")
+				field = strings.ReplaceAll(field, "\n", "\r\n")
+			}
+			b.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`)
+		}
+		b.WriteString(eol)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func (c *CSV) delimiter() rune {
+	if c.Delimiter == 0 {
+		return ','
+	}
+
+	return c.Delimiter
+}
+
+// csvValidDelimiter reports if r can be used as a field delimiter, matching
+// the rules of encoding/csv.
+func csvValidDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' &&
+		utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// WithOptions returns a copy of the CSV handler with the "delimiter" parameter
+// applied to the Delimiter field, if it is a single character, like
+// "csv;delimiter=|" or "csv;delimiter=\t". The "crlf" parameter sets the CRLF
+// field, and the "header" parameter the inverse of the NoHeader field, if
+// they are valid booleans. The "quote" parameter sets the Quote field to
+// CSVQuoteMinimal or CSVQuoteAll for the values "minimal" or "all".
+func (c *CSV) WithOptions(opts *Options) Handler {
+	var delim rune
+	if s := opts.Params["delimiter"]; utf8.RuneCountInString(s) == 1 {
+		delim, _ = utf8.DecodeRuneInString(s)
+	}
+	crlf, hasCRLF := opts.boolParam("crlf")
+	header, hasHeader := opts.boolParam("header")
+	quote, hasQuote := csvQuoteParams[opts.Params["quote"]]
+	if delim == 0 && !hasCRLF && !hasHeader && !hasQuote {
+		return c
+	}
+
+	cp := *c
+	if delim != 0 {
+		cp.Delimiter = delim
+	}
+	if hasCRLF {
+		cp.CRLF = crlf
+	}
+	if hasHeader {
+		cp.NoHeader = !header
+	}
+	if hasQuote {
+		cp.Quote = quote
+	}
+
+	return &cp
+}
+
+// Formats returns a list of format strings that this Handler supports, which
+// is "tsv" if the delimiter is a tab, and "csv" otherwise.
+func (c *CSV) Formats() []string {
+	if c.delimiter() == '\t' {
+		return []string{"tsv"}
+	}
+
+	return []string{"csv"}
+}
+
+// Description returns a short human-readable description of the format.
+func (c *CSV) Description() string {
+	if c.delimiter() == '\t' {
+		return "Tab-separated values"
+	}
+
+	return "Comma-separated values"
+}
+
+// ContentType returns the MIME type of the format.
+func (c *CSV) ContentType() string {
+	if c.delimiter() == '\t' {
+		return "text/tab-separated-values; charset=utf-8"
+	}
+
+	return "text/csv; charset=utf-8"
+}
@@ -0,0 +1,185 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvTestRow struct {
+	Name    string `csv:"name"`
+	Age     int    `csv:"age"`
+	Joined  time.Time
+	Note    *string
+	Skipped string `csv:"-"`
+}
+
+func TestCSV_Render(t *testing.T) {
+	note := "says \"hi\"\nthere"
+	joined := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+	value := []csvTestRow{
+		{Name: "Alice", Age: 30, Joined: joined, Note: &note},
+		{Name: "Bob, Jr.", Age: 4, Skipped: "x"},
+	}
+
+	tests := []struct {
+		name      string
+		csv       *CSV
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "defaults",
+			csv:   &CSV{},
+			value: value,
+			want: "name,age,Joined,Note\n" +
+				"Alice,30,2024-03-25T12:00:00Z,\"says \"\"hi\"\"\nthere\"\n" +
+				"\"Bob, Jr.\",4,,\n",
+		},
+		{
+			name:  "single struct",
+			csv:   &CSV{},
+			value: &csvTestRow{Name: "a"},
+			want:  "name,age,Joined,Note\na,0,,\n",
+		},
+		{
+			name:  "tab delimiter",
+			csv:   &CSV{Delimiter: '\t'},
+			value: value[1:],
+			want:  "name\tage\tJoined\tNote\nBob, Jr.\t4\t\t\n",
+		},
+		{
+			name:  "semicolon delimiter",
+			csv:   &CSV{Delimiter: ';'},
+			value: value[1:],
+			want:  "name;age;Joined;Note\nBob, Jr.;4;;\n",
+		},
+		{
+			name:  "crlf",
+			csv:   &CSV{CRLF: true},
+			value: value,
+			want: "name,age,Joined,Note\r\n" +
+				"Alice,30,2024-03-25T12:00:00Z," +
+				"\"says \"\"hi\"\"\r\nthere\"\r\n" +
+				"\"Bob, Jr.\",4,,\r\n",
+		},
+		{
+			name:  "no header",
+			csv:   &CSV{NoHeader: true},
+			value: value[1:],
+			want:  "\"Bob, Jr.\",4,,\n",
+		},
+		{
+			name:  "quote all",
+			csv:   &CSV{Quote: CSVQuoteAll},
+			value: value,
+			want: "\"name\",\"age\",\"Joined\",\"Note\"\n" +
+				"\"Alice\",\"30\",\"2024-03-25T12:00:00Z\"," +
+				"\"says \"\"hi\"\"\nthere\"\n" +
+				"\"Bob, Jr.\",\"4\",\"\",\"\"\n",
+		},
+		{
+			name: "quote all with crlf and tabs",
+			csv: &CSV{
+				Quote:     CSVQuoteAll,
+				CRLF:      true,
+				Delimiter: '\t',
+				NoHeader:  true,
+			},
+			value: value[:1],
+			want: "\"Alice\"\t\"30\"\t\"2024-03-25T12:00:00Z\"\t" +
+				"\"says \"\"hi\"\"\r\nthere\"\r\n",
+		},
+		{
+			name:      "invalid delimiter",
+			csv:       &CSV{Delimiter: '"'},
+			value:     value,
+			wantErr:   "render: failed: invalid csv delimiter: '\"'",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "not struct based",
+			csv:       &CSV{},
+			value:     []int{1},
+			wantErr:   "render: cannot render: []int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := tt.csv.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestCSV_Render_writeError(t *testing.T) {
+	for _, quote := range []CSVQuote{CSVQuoteMinimal, CSVQuoteAll} {
+		c := &CSV{Quote: quote}
+		w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+		err := c.Render(w, csvTestRow{Name: "a"})
+
+		assert.EqualError(t, err, "render: failed: write error!!1")
+		assert.ErrorIs(t, err, ErrFailed)
+	}
+}
+
+func TestRenderer_Render_csvParams(t *testing.T) {
+	value := []csvTestRow{{Name: "a", Age: 1}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "csv", want: "name,age,Joined,Note\na,1,,\n"},
+		{format: "tsv", want: "name\tage\tJoined\tNote\na\t1\t\t\n"},
+		{format: "csv;delimiter=|", want: "name|age|Joined|Note\na|1||\n"},
+		{
+			format: "csv;delimiter=\\t",
+			want:   "name\tage\tJoined\tNote\na\t1\t\t\n",
+		},
+		{format: "csv;header=false", want: "a,1,,\n"},
+		{format: "csv;header=false;crlf", want: "a,1,,\r\n"},
+		{format: "tsv;header=0;quote=all", want: "\"a\"\t\"1\"\t\"\"\t\"\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := Base.String(tt.format, false, value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCSV_Formats(t *testing.T) {
+	assert.Equal(t, []string{"csv"}, (&CSV{}).Formats())
+	assert.Equal(t, []string{"tsv"}, (&CSV{Delimiter: '\t'}).Formats())
+}
+
+func TestCSV_ContentType(t *testing.T) {
+	assert.Equal(t, "text/csv; charset=utf-8", (&CSV{}).ContentType())
+	assert.Equal(t,
+		"text/tab-separated-values; charset=utf-8",
+		(&CSV{Delimiter: '\t'}).ContentType(),
+	)
+}
@@ -0,0 +1,115 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// DumpDefaultIndent is the default indentation string used by Dump instances
+// when pretty rendering if no Indent value is set on the Dump instance itself.
+var DumpDefaultIndent = "  "
+
+// Dump is a Handler that renders arbitrary values in a debug format, showing
+// the types, pointers, and unexported fields of all values recursively. It is
+// intended for troubleshooting data structures, and the output format is not
+// stable.
+//
+// Map keys are sorted, so output is deterministic as long as pointer
+// addresses are disabled.
+type Dump struct {
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, DumpDefaultIndent will be used.
+	Indent string
+
+	// MaxDepth is the maximum depth of nested values to render. If zero,
+	// there is no limit.
+	MaxDepth int
+
+	// DisablePointerAddresses disables the rendering of pointer addresses.
+	DisablePointerAddresses bool
+
+	// DisableCapacities disables the rendering of the capacity of arrays,
+	// slices, maps and channels.
+	DisableCapacities bool
+}
+
+var (
+	_ Handler          = (*Dump)(nil)
+	_ PrettyHandler    = (*Dump)(nil)
+	_ FormatsHandler   = (*Dump)(nil)
+	_ DescribedHandler = (*Dump)(nil)
+)
+
+// Render writes a single line debug representation of v to w.
+func (d *Dump) Render(w io.Writer, v any) error {
+	// The "+" flag adds pointer addresses when using spew's formatter.
+	format := "%#+v\n"
+	if d.DisablePointerAddresses {
+		format = "%#v\n"
+	}
+
+	_, err := d.config().Fprintf(w, format, v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// RenderPretty writes a multi-line debug representation of v to w, with each
+// nested value on its own line.
+func (d *Dump) RenderPretty(w io.Writer, v any) error {
+	fw := &dumpWriter{w: w}
+	d.config().Fdump(fw, v)
+	if fw.err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, fw.err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (d *Dump) Formats() []string {
+	return []string{"dump", "debug"}
+}
+
+// Description returns a short human-readable description of the format.
+func (d *Dump) Description() string {
+	return "Debug dump showing types, pointers and unexported fields"
+}
+
+func (d *Dump) config() *spew.ConfigState {
+	indent := d.Indent
+	if indent == "" {
+		indent = DumpDefaultIndent
+	}
+
+	return &spew.ConfigState{
+		Indent:                  indent,
+		MaxDepth:                d.MaxDepth,
+		DisablePointerAddresses: d.DisablePointerAddresses,
+		DisableCapacities:       d.DisableCapacities,
+		DisableMethods:          true,
+		SortKeys:                true,
+	}
+}
+
+// dumpWriter records the first error returned by w, as spew's Fdump does not
+// return write errors.
+type dumpWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (dw *dumpWriter) Write(p []byte) (int, error) {
+	if dw.err != nil {
+		return 0, dw.err
+	}
+
+	n, err := dw.w.Write(p)
+	dw.err = err
+
+	return n, err
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dumpTestUser struct {
+	Name   string
+	secret int
+	Tags   map[string]bool
+	Parent *dumpTestUser
+}
+
+func TestDump_Render(t *testing.T) {
+	value := &dumpTestUser{
+		Name:   "John",
+		secret: 42,
+		Tags:   map[string]bool{"b": true, "a": false},
+	}
+
+	tests := []struct {
+		name      string
+		handler   *Dump
+		pretty    bool
+		writeErr  error
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:    "compact",
+			handler: &Dump{DisablePointerAddresses: true},
+			value:   value,
+			want: `(*render.dumpTestUser){Name:(string)John ` +
+				`secret:(int)42 ` +
+				`Tags:(map[string]bool)map[a:false b:true] ` +
+				`Parent:(*render.dumpTestUser)<nil>}` + "\n",
+		},
+		{
+			name:    "pretty",
+			handler: &Dump{DisablePointerAddresses: true},
+			pretty:  true,
+			value:   value,
+			want: `(*render.dumpTestUser)({
+  Name: (string) (len=4) "John",
+  secret: (int) 42,
+  Tags: (map[string]bool) (len=2) {
+    (string) (len=1) "a": (bool) false,
+    (string) (len=1) "b": (bool) true
+  },
+  Parent: (*render.dumpTestUser)(<nil>)
+})
+`,
+		},
+		{
+			name: "pretty with custom indent and max depth",
+			handler: &Dump{
+				Indent:                  "\t",
+				MaxDepth:                1,
+				DisablePointerAddresses: true,
+				DisableCapacities:       true,
+			},
+			pretty: true,
+			value:  []any{[]int{1}, "a"},
+			want: "([]interface {}) (len=2) {\n" +
+				"\t([]int) (len=1) {\n" +
+				"\t\t<max depth reached>\n" +
+				"\t},\n" +
+				"\t(string) (len=1) \"a\"\n" +
+				"}\n",
+		},
+		{
+			name:      "write error",
+			handler:   &Dump{},
+			writeErr:  assert.AnError,
+			value:     1,
+			wantErr:   "render: failed: " + assert.AnError.Error(),
+			wantErrIs: []error{Err, ErrFailed, assert.AnError},
+		},
+		{
+			name:      "pretty write error",
+			handler:   &Dump{},
+			pretty:    true,
+			writeErr:  assert.AnError,
+			value:     1,
+			wantErr:   "render: failed: " + assert.AnError.Error(),
+			wantErrIs: []error{Err, ErrFailed, assert.AnError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockWriter{WriteErr: tt.writeErr}
+
+			var err error
+			if tt.pretty {
+				err = tt.handler.RenderPretty(w, tt.value)
+			} else {
+				err = tt.handler.Render(w, tt.value)
+			}
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDump_Render_pointerAddresses(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := (&Dump{}).RenderPretty(&buf, &dumpTestUser{})
+
+	assert.NoError(t, err)
+	assert.Regexp(
+		t, `^\(\*render\.dumpTestUser\)\(0x[0-9a-f]+\)\(`, buf.String(),
+	)
+}
+
+func TestDump_Formats(t *testing.T) {
+	h := &Dump{}
+
+	assert.Equal(t, []string{"dump", "debug"}, h.Formats())
+}
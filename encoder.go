@@ -0,0 +1,136 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Encoder renders multiple values to the same writer in a single format.
+//
+// If the Handler of the format implements EncoderHandler, the same underlying
+// format encoder is reused for all values, avoiding per value setup.
+// Otherwise each value is rendered with the Handler as with Renderer.Render.
+//
+// The PostProcessors of the Renderer are applied to the output of each value
+// individually, which is rendered to a buffer first if any are set. Unlike
+// Renderer.Render, the Header and Linters of the Renderer are not applied.
+type Encoder struct {
+	r       *Renderer
+	w       io.Writer
+	buf     *bytes.Buffer
+	format  string
+	pretty  bool
+	opts    *Options
+	handler Handler
+	enc     ValueEncoder
+	err     error
+}
+
+// NewEncoder returns an Encoder which writes values to w in the given format.
+// The format and options are handled as with Render, with WithPretty, or a
+// "+pretty" modifier, enabling pretty rendering. Value transformations, like
+// WithFields and WithKeyCase, are applied to each encoded value.
+//
+// If the format is not supported, the error is returned by every call to
+// Encode.
+func (r *Renderer) NewEncoder(
+	w io.Writer,
+	format string,
+	opts ...Option,
+) *Encoder {
+	e := &Encoder{r: r, w: w}
+	e.format, e.pretty, e.opts = r.configure(format, false, opts)
+
+	out := w
+	if len(r.PostProcessors) > 0 {
+		e.buf = &bytes.Buffer{}
+		out = e.buf
+	}
+
+	e.handler, e.err = r.handler(e.format)
+	if e.err != nil {
+		return e
+	}
+	e.handler = withOptions(e.handler, e.opts)
+
+	if x, ok := e.handler.(EncoderHandler); ok {
+		e.enc = x.NewEncoder(out, e.pretty)
+	}
+
+	return e
+}
+
+// NewEncoder is a convenience function that calls the Default renderer's
+// NewEncoder method.
+func NewEncoder(w io.Writer, format string, opts ...Option) *Encoder {
+	return defaultRenderer().NewEncoder(w, format, opts...)
+}
+
+// Encode renders v to the underlying writer.
+func (e *Encoder) Encode(v any) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	v, err := e.opts.transform(e.r.prepare(v))
+	if err != nil {
+		return err
+	}
+
+	if e.enc == nil {
+		out := e.w
+		if e.buf != nil {
+			out = e.buf
+		}
+		err = e.r.render(out, e.handler, e.format, e.pretty, v)
+	} else {
+		err = renderError(e.format, e.encode(v))
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.flush()
+}
+
+func (e *Encoder) encode(v any) (err error) {
+	defer e.r.recoverPanic(e.format, &err)
+
+	return e.enc.Encode(v)
+}
+
+// flush writes any output in the buffer to the underlying writer, transformed
+// by the PostProcessors of the Renderer.
+func (e *Encoder) flush() error {
+	if e.buf == nil || e.buf.Len() == 0 {
+		return nil
+	}
+	defer e.buf.Reset()
+
+	output := e.buf.Bytes()
+	for _, process := range e.r.PostProcessors {
+		var err error
+		if output, err = process(e.format, output); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	}
+
+	if _, err := e.w.Write(output); err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Close closes the underlying format encoder if it requires it, flushing any
+// buffered output. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if c, ok := e.enc.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	}
+
+	return e.flush()
+}
@@ -0,0 +1,297 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_NewEncoder(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"xml":  &XML{},
+		"yaml": &YAML{},
+		"text": &Text{},
+		"mock": &mockPrettyHandler{
+			output:       "plain;",
+			prettyOutput: "pretty;",
+		},
+		"err": &mockHandler{err: errors.New("mock error")},
+	})
+
+	tests := []struct {
+		name      string
+		format    string
+		opts      []Option
+		values    []any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "json",
+			format: "json",
+			values: []any{map[string]int{"a": 1}, []int{1, 2}},
+			want:   "{\"a\":1}\n[1,2]\n",
+		},
+		{
+			name:   "json with WithPretty and WithIndent",
+			format: "json",
+			opts:   []Option{WithPretty(), WithIndent("\t")},
+			values: []any{map[string]int{"a": 1}, []int{1}},
+			want:   "{\n\t\"a\": 1\n}\n[\n\t1\n]\n",
+		},
+		{
+			name:   "json with pretty modifier",
+			format: "json+pretty",
+			values: []any{map[string]int{"a": 1}},
+			want:   "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:   "json with format parameters",
+			format: "json;indent=4",
+			opts:   []Option{WithPretty()},
+			values: []any{map[string]int{"a": 1}},
+			want:   "{\n    \"a\": 1\n}\n",
+		},
+		{
+			name:   "json with WithFields and WithKeyCase",
+			format: "json",
+			opts: []Option{
+				WithFields("userName"), WithKeyCase(SnakeCase),
+			},
+			values: []any{
+				map[string]any{"userName": "foo", "age": 1},
+				map[string]any{"userName": "bar", "age": 2},
+			},
+			want: "{\"user_name\":\"foo\"}\n{\"user_name\":\"bar\"}\n",
+		},
+		{
+			name:   "yaml with WithQuery",
+			format: "yaml",
+			opts:   []Option{WithQuery(".a")},
+			values: []any{map[string]int{"a": 1}, map[string]int{"a": 2}},
+			want:   "1\n---\n2\n",
+		},
+		{
+			name:   "xml",
+			format: "xml",
+			values: []any{
+				struct {
+					XMLName struct{} `xml:"a"`
+					B       int      `xml:"b"`
+				}{B: 1},
+				struct {
+					XMLName struct{} `xml:"a"`
+					B       int      `xml:"b"`
+				}{B: 2},
+			},
+			want: "<a><b>1</b></a><a><b>2</b></a>",
+		},
+		{
+			name:   "yaml",
+			format: "yaml",
+			values: []any{map[string]int{"a": 1}, map[string]int{"b": 2}},
+			want:   "a: 1\n---\nb: 2\n",
+		},
+		{
+			name:   "handler without encoder support",
+			format: "text",
+			values: []any{"foo", "bar"},
+			want:   "foobar",
+		},
+		{
+			name:   "WithPretty on handler without encoder support",
+			format: "mock",
+			opts:   []Option{WithPretty()},
+			values: []any{1, 2},
+			want:   "pretty;pretty;",
+		},
+		{
+			name:      "unsupported format",
+			format:    "unknown",
+			values:    []any{1},
+			wantErr:   "render: unsupported format: unknown",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "value not supported by handler",
+			format:    "json",
+			values:    []any{make(chan int)},
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "handler error",
+			format:    "err",
+			values:    []any{1},
+			wantErr:   "render: failed: mock error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := r.NewEncoder(&buf, tt.format, tt.opts...)
+
+			var err error
+			for _, v := range tt.values {
+				if err = enc.Encode(v); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = enc.Close()
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderer_NewEncoder_jsonOptions(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{NonFinite: JSONNonFiniteNull, PassThrough: true},
+	})
+
+	tests := []struct {
+		name   string
+		format string
+		values []any
+		want   string
+	}{
+		{
+			name:   "non-finite floats",
+			format: "json",
+			values: []any{math.NaN(), []float64{1, math.Inf(1)}},
+			want:   "null\n[1,null]\n",
+		},
+		{
+			name:   "pass through",
+			format: "json",
+			values: []any{`{"a":1}`, []byte(`[1, 2]`), "foo"},
+			want:   "{\"a\":1}\n[1,2]\n\"foo\"\n",
+		},
+		{
+			name:   "pass through pretty",
+			format: "json+pretty",
+			values: []any{`{"a":1}`},
+			want:   "{\n  \"a\": 1\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := r.NewEncoder(&buf, tt.format)
+
+			for _, v := range tt.values {
+				require.NoError(t, enc.Encode(v))
+			}
+			require.NoError(t, enc.Close())
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_NewEncoder_postProcessors(t *testing.T) {
+	r := Base.NewWith("json", "text")
+	r.PostProcessors = []PostProcessor{MinifyJSON(), PrefixLines("> ")}
+
+	tests := []struct {
+		name   string
+		format string
+		values []any
+		want   string
+	}{
+		{
+			name:   "encoder handler",
+			format: "json+pretty",
+			values: []any{map[string]int{"a": 1}, []int{1, 2}},
+			want:   "> {\"a\":1}\n> [1,2]\n",
+		},
+		{
+			name:   "handler without encoder support",
+			format: "text",
+			values: []any{"foo\n", "bar\n"},
+			want:   "> foo\n> bar\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := r.NewEncoder(&buf, tt.format)
+
+			for _, v := range tt.values {
+				require.NoError(t, enc.Encode(v))
+			}
+			require.NoError(t, enc.Close())
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_NewEncoder_postProcessorError(t *testing.T) {
+	r := Base.NewWith("json")
+	r.PostProcessors = []PostProcessor{
+		func(string, []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		},
+	}
+
+	var buf bytes.Buffer
+	err := r.NewEncoder(&buf, "json").Encode(1)
+
+	assert.EqualError(t, err, "render: failed: boom")
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.Empty(t, buf.String())
+}
+
+func TestRenderer_NewEncoder_unsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	enc := New(map[string]Handler{}).NewEncoder(&buf, "json")
+
+	for i := 0; i < 2; i++ {
+		err := enc.Encode(1)
+
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	}
+	assert.NoError(t, enc.Close())
+	assert.Empty(t, buf.String())
+}
+
+func TestRenderer_NewEncoder_writeError(t *testing.T) {
+	enc := NewEncoder(&mockWriter{WriteErr: errors.New("write error")}, "json")
+
+	err := enc.Encode(1)
+
+	require.Error(t, err)
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestNewEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, "yaml")
+
+	require.NoError(t, enc.Encode(map[string]int{"a": 1}))
+	require.NoError(t, enc.Encode([]int{1, 2}))
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, "a: 1\n---\n- 1\n- 2\n", buf.String())
+}
@@ -0,0 +1,188 @@
+package render
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// structField describes an exported field of a struct type, as used by
+// handlers which render structs as rows, records, or key/value pairs.
+type structField struct {
+	// name is the name of the field, taken from the struct tag if set,
+	// otherwise the Go field name.
+	name string
+
+	// index is the index sequence of the field for reflect.Value's
+	// FieldByIndexErr method.
+	index []int
+
+	// options are the comma separated options which followed the name in the
+	// struct tag. Options in "key=value" form are stored with their value,
+	// while plain options are stored with an empty value.
+	options map[string]string
+
+	// typ is the type of the field.
+	typ reflect.Type
+}
+
+// hasOption reports if the struct tag of the field included the given option.
+func (sf structField) hasOption(name string) bool {
+	_, ok := sf.options[name]
+
+	return ok
+}
+
+// value returns the value of the field within v, which must be a struct of the
+// type the field was obtained from. If the field is promoted through a nil
+// embedded pointer, an invalid reflect.Value is returned.
+func (sf structField) value(v reflect.Value) reflect.Value {
+	fv, err := v.FieldByIndexErr(sf.index)
+	if err != nil {
+		return reflect.Value{}
+	}
+
+	return fv
+}
+
+// structFields returns all exported fields of the struct type t, including
+// fields promoted from embedded structs. Field names are taken from the given
+// struct tag key if present, and fields tagged with "-" are skipped. XMLName
+// fields only name XML elements, so they are skipped unless tagKey is "xml".
+func structFields(t reflect.Type, tagKey string) []structField {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []structField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && isStructType(f.Type)) ||
+			(isXMLName(f) && tagKey != "xml") {
+			continue
+		}
+
+		tag := f.Tag.Get(tagKey)
+		if tag == "-" {
+			continue
+		}
+
+		sf := structField{
+			name:    f.Name,
+			index:   f.Index,
+			options: map[string]string{},
+			typ:     f.Type,
+		}
+
+		parts := strings.Split(tag, ",")
+		if parts[0] != "" {
+			sf.name = parts[0]
+		}
+		for _, opt := range parts[1:] {
+			key, value, _ := strings.Cut(opt, "=")
+			if key = strings.TrimSpace(key); key != "" {
+				sf.options[key] = strings.TrimSpace(value)
+			}
+		}
+
+		fields = append(fields, sf)
+	}
+
+	return fields
+}
+
+// isXMLName reports if f is a XMLName field, which names the XML element of
+// its struct.
+func isXMLName(f reflect.StructField) bool {
+	return f.Name == "XMLName" && f.Type == xmlNameType
+}
+
+// isStructType reports if t is a struct, or a pointer to a struct.
+func isStructType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// structRows returns the struct element type and a list of struct values from
+// v, which must be a struct, or a slice or array of structs, optionally via
+// pointers. Nil pointer elements are skipped. If v is not struct based, ok is
+// false.
+func structRows(v any) (t reflect.Type, rows []reflect.Value, ok bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		return rv.Type(), []reflect.Value{rv}, true
+	case reflect.Slice, reflect.Array:
+		t = rv.Type().Elem()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, false
+		}
+
+		rows = make([]reflect.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if ev := indirect(rv.Index(i)); ev.IsValid() {
+				rows = append(rows, ev)
+			}
+		}
+
+		return t, rows, true
+	}
+
+	return nil, nil, false
+}
+
+// fieldText returns the text of a field value for text based rows. Values are
+// rendered with their String method if available, time.Time values as RFC
+// 3339, and all other values with fmt.Sprint. Nil and zero time values are
+// rendered as an empty string.
+func fieldText(rv reflect.Value) string {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return ""
+	}
+
+	switch x := rv.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+
+		return x.Format(time.RFC3339)
+	case fmt.Stringer:
+		return x.String()
+	case []byte:
+		return string(x)
+	default:
+		if s, ok := addrStringer(rv); ok {
+			return s.String()
+		}
+
+		return fmt.Sprint(x)
+	}
+}
+
+// addrStringer returns rv as a fmt.Stringer if its pointer implements it.
+func addrStringer(rv reflect.Value) (fmt.Stringer, bool) {
+	if !rv.CanAddr() {
+		return nil, false
+	}
+	s, ok := rv.Addr().Interface().(fmt.Stringer)
+
+	return s, ok
+}
@@ -0,0 +1,203 @@
+package render
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldsTestEmbedded struct {
+	Embedded string
+}
+
+type fieldsTestStruct struct {
+	fieldsTestEmbedded
+	Name    string `test:"name"`
+	Age     int    `test:",wide,order=2"`
+	Skipped bool   `test:"-"`
+	private string
+}
+
+func Test_structFields(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		want []structField
+	}{
+		{
+			name: "not a struct",
+			typ:  reflect.TypeOf(42),
+			want: nil,
+		},
+		{
+			name: "struct",
+			typ:  reflect.TypeOf(fieldsTestStruct{}),
+			want: []structField{
+				{
+					name:    "Embedded",
+					index:   []int{0, 0},
+					options: map[string]string{},
+					typ:     reflect.TypeOf(""),
+				},
+				{
+					name:    "name",
+					index:   []int{1},
+					options: map[string]string{},
+					typ:     reflect.TypeOf(""),
+				},
+				{
+					name:    "Age",
+					index:   []int{2},
+					options: map[string]string{"wide": "", "order": "2"},
+					typ:     reflect.TypeOf(0),
+				},
+			},
+		},
+		{
+			name: "pointer to struct",
+			typ:  reflect.TypeOf(&struct{ Name string }{}),
+			want: []structField{
+				{
+					name:    "Name",
+					index:   []int{0},
+					options: map[string]string{},
+					typ:     reflect.TypeOf(""),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := structFields(tt.typ, "test")
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_structFields_xmlName(t *testing.T) {
+	typ := reflect.TypeOf(struct {
+		XMLName xml.Name `xml:"item"`
+		Name    string
+	}{})
+
+	assert.Equal(t, []string{"Name"}, fieldNames(structFields(typ, "csv")))
+	assert.Equal(t,
+		[]string{"item", "Name"}, fieldNames(structFields(typ, "xml")),
+	)
+}
+
+func fieldNames(fields []structField) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+
+	return names
+}
+
+func Test_structField_value(t *testing.T) {
+	type embedded struct{ Name string }
+	type outer struct{ *embedded }
+
+	fields := structFields(reflect.TypeOf(outer{}), "")
+
+	got := fields[0].value(reflect.ValueOf(outer{}))
+	assert.False(t, got.IsValid())
+
+	got = fields[0].value(reflect.ValueOf(outer{&embedded{Name: "foo"}}))
+	assert.Equal(t, "foo", got.Interface())
+}
+
+func Test_structRows(t *testing.T) {
+	type row struct{ Name string }
+
+	tests := []struct {
+		name     string
+		value    any
+		wantType reflect.Type
+		wantRows []any
+		wantOK   bool
+	}{
+		{
+			name:   "nil",
+			value:  nil,
+			wantOK: false,
+		},
+		{
+			name:   "nil pointer",
+			value:  (*row)(nil),
+			wantOK: false,
+		},
+		{
+			name:   "map",
+			value:  map[string]any{},
+			wantOK: false,
+		},
+		{
+			name:   "slice of strings",
+			value:  []string{"foo"},
+			wantOK: false,
+		},
+		{
+			name:     "struct",
+			value:    row{Name: "foo"},
+			wantType: reflect.TypeOf(row{}),
+			wantRows: []any{row{Name: "foo"}},
+			wantOK:   true,
+		},
+		{
+			name:     "struct pointer",
+			value:    &row{Name: "foo"},
+			wantType: reflect.TypeOf(row{}),
+			wantRows: []any{row{Name: "foo"}},
+			wantOK:   true,
+		},
+		{
+			name:     "slice of struct pointers skipping nil",
+			value:    []*row{{Name: "foo"}, nil, {Name: "bar"}},
+			wantType: reflect.TypeOf(row{}),
+			wantRows: []any{row{Name: "foo"}, row{Name: "bar"}},
+			wantOK:   true,
+		},
+		{
+			name:     "empty array",
+			value:    [0]row{},
+			wantType: reflect.TypeOf(row{}),
+			wantRows: []any{},
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, rows, ok := structRows(tt.value)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantType, typ)
+			if tt.wantOK {
+				got := make([]any, 0, len(rows))
+				for _, r := range rows {
+					got = append(got, r.Interface())
+				}
+				assert.Equal(t, tt.wantRows, got)
+			}
+		})
+	}
+}
+
+func Test_addrStringer(t *testing.T) {
+	s := mockStringer{value: "foo"}
+
+	got, ok := addrStringer(reflect.ValueOf(&s).Elem())
+	assert.True(t, ok)
+	assert.Equal(t, "foo", got.String())
+
+	_, ok = addrStringer(reflect.ValueOf(s))
+	assert.False(t, ok)
+
+	n := 42
+	_, ok = addrStringer(reflect.ValueOf(&n).Elem())
+	assert.False(t, ok)
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RenderFile renders v to the file at path, in the format matching the file
+// extension of path, as returned by FormatFromPath. If the extension does not
+// match a supported format, a ErrUnsupportedFormat error is returned.
+//
+// Output is written to a temporary file in the same directory, which is
+// renamed to path once rendering succeeds. This ensures path is never left
+// with partial output. If path already exists, its file mode is kept,
+// otherwise the file is created with mode 0644.
+func (r *Renderer) RenderFile(
+	path string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) error {
+	format, ok := r.FormatFromPath(path)
+	if !ok {
+		return fmt.Errorf(
+			"%w: %s", ErrUnsupportedFormat, filepath.Ext(path),
+		)
+	}
+
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(
+		filepath.Dir(path), "."+filepath.Base(path)+".*.tmp",
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	err = r.Render(f, format, pretty, v, opts...)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	err = f.Sync()
+	if err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	err = f.Close()
+	if err == nil {
+		err = os.Chmod(tmp, mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// RenderFile is a convenience function that calls the Default renderer's
+// RenderFile method.
+func RenderFile(path string, pretty bool, v any, opts ...Option) error {
+	return defaultRenderer().RenderFile(path, pretty, v, opts...)
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_RenderFile(t *testing.T) {
+	r := NewWith("json", "yaml")
+
+	tests := []struct {
+		name      string
+		file      string
+		existing  string
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "json",
+			file:  "out.json",
+			value: map[string]int{"a": 1},
+			want:  `{"a":1}` + "\n",
+		},
+		{
+			name:   "pretty json",
+			file:   "out.JSON",
+			pretty: true,
+			value:  map[string]int{"a": 1},
+			want:   "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:     "replaces existing file",
+			file:     "config.yml",
+			existing: "old: true\n",
+			value:    map[string]int{"a": 1},
+			want:     "a: 1\n",
+		},
+		{
+			name:      "unsupported extension",
+			file:      "out.xml",
+			value:     map[string]int{"a": 1},
+			wantErr:   "render: unsupported format: .xml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "render failure keeps existing file",
+			file:      "out.json",
+			existing:  "{}\n",
+			value:     make(chan<- int),
+			want:      "{}\n",
+			wantErr:   "render: failed: json: unsupported type: chan<- int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.file)
+			if tt.existing != "" {
+				err := os.WriteFile(path, []byte(tt.existing), 0o600)
+				require.NoError(t, err)
+			}
+
+			err := r.RenderFile(path, tt.pretty, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+			}
+
+			if tt.want != "" {
+				got, err := os.ReadFile(path)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(entries), 1, "temp file left behind")
+		})
+	}
+}
+
+func TestRenderer_RenderFile_fileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on windows")
+	}
+	dir := t.TempDir()
+	r := NewWith("json")
+
+	path := filepath.Join(dir, "new.json")
+	require.NoError(t, r.RenderFile(path, false, 1))
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), fi.Mode().Perm())
+
+	path = filepath.Join(dir, "existing.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+	require.NoError(t, r.RenderFile(path, false, 1))
+	fi, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+}
+
+func TestRenderer_RenderFile_missingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "out.json")
+
+	err := NewWith("json").RenderFile(path, false, 1)
+
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRenderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.yaml")
+
+	err := RenderFile(path, false, []int{1})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "- 1\n", string(got))
+}
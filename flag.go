@@ -0,0 +1,96 @@
+package render
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// FormatFlag is a command line flag value for selecting a format, which only
+// accepts formats supported by a Renderer. It implements the flag.Value
+// interface, as well as the pflag.Value interface used by cobra, so it can be
+// used with both:
+//
+//	format := render.NewFormatFlag(nil, "text")
+//	flag.Var(format, "output", format.Usage("output format"))
+//	cmd.Flags().VarP(format, "output", "o", format.Usage("output format"))
+//
+// After flags are parsed, the selected format is available from the Value
+// field, and can be passed directly to Render.
+type FormatFlag struct {
+	// Renderer is the Renderer which formats are validated against. If nil,
+	// the Default renderer is used.
+	Renderer *Renderer
+
+	// Value is the selected format.
+	Value string
+}
+
+var _ flag.Value = (*FormatFlag)(nil)
+
+// NewFormatFlag returns a FormatFlag for formats supported by r, with value as
+// the default format. If r is nil, the Default renderer is used.
+func NewFormatFlag(r *Renderer, value string) *FormatFlag {
+	return &FormatFlag{Renderer: r, Value: value}
+}
+
+// String returns the selected format.
+func (f *FormatFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Value
+}
+
+// Set sets the selected format. If the format is not supported by the
+// Renderer, a ErrUnsupportedFormat error listing supported formats is
+// returned.
+func (f *FormatFlag) Set(format string) error {
+	if !f.renderer().Supports(format) {
+		return fmt.Errorf(
+			"%w: %s (must be one of: %s)", ErrUnsupportedFormat, format,
+			strings.Join(f.Formats(), ", "),
+		)
+	}
+
+	f.Value = format
+
+	return nil
+}
+
+// Type returns the name of the flag value type, as shown in pflag help text.
+func (f *FormatFlag) Type() string {
+	return "format"
+}
+
+// Formats returns the names of all formats supported by the Renderer,
+// excluding aliases.
+func (f *FormatFlag) Formats() []string {
+	formats := f.renderer().Formats()
+	names := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, format.Name)
+	}
+
+	return names
+}
+
+// Usage returns description followed by a list of supported formats, for use
+// as the usage text of the flag, like "output format (json|text|yaml)".
+func (f *FormatFlag) Usage(description string) string {
+	formats := "(" + strings.Join(f.Formats(), "|") + ")"
+	if description == "" {
+		return formats
+	}
+
+	return description + " " + formats
+}
+
+func (f *FormatFlag) renderer() *Renderer {
+	if f.Renderer == nil {
+		return defaultRenderer()
+	}
+
+	return f.Renderer
+}
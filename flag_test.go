@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFlag_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		renderer  *Renderer
+		value     string
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "supported format",
+			value: "yaml",
+			want:  "yaml",
+		},
+		{
+			name:  "alias",
+			value: "YML",
+			want:  "YML",
+		},
+		{
+			name:  "unsupported format",
+			value: "xml",
+			want:  "text",
+			wantErr: "render: unsupported format: xml " +
+				"(must be one of: json, text, yaml)",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:     "custom renderer",
+			renderer: NewWith("xml"),
+			value:    "xml",
+			want:     "xml",
+		},
+		{
+			name:     "format not in custom renderer",
+			renderer: NewWith("xml", "json"),
+			value:    "yaml",
+			want:     "text",
+			wantErr: "render: unsupported format: yaml " +
+				"(must be one of: json, xml)",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFormatFlag(tt.renderer, "text")
+
+			err := f.Set(tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			assert.Equal(t, tt.want, f.Value)
+			assert.Equal(t, tt.want, f.String())
+		})
+	}
+}
+
+func TestFormatFlag_Usage(t *testing.T) {
+	f := NewFormatFlag(nil, "text")
+
+	assert.Equal(t, "output format (json|text|yaml)", f.Usage("output format"))
+	assert.Equal(t, "(json|text|yaml)", f.Usage(""))
+	assert.Equal(t, []string{"json", "text", "yaml"}, f.Formats())
+	assert.Equal(t, "format", f.Type())
+}
+
+func TestFormatFlag_flagSet(t *testing.T) {
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	f := NewFormatFlag(nil, "text")
+	fs.Var(f, "output", f.Usage("output format"))
+
+	require.NoError(t, fs.Parse([]string{"-output", "json"}))
+	assert.Equal(t, "json", f.Value)
+
+	err := fs.Parse([]string{"-output", "xml"})
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), "(must be one of: json, text, yaml)")
+
+	out.Reset()
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "output format (json|text|yaml)")
+	assert.Contains(t, out.String(), `(default text)`)
+}
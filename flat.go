@@ -0,0 +1,143 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Flat is a Handler that flattens values into "key=value" lines, with the key
+// being the dot-notation path to each value within nested objects, and arrays
+// elements indexed like "a[0]". For example:
+//
+//	user.name=John
+//	user.tags[0]=admin
+//	user.tags[1]=dev
+//
+// Values are first marshaled to JSON, so "json" struct tags and MarshalJSON
+// methods are respected, and lines are written in the order of the JSON
+// output. Keys which contain characters with a special meaning in the path are
+// written as quoted strings in brackets, like `a["b.c"]`. Strings containing
+// line breaks are written as quoted JSON strings, and empty objects and arrays
+// are written as "{}" and "[]". A value which is not an object or array is
+// written as is, without a key.
+//
+// This is useful for diffing and grepping deeply nested structures in shell
+// pipelines.
+type Flat struct{}
+
+var (
+	_ Handler          = (*Flat)(nil)
+	_ FormatsHandler   = (*Flat)(nil)
+	_ DescribedHandler = (*Flat)(nil)
+)
+
+// Render writes v as flattened key/value lines to w.
+func (f *Flat) Render(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err = flatWalk(&buf, dec, ""); err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (f *Flat) Formats() []string {
+	return []string{"flat"}
+}
+
+// Description returns a short human-readable description of the format.
+func (f *Flat) Description() string {
+	return "Flattened dot-notation key=value lines"
+}
+
+// flatWalk reads the next JSON value from dec, writing a line for each leaf
+// value within it, prefixed by path.
+func flatWalk(buf *bytes.Buffer, dec *json.Decoder, path string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	var value string
+	switch x := tok.(type) {
+	case json.Delim:
+		empty := true
+		for i := 0; dec.More(); i++ {
+			empty = false
+
+			var p string
+			if x == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				p = flatKey(path, key.(string))
+			} else {
+				p = path + "[" + strconv.Itoa(i) + "]"
+			}
+
+			if err = flatWalk(buf, dec, p); err != nil {
+				return err
+			}
+		}
+		if _, err = dec.Token(); err != nil {
+			return err
+		}
+		if !empty {
+			return nil
+		}
+
+		value = "{}"
+		if x == '[' {
+			value = "[]"
+		}
+	case string:
+		value = x
+		if strings.ContainsAny(x, "\r\n") {
+			value = strconv.Quote(x)
+		}
+	case nil:
+		value = "null"
+	default:
+		value = fmt.Sprint(x)
+	}
+
+	if path != "" {
+		buf.WriteString(path)
+		buf.WriteByte('=')
+	}
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+
+	return nil
+}
+
+// flatKey returns the path to key within the object at path.
+func flatKey(path, key string) string {
+	if key == "" || strings.ContainsAny(key, ".[]=\"\\ \t\r\n") {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flatTestUser struct {
+	Name    string            `json:"name"`
+	Tags    []string          `json:"tags"`
+	Address *flatTestAddress  `json:"address"`
+	Meta    map[string]any    `json:"meta,omitempty"`
+	Labels  map[string]string `json:"labels"`
+}
+
+type flatTestAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+func TestFlat_Render(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeErr  error
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "nested struct",
+			value: &flatTestUser{
+				Name:    "John",
+				Tags:    []string{"admin", "dev"},
+				Address: &flatTestAddress{City: "Oslo", Zip: 150},
+				Meta: map[string]any{
+					"b":       true,
+					"a":       1.5,
+					"x.y":     nil,
+					"":        "empty",
+					"notes":   "line 1\nline 2",
+					"objects": []any{map[string]any{"id": 1}, []int{}},
+				},
+				Labels: map[string]string{},
+			},
+			want: "name=John\n" +
+				"tags[0]=admin\n" +
+				"tags[1]=dev\n" +
+				"address.city=Oslo\n" +
+				"address.zip=150\n" +
+				`meta[""]=empty` + "\n" +
+				"meta.a=1.5\n" +
+				"meta.b=true\n" +
+				`meta.notes="line 1\nline 2"` + "\n" +
+				"meta.objects[0].id=1\n" +
+				"meta.objects[1]=[]\n" +
+				`meta["x.y"]=null` + "\n" +
+				"labels={}\n",
+		},
+		{
+			name:  "top-level array",
+			value: []any{1, "a", []string{"b"}},
+			want:  "[0]=1\n[1]=a\n[2][0]=b\n",
+		},
+		{
+			name:  "scalar",
+			value: "hello",
+			want:  "hello\n",
+		},
+		{
+			name:  "nil",
+			value: nil,
+			want:  "null\n",
+		},
+		{
+			name:  "large number",
+			value: map[string]uint64{"n": 18446744073709551615},
+			want:  "n=18446744073709551615\n",
+		},
+		{
+			name:      "cannot marshal",
+			value:     make(chan int),
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "write error",
+			writeErr:  assert.AnError,
+			value:     map[string]int{"a": 1},
+			wantErr:   "render: failed: " + assert.AnError.Error(),
+			wantErrIs: []error{Err, ErrFailed, assert.AnError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockWriter{WriteErr: tt.writeErr}
+			h := &Flat{}
+
+			err := h.Render(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFlat_Formats(t *testing.T) {
+	h := &Flat{}
+
+	assert.Equal(t, []string{"flat"}, h.Formats())
+}
@@ -0,0 +1,210 @@
+package render
+
+import (
+	"encoding/binary"
+)
+
+// fbObject is an object within a flatbuffer, which can be referenced by
+// offset. It is one of *fbTable, fbString, fbOffsets, or fbStructs.
+type fbObject interface {
+	fbObject()
+}
+
+// fbTable is a flatbuffer table. Fields are indexed by their field ID, as
+// given by their order in the schema.
+type fbTable struct {
+	fields []fbField
+}
+
+// fbField is a single field of a fbTable. It is either a little-endian encoded
+// scalar, or a reference to another object.
+type fbField struct {
+	set    bool
+	scalar []byte
+	ref    fbObject
+}
+
+// fbString is a flatbuffer string.
+type fbString string
+
+// fbOffsets is a flatbuffer vector of references to other objects.
+type fbOffsets []fbObject
+
+// fbStructs is a flatbuffer vector of structs of 8-byte aligned fields, given
+// as their encoded little-endian bytes.
+type fbStructs struct {
+	len  int
+	data []byte
+}
+
+func (*fbTable) fbObject()  {}
+func (fbString) fbObject()  {}
+func (fbOffsets) fbObject() {}
+func (fbStructs) fbObject() {}
+
+func (t *fbTable) field(id int) *fbField {
+	for len(t.fields) <= id {
+		t.fields = append(t.fields, fbField{})
+	}
+
+	return &t.fields[id]
+}
+
+func (t *fbTable) scalar(id int, b []byte) *fbTable {
+	*t.field(id) = fbField{set: true, scalar: b}
+
+	return t
+}
+
+func (t *fbTable) bool(id int, v bool) *fbTable {
+	if v {
+		return t.scalar(id, []byte{1})
+	}
+
+	return t.scalar(id, []byte{0})
+}
+
+func (t *fbTable) uint8(id int, v uint8) *fbTable {
+	return t.scalar(id, []byte{v})
+}
+
+func (t *fbTable) int16(id int, v int16) *fbTable {
+	return t.scalar(id, binary.LittleEndian.AppendUint16(nil, uint16(v)))
+}
+
+func (t *fbTable) int32(id int, v int32) *fbTable {
+	return t.scalar(id, binary.LittleEndian.AppendUint32(nil, uint32(v)))
+}
+
+func (t *fbTable) int64(id int, v int64) *fbTable {
+	return t.scalar(id, binary.LittleEndian.AppendUint64(nil, uint64(v)))
+}
+
+func (t *fbTable) ref(id int, obj fbObject) *fbTable {
+	*t.field(id) = fbField{set: true, ref: obj}
+
+	return t
+}
+
+// fbBuilder serializes a tree of flatbuffer objects. Unlike the reference
+// implementation it writes front to back, with referenced objects always
+// written after the object referencing them, as offsets must point forward.
+type fbBuilder struct {
+	buf     []byte
+	pending []fbPending
+}
+
+// fbPending is an offset at position at within the buffer, which is to be set
+// once obj has been written.
+type fbPending struct {
+	at  int
+	obj fbObject
+}
+
+// fbFinish returns the serialized flatbuffer with root as its root table,
+// padded to a multiple of 8 bytes.
+func fbFinish(root *fbTable) []byte {
+	b := &fbBuilder{}
+	b.offset(root)
+
+	for len(b.pending) > 0 {
+		p := b.pending[0]
+		b.pending = b.pending[1:]
+
+		pos := b.write(p.obj)
+		binary.LittleEndian.PutUint32(b.buf[p.at:], uint32(pos-p.at))
+	}
+	b.align(8)
+
+	return b.buf
+}
+
+// align pads the buffer with zeros to a multiple of n bytes.
+func (b *fbBuilder) align(n int) {
+	for len(b.buf)%n != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+// offset writes a placeholder offset to obj, which is set when obj is written.
+func (b *fbBuilder) offset(obj fbObject) {
+	b.align(4)
+	b.pending = append(b.pending, fbPending{at: len(b.buf), obj: obj})
+	b.buf = append(b.buf, 0, 0, 0, 0)
+}
+
+// write writes obj to the end of the buffer, and returns its position.
+func (b *fbBuilder) write(obj fbObject) int {
+	switch x := obj.(type) {
+	case *fbTable:
+		return b.writeTable(x)
+	case fbString:
+		b.align(4)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(x)))
+		b.buf = append(append(b.buf, x...), 0)
+
+		return pos
+	case fbOffsets:
+		b.align(4)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(x)))
+		for _, o := range x {
+			b.offset(o)
+		}
+
+		return pos
+	case fbStructs:
+		// Align the vector contents, which follow the length, to 8 bytes.
+		for (len(b.buf)+4)%8 != 0 {
+			b.buf = append(b.buf, 0)
+		}
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(x.len))
+		b.buf = append(b.buf, x.data...)
+
+		return pos
+	}
+
+	return 0
+}
+
+func (b *fbBuilder) writeTable(t *fbTable) int {
+	// The vtable is written first, followed by the table, which refers back
+	// to it with a signed offset.
+	b.align(2)
+	vtPos := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint16(
+		b.buf, uint16(4+2*len(t.fields)),
+	)
+	b.buf = append(b.buf, make([]byte, 2+2*len(t.fields))...)
+
+	b.align(4)
+	pos := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(pos-vtPos))
+
+	for id, f := range t.fields {
+		if !f.set {
+			continue
+		}
+
+		if f.ref != nil {
+			b.offset(f.ref)
+		} else {
+			b.align(len(f.scalar))
+			b.buf = append(b.buf, f.scalar...)
+		}
+
+		size := len(f.scalar)
+		if f.ref != nil {
+			size = 4
+		}
+		binary.LittleEndian.PutUint16(
+			b.buf[vtPos+4+2*id:], uint16(len(b.buf)-size-pos),
+		)
+	}
+
+	binary.LittleEndian.PutUint16(b.buf[vtPos+2:], uint16(len(b.buf)-pos))
+
+	return pos
+}
@@ -0,0 +1,164 @@
+package render
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fbTestReader reads flatbuffers, verifying the alignment of all values read.
+type fbTestReader struct {
+	t   *testing.T
+	buf []byte
+}
+
+func (r *fbTestReader) aligned(pos, n int) {
+	r.t.Helper()
+	require.Zerof(r.t, pos%n, "position %d is not %d-byte aligned", pos, n)
+	require.LessOrEqual(r.t, pos+n, len(r.buf), "position out of bounds")
+}
+
+func (r *fbTestReader) u16(pos int) int {
+	r.aligned(pos, 2)
+
+	return int(binary.LittleEndian.Uint16(r.buf[pos:]))
+}
+
+func (r *fbTestReader) u32(pos int) int {
+	r.aligned(pos, 4)
+
+	return int(binary.LittleEndian.Uint32(r.buf[pos:]))
+}
+
+func (r *fbTestReader) i64(pos int) int64 {
+	r.aligned(pos, 8)
+
+	return int64(binary.LittleEndian.Uint64(r.buf[pos:]))
+}
+
+// deref follows the offset at pos.
+func (r *fbTestReader) deref(pos int) int {
+	return pos + r.u32(pos)
+}
+
+// root returns the position of the root table.
+func (r *fbTestReader) root() int {
+	return r.deref(0)
+}
+
+// field returns the position of field id of the table at tbl, or -1 if the
+// field is not set.
+func (r *fbTestReader) field(tbl, id int) int {
+	r.t.Helper()
+
+	vt := tbl - int(int32(r.u32(tbl)))
+	vtSize := r.u16(vt)
+	tblSize := r.u16(vt + 2)
+	if 4+2*id >= vtSize {
+		return -1
+	}
+
+	off := r.u16(vt + 4 + 2*id)
+	if off == 0 {
+		return -1
+	}
+	require.Less(r.t, off, tblSize, "field outside of table")
+
+	return tbl + off
+}
+
+func (r *fbTestReader) fieldU8(tbl, id int) int {
+	if p := r.field(tbl, id); p >= 0 {
+		return int(r.buf[p])
+	}
+
+	return 0
+}
+
+func (r *fbTestReader) fieldI16(tbl, id int) int {
+	if p := r.field(tbl, id); p >= 0 {
+		return int(int16(r.u16(p)))
+	}
+
+	return 0
+}
+
+func (r *fbTestReader) fieldI32(tbl, id int) int {
+	if p := r.field(tbl, id); p >= 0 {
+		return int(int32(r.u32(p)))
+	}
+
+	return 0
+}
+
+func (r *fbTestReader) fieldI64(tbl, id int) int64 {
+	if p := r.field(tbl, id); p >= 0 {
+		return r.i64(p)
+	}
+
+	return 0
+}
+
+// fieldRef returns the position of the object referenced by field id of the
+// table at tbl, or -1 if the field is not set.
+func (r *fbTestReader) fieldRef(tbl, id int) int {
+	if p := r.field(tbl, id); p >= 0 {
+		return r.deref(p)
+	}
+
+	return -1
+}
+
+func (r *fbTestReader) string(pos int) string {
+	n := r.u32(pos)
+	require.Equal(r.t, byte(0), r.buf[pos+4+n], "missing null terminator")
+
+	return string(r.buf[pos+4 : pos+4+n])
+}
+
+// vector returns the length of the vector at pos, and the position of its
+// first element.
+func (r *fbTestReader) vector(pos int) (n int, start int) {
+	return r.u32(pos), pos + 4
+}
+
+func TestFBFinish(t *testing.T) {
+	child := (&fbTable{}).int32(0, 42)
+	root := (&fbTable{}).
+		uint8(0, 7).
+		int64(1, -5).
+		ref(2, fbString("hello")).
+		bool(4, true).
+		int16(5, -2).
+		ref(6, fbOffsets{child, &fbTable{}}).
+		ref(7, fbStructs{
+			len:  2,
+			data: []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0},
+		})
+
+	buf := fbFinish(root)
+	assert.Zero(t, len(buf)%8)
+
+	r := &fbTestReader{t: t, buf: buf}
+	tbl := r.root()
+
+	assert.Equal(t, 7, r.fieldU8(tbl, 0))
+	assert.Equal(t, int64(-5), r.fieldI64(tbl, 1))
+	assert.Equal(t, "hello", r.string(r.fieldRef(tbl, 2)))
+	assert.Equal(t, -1, r.field(tbl, 3))
+	assert.Equal(t, 1, r.fieldU8(tbl, 4))
+	assert.Equal(t, -2, r.fieldI16(tbl, 5))
+	assert.Equal(t, -1, r.field(tbl, 8))
+
+	n, start := r.vector(r.fieldRef(tbl, 6))
+	require.Equal(t, 2, n)
+	assert.Equal(t, 42, r.fieldI32(r.deref(start), 0))
+	assert.Equal(t, -1, r.field(r.deref(start+4), 0))
+
+	n, start = r.vector(r.fieldRef(tbl, 7))
+	require.Equal(t, 2, n)
+	assert.Equal(t, int64(1), r.i64(start))
+	assert.Equal(t, int64(2), r.i64(start+8))
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Format describes a format supported by a Renderer.
+type Format struct {
+	// Name is the canonical name of the format.
+	Name string
+
+	// Aliases are all other format strings which render the same format,
+	// sorted alphabetically.
+	Aliases []string
+
+	// Description is a short human-readable description of the format. It is
+	// empty if the handler does not implement DescribedHandler.
+	Description string
+
+	// ContentType is the MIME type of the format. It is empty if the handler
+	// does not implement ContentTypeHandler.
+	ContentType string
+
+	// Handler is the handler which renders the format.
+	Handler Handler
+}
+
+// Formats returns all formats supported by the Renderer, sorted by name.
+// Format strings which share the same handler are grouped together as a single
+// Format, with the format string returned first by the handler's Formats
+// method used as the name.
+//
+// This is useful for building help screens, and listing export options in
+// user interfaces.
+func (r *Renderer) Formats() []Format {
+	groups := r.formatGroups()
+	formats := make([]Format, 0, len(groups))
+
+	for _, g := range groups {
+		f := Format{Name: g.name, Aliases: g.aliases, Handler: g.handler}
+		if x, ok := g.handler.(DescribedHandler); ok {
+			f.Description = x.Description()
+		}
+		if x, ok := g.handler.(ContentTypeHandler); ok {
+			f.ContentType = x.ContentType()
+		}
+		formats = append(formats, f)
+	}
+
+	return formats
+}
+
+// formatGroup is a Handler along with all the format strings it is registered
+// under within a Renderer.
+type formatGroup struct {
+	// name is the canonical format name of the handler. It is the first
+	// format returned by the handler's Formats method if the handler is
+	// registered under it, otherwise the alphabetically first format the
+	// handler is registered under.
+	name string
+
+	// aliases are all other format strings the handler is registered under,
+	// sorted alphabetically.
+	aliases []string
+
+	handler Handler
+}
+
+// formatGroups returns the handlers of the Renderer grouped by handler, sorted
+// by their canonical format name.
+func (r *Renderer) formatGroups() []*formatGroup {
+	formats := make([]string, 0, len(r.Handlers))
+	for f := range r.Handlers {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+
+	var groups []*formatGroup
+	byHandler := map[Handler]*formatGroup{}
+
+	for _, f := range formats {
+		h := r.Handlers[f]
+
+		var g *formatGroup
+		groupable := h != nil && reflect.TypeOf(h).Comparable()
+		if groupable {
+			g = byHandler[h]
+		}
+		if g == nil {
+			g = &formatGroup{handler: h}
+			groups = append(groups, g)
+			if groupable {
+				byHandler[h] = g
+			}
+		}
+
+		g.aliases = append(g.aliases, f)
+	}
+
+	for _, g := range groups {
+		g.name = g.aliases[0]
+		if x, ok := g.handler.(FormatsHandler); ok {
+			if fs := x.Formats(); len(fs) > 0 {
+				for _, a := range g.aliases {
+					if a == r.key(fs[0]) {
+						g.name = a
+
+						break
+					}
+				}
+			}
+		}
+
+		aliases := make([]string, 0, len(g.aliases)-1)
+		for _, a := range g.aliases {
+			if a != g.name {
+				aliases = append(aliases, a)
+			}
+		}
+		g.aliases = aliases
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].name < groups[j].name
+	})
+
+	return groups
+}
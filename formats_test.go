@@ -0,0 +1,166 @@
+package render
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFuncHandler is a non-comparable Handler.
+type mockFuncHandler func(w io.Writer, v any) error
+
+func (mfh mockFuncHandler) Render(w io.Writer, v any) error {
+	return mfh(w, v)
+}
+
+func TestRenderer_formatGroups(t *testing.T) {
+	alias := &mockFormatsHandler{formats: []string{"Mock", "m"}}
+	other := &mockFormatsHandler{formats: []string{"other", "o"}}
+	plain := &mockHandler{}
+	funcHandler := mockFuncHandler(nil)
+
+	type group struct {
+		name    string
+		aliases []string
+		handler Handler
+	}
+
+	tests := []struct {
+		name     string
+		handlers map[string]Handler
+		want     []group
+	}{
+		{
+			name:     "no handlers",
+			handlers: map[string]Handler{},
+			want:     []group{},
+		},
+		{
+			name: "handlers with and without aliases",
+			handlers: map[string]Handler{
+				"mock":  alias,
+				"m":     alias,
+				"o":     other,
+				"other": other,
+				"x":     other,
+				"plain": plain,
+			},
+			want: []group{
+				{name: "mock", aliases: []string{"m"}, handler: alias},
+				{
+					name:    "other",
+					aliases: []string{"o", "x"},
+					handler: other,
+				},
+				{name: "plain", aliases: []string{}, handler: plain},
+			},
+		},
+		{
+			name: "first format not registered",
+			handlers: map[string]Handler{
+				"z": alias,
+				"m": alias,
+			},
+			want: []group{
+				{name: "m", aliases: []string{"z"}, handler: alias},
+			},
+		},
+		{
+			name: "non-comparable handlers",
+			handlers: map[string]Handler{
+				"a": funcHandler,
+				"b": funcHandler,
+			},
+			want: []group{
+				{name: "a", aliases: []string{}},
+				{name: "b", aliases: []string{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Renderer{Handlers: tt.handlers}
+
+			groups := r.formatGroups()
+
+			got := []group{}
+			for _, g := range groups {
+				got = append(got, group{g.name, g.aliases, g.handler})
+			}
+
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].name, got[i].name)
+				assert.Equal(t, tt.want[i].aliases, got[i].aliases)
+				if tt.want[i].handler != nil {
+					assert.Same(t, tt.want[i].handler, got[i].handler)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderer_Formats(t *testing.T) {
+	described := &mockDescribedHandler{
+		mockFormatsHandler: mockFormatsHandler{formats: []string{"d", "dd"}},
+		description:        "Described format",
+	}
+	plain := &mockHandler{}
+
+	r := &Renderer{Handlers: map[string]Handler{
+		"dd":    described,
+		"d":     described,
+		"plain": plain,
+	}}
+
+	got := r.Formats()
+
+	assert.Equal(t, []Format{
+		{
+			Name:        "d",
+			Aliases:     []string{"dd"},
+			Description: "Described format",
+			Handler:     described,
+		},
+		{
+			Name:    "plain",
+			Aliases: []string{},
+			Handler: plain,
+		},
+	}, got)
+}
+
+func TestFormats(t *testing.T) {
+	got := Formats()
+
+	want := []Format{
+		{
+			Name:        "json",
+			Aliases:     []string{},
+			Description: "JSON, machine readable",
+			ContentType: "application/json",
+		},
+		{
+			Name:        "text",
+			Aliases:     []string{"plain", "txt"},
+			Description: "Plain text",
+			ContentType: "text/plain; charset=utf-8",
+		},
+		{
+			Name:        "yaml",
+			Aliases:     []string{"yml"},
+			Description: "YAML, human and machine readable",
+			ContentType: "application/yaml",
+		},
+	}
+
+	assert.Equal(t, len(want), len(got))
+	for i := range want {
+		assert.Equal(t, want[i].Name, got[i].Name)
+		assert.Equal(t, want[i].Aliases, got[i].Aliases)
+		assert.Equal(t, want[i].Description, got[i].Description)
+		assert.Equal(t, want[i].ContentType, got[i].ContentType)
+		assert.NotNil(t, got[i].Handler)
+	}
+}
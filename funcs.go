@@ -0,0 +1,87 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Funcs is a Handler composed of separate compact and pretty render functions,
+// allowing full Handler implementations to be built from closures.
+//
+// Errors returned by the functions are wrapped with ErrFailed, unless they
+// already wrap ErrFailed or ErrCannotRender.
+type Funcs struct {
+	// CompactFunc renders v to w. If nil, all values fail to render with a
+	// ErrCannotRender error.
+	CompactFunc func(w io.Writer, v any) error
+
+	// PrettyFunc renders v to w with pretty formatting. If nil, CompactFunc
+	// is used instead.
+	PrettyFunc func(w io.Writer, v any) error
+
+	// FormatNames is the list of format strings returned by Formats.
+	FormatNames []string
+}
+
+var (
+	_ Handler        = (*Funcs)(nil)
+	_ PrettyHandler  = (*Funcs)(nil)
+	_ FormatsHandler = (*Funcs)(nil)
+)
+
+// FromMarshalFunc returns a Handler for the given formats, which renders values
+// with the given marshal function, like json.Marshal or a third-party encoder.
+// Errors returned by marshal are wrapped with ErrFailed.
+func FromMarshalFunc(
+	formats []string,
+	marshal func(v any) ([]byte, error),
+) *Funcs {
+	return &Funcs{
+		CompactFunc: func(w io.Writer, v any) error {
+			b, err := marshal(v)
+			if err != nil {
+				return err
+			}
+
+			_, err = (&fullWriter{w: w}).Write(b)
+
+			return err
+		},
+		FormatNames: formats,
+	}
+}
+
+// Render renders v to w with CompactFunc.
+func (f *Funcs) Render(w io.Writer, v any) error {
+	return f.call(f.CompactFunc, w, v)
+}
+
+// RenderPretty renders v to w with PrettyFunc, or CompactFunc if PrettyFunc is
+// nil.
+func (f *Funcs) RenderPretty(w io.Writer, v any) error {
+	if f.PrettyFunc == nil {
+		return f.Render(w, v)
+	}
+
+	return f.call(f.PrettyFunc, w, v)
+}
+
+// Formats returns FormatNames.
+func (f *Funcs) Formats() []string {
+	return f.FormatNames
+}
+
+func (f *Funcs) call(fn func(io.Writer, any) error, w io.Writer, v any) error {
+	if fn == nil {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	err := fn(w, v)
+	if err != nil &&
+		!errors.Is(err, ErrFailed) && !errors.Is(err, ErrCannotRender) {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return err
+}
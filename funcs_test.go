@@ -0,0 +1,142 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncs_Render(t *testing.T) {
+	compact := func(w io.Writer, v any) error {
+		_, err := fmt.Fprintf(w, "compact: %v", v)
+
+		return err
+	}
+	pretty := func(w io.Writer, v any) error {
+		_, err := fmt.Fprintf(w, "pretty: %v", v)
+
+		return err
+	}
+
+	tests := []struct {
+		name       string
+		funcs      *Funcs
+		pretty     bool
+		want       string
+		wantErr    string
+		wantErrIs  []error
+		wantErrNot []error
+	}{
+		{
+			name:  "compact",
+			funcs: &Funcs{CompactFunc: compact, PrettyFunc: pretty},
+			want:  "compact: foo",
+		},
+		{
+			name:   "pretty",
+			funcs:  &Funcs{CompactFunc: compact, PrettyFunc: pretty},
+			pretty: true,
+			want:   "pretty: foo",
+		},
+		{
+			name:   "pretty without PrettyFunc",
+			funcs:  &Funcs{CompactFunc: compact},
+			pretty: true,
+			want:   "compact: foo",
+		},
+		{
+			name:      "without CompactFunc",
+			funcs:     &Funcs{PrettyFunc: pretty},
+			wantErr:   "render: cannot render: string",
+			wantErrIs: []error{ErrCannotRender},
+		},
+		{
+			name: "error",
+			funcs: &Funcs{CompactFunc: func(io.Writer, any) error {
+				return errors.New("oops")
+			}},
+			wantErr:   "render: failed: oops",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name: "ErrCannotRender error",
+			funcs: &Funcs{CompactFunc: func(_ io.Writer, v any) error {
+				return fmt.Errorf("%w: %T", ErrCannotRender, v)
+			}},
+			wantErr:    "render: cannot render: string",
+			wantErrIs:  []error{ErrCannotRender},
+			wantErrNot: []error{ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = tt.funcs.RenderPretty(&buf, "foo")
+			} else {
+				err = tt.funcs.Render(&buf, "foo")
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			for _, e := range tt.wantErrNot {
+				assert.NotErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestFuncs_Formats(t *testing.T) {
+	f := &Funcs{FormatNames: []string{"csv", "tsv"}}
+
+	assert.Equal(t, []string{"csv", "tsv"}, f.Formats())
+
+	r := New(map[string]Handler{"comma": f})
+	assert.True(t, r.Supports("comma"))
+	assert.True(t, r.Supports("tsv"))
+}
+
+func TestFromMarshalFunc(t *testing.T) {
+	h := FromMarshalFunc([]string{"mock"}, func(v any) ([]byte, error) {
+		if v == nil {
+			return nil, errors.New("nil value")
+		}
+
+		return []byte(fmt.Sprintf("<%v>", v)), nil
+	})
+
+	assert.Equal(t, []string{"mock"}, h.Formats())
+
+	var buf bytes.Buffer
+	err := h.Render(&buf, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "<foo>", buf.String())
+
+	buf.Reset()
+	err = h.RenderPretty(&buf, "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "<bar>", buf.String())
+
+	err = h.Render(&buf, nil)
+	assert.EqualError(t, err, "render: failed: nil value")
+	assert.ErrorIs(t, err, ErrFailed)
+
+	err = h.Render(&mockWriter{WriteErr: errors.New("write error")}, "foo")
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
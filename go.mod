@@ -3,11 +3,9 @@ module github.com/jimeh/go-render
 go 1.20
 
 require (
+	github.com/davecgh/go-spew v1.1.1
 	github.com/stretchr/testify v1.9.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
-)
+require github.com/pmezard/go-difflib v1.0.0 // indirect
@@ -0,0 +1,212 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// HALLink is a link object of a HAL resource.
+type HALLink struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// LinksProvider is an optional interface that can be implemented by values
+// rendered by the HAL handler to provide the "_links" section of the resource.
+type LinksProvider interface {
+	// Links returns the links of the resource keyed by relation type. A
+	// relation with a single link is rendered as a link object, and all other
+	// relations as an array of link objects.
+	Links() map[string][]HALLink
+}
+
+// EmbeddedProvider is an optional interface that can be implemented by values
+// rendered by the HAL handler to provide the "_embedded" section of the
+// resource.
+type EmbeddedProvider interface {
+	// Embedded returns the embedded resources keyed by relation type. Values
+	// can be a single resource, or a slice or array of resources, which are
+	// themselves rendered as HAL resources.
+	Embedded() map[string]any
+}
+
+// HAL is a Handler that renders values as HAL+JSON
+// (https://datatracker.ietf.org/doc/html/draft-kelly-json-hal) resources.
+//
+// The value is marshaled to JSON as is, and the "_links" and "_embedded"
+// sections are added if the value implements the LinksProvider and
+// EmbeddedProvider interfaces respectively.
+//
+// If the value does not marshal to a JSON object, a ErrCannotRender error will
+// be returned.
+type HAL struct {
+	// Prefix is the prefix added to each level of indentation when pretty
+	// rendering.
+	Prefix string
+
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, the Indent of the JSON handler is used.
+	Indent string
+
+	// JSON is the handler used to encode resources. If nil, a JSON handler
+	// with default options is used.
+	JSON *JSON
+}
+
+var (
+	_ Handler            = (*HAL)(nil)
+	_ PrettyHandler      = (*HAL)(nil)
+	_ FormatsHandler     = (*HAL)(nil)
+	_ DescribedHandler   = (*HAL)(nil)
+	_ ContentTypeHandler = (*HAL)(nil)
+	_ OptionsHandler     = (*HAL)(nil)
+)
+
+// Render marshals the given value to a HAL resource.
+func (hr *HAL) Render(w io.Writer, v any) error {
+	res, err := newHALDocument(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(hr.JSON, hr.Prefix, hr.Indent).Render(w, res)
+}
+
+// RenderPretty marshals the given value to a HAL resource with line breaks and
+// indentation.
+func (hr *HAL) RenderPretty(w io.Writer, v any) error {
+	res, err := newHALDocument(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(hr.JSON, hr.Prefix, hr.Indent).RenderPretty(w, res)
+}
+
+// WithOptions returns a copy of the HAL handler, with the options applied to
+// its JSON handler.
+func (hr *HAL) WithOptions(opts *Options) Handler {
+	jr := jsonWith(hr.JSON, hr.Prefix, hr.Indent)
+
+	c := *hr
+	c.JSON, _ = jr.WithOptions(opts).(*JSON)
+	c.Prefix = ""
+	c.Indent = ""
+
+	return &c
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (hr *HAL) Formats() []string {
+	return []string{"hal", "hal+json"}
+}
+
+// Description returns a short human-readable description of the format.
+func (hr *HAL) Description() string {
+	return "HAL+JSON hypermedia resource"
+}
+
+// ContentType returns the MIME type of the format.
+func (hr *HAL) ContentType() string {
+	return "application/hal+json"
+}
+
+func newHALDocument(v any) (json.RawMessage, error) {
+	res, ok, err := halResource(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	return res, nil
+}
+
+// halResource marshals v to JSON, adding the "_links" and "_embedded" sections
+// to it. If v does not marshal to a JSON object, it is returned as is, and ok
+// is false.
+func halResource(v any) (res json.RawMessage, ok bool, err error) {
+	b, err := jsonMarshal(v)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(b) < 2 || b[0] != '{' {
+		return b, false, nil
+	}
+
+	var parts [][]byte
+
+	if lp, ok := v.(LinksProvider); ok {
+		if links := lp.Links(); len(links) > 0 {
+			m := make(map[string]any, len(links))
+			for rel, l := range links {
+				if len(l) == 1 {
+					m[rel] = l[0]
+				} else {
+					m[rel] = l
+				}
+			}
+
+			lb, err := jsonMarshal(m)
+			if err != nil {
+				return nil, false, err
+			}
+			parts = append(parts, append([]byte(`"_links":`), lb...))
+		}
+	}
+
+	if body := bytes.TrimSpace(b[1 : len(b)-1]); len(body) > 0 {
+		parts = append(parts, body)
+	}
+
+	if ep, ok := v.(EmbeddedProvider); ok {
+		if embedded := ep.Embedded(); len(embedded) > 0 {
+			m := make(map[string]json.RawMessage, len(embedded))
+			for rel, ev := range embedded {
+				m[rel], err = halEmbedded(ev)
+				if err != nil {
+					return nil, false, err
+				}
+			}
+
+			eb, err := jsonMarshal(m)
+			if err != nil {
+				return nil, false, err
+			}
+			parts = append(parts, append([]byte(`"_embedded":`), eb...))
+		}
+	}
+
+	res = append([]byte{'{'}, bytes.Join(parts, []byte{','})...)
+
+	return append(res, '}'), true, nil
+}
+
+// halEmbedded marshals an embedded resource, or a slice or array of embedded
+// resources.
+func halEmbedded(v any) (json.RawMessage, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		res, _, err := halResource(v)
+
+		return res, err
+	}
+
+	list := make([]json.RawMessage, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		res, _, err := halResource(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res)
+	}
+
+	return jsonMarshal(list)
+}
@@ -0,0 +1,225 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type halTestOrder struct {
+	ID    int    `json:"id"`
+	Total string `json:"total"`
+
+	links    map[string][]HALLink
+	embedded map[string]any
+}
+
+func (o *halTestOrder) Links() map[string][]HALLink {
+	return o.links
+}
+
+func (o *halTestOrder) Embedded() map[string]any {
+	return o.embedded
+}
+
+type halTestItem struct {
+	Name string `json:"name"`
+}
+
+func (i halTestItem) Links() map[string][]HALLink {
+	return map[string][]HALLink{
+		"self": {{Href: "/items/" + i.Name}},
+	}
+}
+
+type halTestInvalid struct{}
+
+func (halTestInvalid) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("oops")
+}
+
+func TestHAL_Render(t *testing.T) {
+	tests := []struct {
+		name      string
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "links and embedded resources",
+			value: &halTestOrder{
+				ID:    1,
+				Total: "9.99",
+				links: map[string][]HALLink{
+					"self": {{Href: "/orders/1"}},
+					"find": {{Href: "/orders{?id}", Templated: true}},
+					"item": {
+						{Href: "/items/a", Title: "A"},
+						{Href: "/items/b", Title: "B"},
+					},
+				},
+				embedded: map[string]any{
+					"items": []halTestItem{{Name: "a"}, {Name: "b"}},
+					"customer": map[string]any{
+						"name": "John",
+					},
+				},
+			},
+			want: `{"_links":{` +
+				`"find":{"href":"/orders{?id}","templated":true},` +
+				`"item":[{"href":"/items/a","title":"A"},` +
+				`{"href":"/items/b","title":"B"}],` +
+				`"self":{"href":"/orders/1"}},` +
+				`"id":1,"total":"9.99",` +
+				`"_embedded":{"customer":{"name":"John"},` +
+				`"items":[` +
+				`{"_links":{"self":{"href":"/items/a"}},"name":"a"},` +
+				`{"_links":{"self":{"href":"/items/b"}},"name":"b"}]}}` +
+				"\n",
+		},
+		{
+			name:  "no links or embedded resources",
+			value: &halTestOrder{ID: 2, Total: "0"},
+			want:  `{"id":2,"total":"0"}` + "\n",
+		},
+		{
+			name:  "plain object",
+			value: map[string]int{"a": 1},
+			want:  `{"a":1}` + "\n",
+		},
+		{
+			name:  "empty object with links",
+			value: halTestItem{},
+			want:  `{"_links":{"self":{"href":"/items/"}},"name":""}` + "\n",
+		},
+		{
+			name:   "pretty",
+			pretty: true,
+			value:  halTestItem{Name: "a"},
+			want: `{
+  "_links": {
+    "self": {
+      "href": "/items/a"
+    }
+  },
+  "name": "a"
+}
+`,
+		},
+		{
+			name:      "not an object",
+			value:     []int{1, 2},
+			wantErr:   "render: cannot render: []int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "nil pointer",
+			value:     (*halTestOrder)(nil),
+			wantErr:   "render: cannot render: *render.halTestOrder",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name: "invalid embedded resource",
+			value: &halTestOrder{
+				embedded: map[string]any{"x": halTestInvalid{}},
+			},
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &HAL{}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHAL_Formats(t *testing.T) {
+	h := &HAL{}
+
+	assert.Equal(t, []string{"hal", "hal+json"}, h.Formats())
+}
+
+func TestHAL_WithOptions(t *testing.T) {
+	h := &HAL{Indent: "\t"}
+
+	got := h.WithOptions(&Options{
+		Prefix: "//",
+		Params: map[string]string{"escape_html": "false"},
+	})
+
+	assert.Equal(t,
+		&HAL{JSON: &JSON{Prefix: "//", Indent: "\t", NoEscapeHTML: true}},
+		got,
+	)
+	assert.Equal(t, &HAL{Indent: "\t"}, h)
+}
+
+func TestRenderer_Render_halOptions(t *testing.T) {
+	r := New(map[string]Handler{"hal": &HAL{}})
+	item := halTestItem{Name: "a&b"}
+
+	tests := []struct {
+		name   string
+		format string
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "escapes HTML by default",
+			format: "hal",
+			want: `{"_links":{"self":{"href":"/items/a\u0026b"}},` +
+				`"name":"a\u0026b"}` + "\n",
+		},
+		{
+			name:   "format parameters",
+			format: "hal;escape_html=false;sort_keys",
+			want: `{"_links":{"self":{"href":"/items/a&b"}},` +
+				`"name":"a&b"}` + "\n",
+		},
+		{
+			name:   "WithIndent",
+			format: "hal",
+			opts: []Option{
+				WithPretty(), WithIndent(" "), WithParam("escape_html", "0"),
+			},
+			want: "{\n \"_links\": {\n  \"self\": {\n" +
+				"   \"href\": \"/items/a&b\"\n  }\n },\n" +
+				" \"name\": \"a&b\"\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, item, tt.opts...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header describes a comment header which is written at the top of rendered
+// output, for traceability of generated artifacts. It is only written for
+// formats which support comments, determined by the Handler implementing the
+// CommentHandler interface.
+//
+// For example, a header for YAML output might look like:
+//
+//	# Generated by mytool 1.2.3
+//	# Timestamp: 2024-03-25T12:00:00Z
+//	# Command: mytool export --output yaml
+type Header struct {
+	// Tool is the name of the tool which generated the output. If empty, the
+	// base name of the running executable is used.
+	Tool string
+
+	// Version is the version of the tool, included after the tool name if not
+	// empty.
+	Version string
+
+	// Timestamp includes the time the output was rendered when true.
+	Timestamp bool
+
+	// CommandLine includes the command line used to run the tool when true.
+	CommandLine bool
+
+	// Now returns the time used when Timestamp is true. If nil, time.Now is
+	// used.
+	Now func() time.Time
+
+	// Args is the command line included when CommandLine is true. If nil,
+	// os.Args is used.
+	Args []string
+}
+
+// comment returns the header as comment lines, each starting with prefix and
+// ending with a newline.
+func (h *Header) comment(prefix string) string {
+	args := h.Args
+	if args == nil {
+		args = os.Args
+	}
+
+	tool := h.Tool
+	if tool == "" && len(args) > 0 {
+		tool = filepath.Base(args[0])
+	}
+
+	generated := "Generated by " + tool
+	if h.Version != "" {
+		generated += " " + h.Version
+	}
+	lines := []string{strings.TrimSpace(generated)}
+
+	if h.Timestamp {
+		now := time.Now
+		if h.Now != nil {
+			now = h.Now
+		}
+		lines = append(lines, "Timestamp: "+now().Format(time.RFC3339))
+	}
+
+	if h.CommandLine {
+		quoted := make([]string, 0, len(args))
+		for _, arg := range args {
+			if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\") {
+				arg = strconv.Quote(arg)
+			}
+			quoted = append(quoted, arg)
+		}
+		lines = append(lines, "Command: "+strings.Join(quoted, " "))
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(prefix)
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeader_comment(t *testing.T) {
+	now := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header *Header
+		prefix string
+		want   string
+	}{
+		{
+			name:   "defaults",
+			header: &Header{Args: []string{"/usr/bin/mytool", "foo"}},
+			prefix: "# ",
+			want:   "# Generated by mytool\n",
+		},
+		{
+			name:   "default tool from os.Args",
+			header: &Header{},
+			prefix: "# ",
+			want:   "# Generated by " + filepath.Base(os.Args[0]) + "\n",
+		},
+		{
+			name: "all fields",
+			header: &Header{
+				Tool:        "mytool",
+				Version:     "1.2.3",
+				Timestamp:   true,
+				CommandLine: true,
+				Now:         func() time.Time { return now },
+				Args: []string{
+					"mytool", "export", "--name", "John Doe", "",
+				},
+			},
+			prefix: "// ",
+			want: "// Generated by mytool 1.2.3\n" +
+				"// Timestamp: 2024-03-25T12:00:00Z\n" +
+				"// Command: mytool export --name \"John Doe\" \"\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.header.comment(tt.prefix)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderer_Render_header(t *testing.T) {
+	header := &Header{Tool: "mytool", Version: "1.2.3"}
+
+	tests := []struct {
+		name      string
+		format    string
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "yaml",
+			format: "yaml",
+			value:  map[string]int{"age": 30},
+			want:   "# Generated by mytool 1.2.3\nage: 30\n",
+		},
+		{
+			name:   "text",
+			format: "text",
+			value:  "hello\n",
+			want:   "# Generated by mytool 1.2.3\nhello\n",
+		},
+		{
+			name:   "json does not support comments",
+			format: "json",
+			value:  map[string]int{"age": 30},
+			want:   "{\"age\":30}\n",
+		},
+		{
+			name:      "header is not written on error",
+			format:    "yaml",
+			value:     &mockYAMLMarshaler{err: errors.New("mock error")},
+			wantErr:   "render: failed: mock error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Base.NewWith("json", "text", "yaml")
+			r.Header = header
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, buf.String())
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
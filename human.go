@@ -0,0 +1,120 @@
+package render
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a size in bytes. The Text handler renders it as a plain number,
+// and humanized with binary units, like "1.2 GiB", when pretty rendering.
+type ByteSize int64
+
+// String returns the size as a plain number of bytes.
+func (b ByteSize) String() string {
+	return strconv.FormatInt(int64(b), 10)
+}
+
+// byteUnits are the binary units used to humanize byte sizes.
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// numberUnits are the units used to humanize large numbers.
+var numberUnits = []string{"K", "M", "B", "T"}
+
+// humanize returns a human readable representation of v, and true if v is of a
+// type which is humanized. Byte sizes use binary units, like "1.2 GiB",
+// durations are rounded to a precision based on their length, like "3h12m",
+// and integers of 1000 or more use short scale units, like "1.4M".
+func humanize(v any) (string, bool) {
+	switch x := v.(type) {
+	case ByteSize:
+		return humanBytes(x), true
+	case time.Duration:
+		return humanDuration(x), true
+	case int:
+		return humanNumber(float64(x)), true
+	case int8:
+		return humanNumber(float64(x)), true
+	case int16:
+		return humanNumber(float64(x)), true
+	case int32:
+		return humanNumber(float64(x)), true
+	case int64:
+		return humanNumber(float64(x)), true
+	case uint:
+		return humanNumber(float64(x)), true
+	case uint8:
+		return humanNumber(float64(x)), true
+	case uint16:
+		return humanNumber(float64(x)), true
+	case uint32:
+		return humanNumber(float64(x)), true
+	case uint64:
+		return humanNumber(float64(x)), true
+	}
+
+	return "", false
+}
+
+func humanBytes(b ByteSize) string {
+	if b > -1024 && b < 1024 {
+		return strconv.FormatInt(int64(b), 10) + " B"
+	}
+
+	return humanScale(float64(b), 1024, byteUnits, " ")
+}
+
+func humanNumber(n float64) string {
+	if math.Abs(n) < 1000 {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+
+	return humanScale(n, 1000, numberUnits, "")
+}
+
+// humanScale divides n by base until it is below base, or units run out, and
+// formats it with one decimal and the matching unit. n must be at least base.
+func humanScale(n, base float64, units []string, sep string) string {
+	unit := ""
+	for _, u := range units {
+		// Round first, so 999999 is "1M" instead of "1000K".
+		if unit != "" && math.Abs(math.Round(n*10)/10) < base {
+			break
+		}
+		n /= base
+		unit = u
+	}
+
+	s := strconv.FormatFloat(n, 'f', 1, 64)
+
+	return strings.TrimSuffix(s, ".0") + sep + unit
+}
+
+func humanDuration(d time.Duration) string {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs >= time.Hour:
+		d = d.Round(time.Minute)
+	case abs >= time.Minute:
+		d = d.Round(time.Second)
+	case abs >= time.Second:
+		d = d.Round(100 * time.Millisecond)
+	default:
+		return d.String()
+	}
+
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
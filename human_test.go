@@ -0,0 +1,117 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteSize_String(t *testing.T) {
+	assert.Equal(t, "1288490189", ByteSize(1288490189).String())
+}
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   string
+		wantOk bool
+	}{
+		{name: "bytes", value: ByteSize(512), want: "512 B", wantOk: true},
+		{name: "KiB", value: ByteSize(1024), want: "1 KiB", wantOk: true},
+		{
+			name:   "GiB",
+			value:  ByteSize(1288490189),
+			want:   "1.2 GiB",
+			wantOk: true,
+		},
+		{
+			name:   "rounds up to next unit",
+			value:  ByteSize(1048575),
+			want:   "1 MiB",
+			wantOk: true,
+		},
+		{
+			name:   "negative bytes",
+			value:  ByteSize(-1536),
+			want:   "-1.5 KiB",
+			wantOk: true,
+		},
+		{
+			name:   "EiB",
+			value:  ByteSize(1 << 62),
+			want:   "4 EiB",
+			wantOk: true,
+		},
+		{
+			name:   "hours",
+			value:  3*time.Hour + 12*time.Minute + 5*time.Second,
+			want:   "3h12m",
+			wantOk: true,
+		},
+		{
+			name:   "whole hours",
+			value:  2*time.Hour + 10*time.Second,
+			want:   "2h",
+			wantOk: true,
+		},
+		{
+			name:   "minutes",
+			value:  2*time.Minute + 3400*time.Millisecond,
+			want:   "2m3s",
+			wantOk: true,
+		},
+		{
+			name:   "whole minutes",
+			value:  5 * time.Minute,
+			want:   "5m",
+			wantOk: true,
+		},
+		{
+			name:   "seconds",
+			value:  3456 * time.Millisecond,
+			want:   "3.5s",
+			wantOk: true,
+		},
+		{
+			name:   "sub-second",
+			value:  1500 * time.Microsecond,
+			want:   "1.5ms",
+			wantOk: true,
+		},
+		{
+			name:   "negative duration",
+			value:  -90 * time.Minute,
+			want:   "-1h30m",
+			wantOk: true,
+		},
+		{name: "small int", value: 999, want: "999", wantOk: true},
+		{name: "thousands", value: 1500, want: "1.5K", wantOk: true},
+		{name: "millions", value: int64(1400000), want: "1.4M", wantOk: true},
+		{
+			name:   "rounds up to next unit",
+			value:  uint32(999999),
+			want:   "1M",
+			wantOk: true,
+		},
+		{name: "billions", value: uint64(2e9), want: "2B", wantOk: true},
+		{name: "negative", value: int16(-2500), want: "-2.5K", wantOk: true},
+		{
+			name:   "beyond trillions",
+			value:  int64(5e15),
+			want:   "5000T",
+			wantOk: true,
+		},
+		{name: "float", value: 1500.5},
+		{name: "string", value: "1500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := humanize(tt.value)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
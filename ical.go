@@ -0,0 +1,281 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICalDefaultProdID is the default product identifier used by ICal instances
+// if no ProdID value is set on the ICal instance itself.
+var ICalDefaultProdID = "-//jimeh//go-render//EN"
+
+// ICal is a Handler that renders structs, and slices or arrays of structs, as
+// RFC 5545 iCalendar documents, with each struct rendered as a VEVENT.
+//
+// The iCalendar property of each exported field is taken from the "ical"
+// struct tag, for example `ical:"dtstart"`. Fields without a property name in
+// their tag, like `ical:",date"`, are mapped based on their name if it matches
+// a common event property, like Summary, Description, Location, UID, URL, and
+// Status, or Start and End for DTSTART and DTEND. Other fields, and fields
+// tagged with `ical:"-"`, are skipped.
+//
+// Field values are formatted based on their type:
+//
+//   - time.Time values are written in UTC, or as a date if the tag has the
+//     "date" option, for example `ical:"dtstart,date"`. Zero times are skipped.
+//   - time.Duration values are written as iCalendar durations.
+//   - []string values are written as a comma separated list.
+//   - Strings, fmt.Stringer values, numbers and bools are written as text.
+//     Values of URI and CAL-ADDRESS properties, like URL and ORGANIZER, are
+//     written as is, and all others are escaped as TEXT values.
+//
+// Empty strings and nil values are skipped. Every event must have a UID,
+// otherwise a ErrFailed error is returned. If an event has no DTSTAMP, the
+// current time is used.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type ICal struct {
+	// ProdID is the product identifier of the calendar. If empty,
+	// ICalDefaultProdID is used.
+	ProdID string
+
+	// Now returns the time used for DTSTAMP properties of events which do not
+	// have one. If nil, time.Now is used.
+	Now func() time.Time
+}
+
+var (
+	_ Handler            = (*ICal)(nil)
+	_ FormatsHandler     = (*ICal)(nil)
+	_ DescribedHandler   = (*ICal)(nil)
+	_ ContentTypeHandler = (*ICal)(nil)
+)
+
+// icalFieldProperties maps common struct field names to iCalendar properties,
+// used for fields without a property name in their "ical" struct tag.
+var icalFieldProperties = map[string]string{
+	"UID":          "UID",
+	"Summary":      "SUMMARY",
+	"Description":  "DESCRIPTION",
+	"Location":     "LOCATION",
+	"URL":          "URL",
+	"Status":       "STATUS",
+	"Categories":   "CATEGORIES",
+	"Organizer":    "ORGANIZER",
+	"Start":        "DTSTART",
+	"End":          "DTEND",
+	"Duration":     "DURATION",
+	"Created":      "CREATED",
+	"LastModified": "LAST-MODIFIED",
+	"Sequence":     "SEQUENCE",
+	"Priority":     "PRIORITY",
+}
+
+// icalRawProperties are iCalendar properties which do not have TEXT values,
+// like URIs and calendar user addresses, so their values must not be escaped.
+var icalRawProperties = map[string]bool{
+	"ATTACH":    true,
+	"ATTENDEE":  true,
+	"GEO":       true,
+	"ORGANIZER": true,
+	"RRULE":     true,
+	"TZURL":     true,
+	"URL":       true,
+}
+
+// Render writes v as a iCalendar document to w.
+func (ic *ICal) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	fields := structFields(t, "ical")
+	props := make([]string, len(fields))
+	for i, f := range fields {
+		sf := t.FieldByIndex(f.index)
+		if name, _, _ := strings.Cut(sf.Tag.Get("ical"), ","); name != "" {
+			props[i] = strings.ToUpper(name)
+		} else {
+			props[i] = icalFieldProperties[sf.Name]
+		}
+	}
+
+	prodID := ic.ProdID
+	if prodID == "" {
+		prodID = ICalDefaultProdID
+	}
+	now := time.Now
+	if ic.Now != nil {
+		now = ic.Now
+	}
+	stamp := icalDateTime(now())
+
+	var buf strings.Builder
+	writeContentLine(&buf, "BEGIN", "VCALENDAR")
+	writeContentLine(&buf, "VERSION", "2.0")
+	writeContentLine(&buf, "PRODID", escapeText(prodID))
+
+	for n, row := range rows {
+		var hasUID, hasStamp bool
+
+		writeContentLine(&buf, "BEGIN", "VEVENT")
+		for i, f := range fields {
+			if props[i] == "" {
+				continue
+			}
+
+			name, value, ok := icalProperty(props[i], f, f.value(row))
+			if !ok {
+				continue
+			}
+
+			switch props[i] {
+			case "UID":
+				hasUID = true
+			case "DTSTAMP":
+				hasStamp = true
+			}
+			writeContentLine(&buf, name, value)
+		}
+
+		if !hasUID {
+			return fmt.Errorf("%w: ical: event %d has no UID", ErrFailed, n+1)
+		}
+		if !hasStamp {
+			writeContentLine(&buf, "DTSTAMP", stamp)
+		}
+		writeContentLine(&buf, "END", "VEVENT")
+	}
+
+	writeContentLine(&buf, "END", "VCALENDAR")
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (ic *ICal) Formats() []string {
+	return []string{"ics", "ical", "icalendar"}
+}
+
+// Description returns a short human-readable description of the format.
+func (ic *ICal) Description() string {
+	return "iCalendar (RFC 5545) events"
+}
+
+// ContentType returns the MIME type of the format.
+func (ic *ICal) ContentType() string {
+	return "text/calendar; charset=utf-8"
+}
+
+// icalProperty returns the property name (including any parameters) and value
+// for the given field value. If the value is empty, ok is false.
+func icalProperty(
+	prop string,
+	f structField,
+	rv reflect.Value,
+) (name string, value string, ok bool) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return "", "", false
+	}
+
+	switch x := rv.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return "", "", false
+		}
+		if f.hasOption("date") {
+			return prop + ";VALUE=DATE", x.Format("20060102"), true
+		}
+
+		return prop, icalDateTime(x), true
+	case time.Duration:
+		return prop, icalDuration(x), true
+	case []string:
+		if len(x) == 0 {
+			return "", "", false
+		}
+		values := make([]string, 0, len(x))
+		for _, s := range x {
+			values = append(values, escapeText(s))
+		}
+
+		return prop, strings.Join(values, ","), true
+	case fmt.Stringer:
+		value = x.String()
+	case bool:
+		value = strings.ToUpper(strconv.FormatBool(x))
+	default:
+		switch rv.Kind() { //nolint:exhaustive
+		case reflect.String:
+			value = rv.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64, reflect.Float32,
+			reflect.Float64:
+			value = fmt.Sprint(x)
+		default:
+			return "", "", false
+		}
+	}
+
+	if value == "" {
+		return "", "", false
+	}
+	if icalRawProperties[prop] {
+		return prop, value, true
+	}
+
+	return prop, escapeText(value), true
+}
+
+func icalDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icalDuration formats d as a RFC 5545 duration, for example "PT1H30M".
+func icalDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+
+	if h > 0 || m > 0 || s > 0 || days == 0 {
+		b.WriteByte('T')
+		if h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+		if s > 0 || (h == 0 && m == 0) {
+			fmt.Fprintf(&b, "%dS", s)
+		}
+	}
+
+	return b.String()
+}
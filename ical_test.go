@@ -0,0 +1,231 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type icalTestEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Location    *string
+	Categories  []string
+	Ignored     string
+}
+
+type icalTestTaggedEvent struct {
+	ID       string        `ical:"uid"`
+	Title    string        `ical:"summary"`
+	Day      time.Time     `ical:"dtstart,date"`
+	Length   time.Duration `ical:"duration"`
+	Stamp    time.Time     `ical:"dtstamp"`
+	Attendee string        `ical:"-"`
+	Summary  string
+}
+
+type icalTestAddressEvent struct {
+	UID       string
+	Start     time.Time `ical:",date"`
+	URL       string
+	Organizer string
+	Location  string
+	RRule     string `ical:"rrule"`
+}
+
+func crlf(lines ...string) string {
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func TestICal_Render(t *testing.T) {
+	now := time.Date(2024, 3, 25, 9, 0, 0, 0, time.UTC)
+	loc := time.FixedZone("CET", 3600)
+	location := "Room 1"
+
+	tests := []struct {
+		name      string
+		prodID    string
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "slice of events",
+			value: []icalTestEvent{
+				{
+					UID:         "1@example.com",
+					Summary:     "Meeting, with; team",
+					Description: "Line 1\nLine 2",
+					Start:       time.Date(2024, 4, 1, 10, 0, 0, 0, loc),
+					End:         time.Date(2024, 4, 1, 11, 0, 0, 0, loc),
+					Location:    &location,
+					Categories:  []string{"work", "a,b"},
+					Ignored:     "ignored",
+				},
+				{UID: "2@example.com"},
+			},
+			want: crlf(
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//jimeh//go-render//EN",
+				"BEGIN:VEVENT",
+				"UID:1@example.com",
+				`SUMMARY:Meeting\, with\; team`,
+				`DESCRIPTION:Line 1\nLine 2`,
+				"DTSTART:20240401T090000Z",
+				"DTEND:20240401T100000Z",
+				"LOCATION:Room 1",
+				`CATEGORIES:work,a\,b`,
+				"DTSTAMP:20240325T090000Z",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:2@example.com",
+				"DTSTAMP:20240325T090000Z",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			),
+		},
+		{
+			name:   "single tagged event with custom prodid",
+			prodID: "-//Example//Test//EN",
+			value: &icalTestTaggedEvent{
+				ID:       "3@example.com",
+				Title:    "Holiday",
+				Day:      time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+				Length:   24*time.Hour + 90*time.Minute,
+				Stamp:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Attendee: "nobody",
+				Summary:  "untagged field mapped by name",
+			},
+			want: crlf(
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Example//Test//EN",
+				"BEGIN:VEVENT",
+				"UID:3@example.com",
+				"SUMMARY:Holiday",
+				"DTSTART;VALUE=DATE:20241225",
+				"DURATION:P1DT1H30M",
+				"DTSTAMP:20240101T000000Z",
+				"SUMMARY:untagged field mapped by name",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			),
+		},
+		{
+			name: "tag options without name and unescaped addresses",
+			value: icalTestAddressEvent{
+				UID:       "4@example.com",
+				Start:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				URL:       "https://example.com/?a=1,2;b",
+				Organizer: "mailto:jim@example.com",
+				Location:  "Hall A, Room 1",
+				RRule:     "FREQ=WEEKLY;COUNT=4",
+			},
+			want: crlf(
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//jimeh//go-render//EN",
+				"BEGIN:VEVENT",
+				"UID:4@example.com",
+				"DTSTART;VALUE=DATE:20240501",
+				"URL:https://example.com/?a=1,2;b",
+				"ORGANIZER:mailto:jim@example.com",
+				`LOCATION:Hall A\, Room 1`,
+				"RRULE:FREQ=WEEKLY;COUNT=4",
+				"DTSTAMP:20240325T090000Z",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			),
+		},
+		{
+			name:  "empty slice",
+			value: []icalTestEvent{},
+			want: crlf(
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//jimeh//go-render//EN",
+				"END:VCALENDAR",
+			),
+		},
+		{
+			name:      "missing UID",
+			value:     []icalTestEvent{{UID: "1"}, {Summary: "No UID"}},
+			wantErr:   "render: failed: ical: event 2 has no UID",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "not struct based",
+			value:     "hello",
+			wantErr:   "render: cannot render: string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ic := &ICal{
+				ProdID: tt.prodID,
+				Now:    func() time.Time { return now },
+			}
+			w := &mockWriter{}
+
+			err := ic.Render(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestICal_Render_writeError(t *testing.T) {
+	ic := &ICal{}
+	w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+	err := ic.Render(w, []icalTestEvent{{UID: "1"}})
+
+	assert.EqualError(t, err, "render: failed: write error!!1")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestICal_Formats(t *testing.T) {
+	h := &ICal{}
+
+	assert.Equal(t, []string{"ics", "ical", "icalendar"}, h.Formats())
+}
+
+func Test_icalDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "PT0S"},
+		{d: 30 * time.Second, want: "PT30S"},
+		{d: 90 * time.Minute, want: "PT1H30M"},
+		{d: 2 * time.Hour, want: "PT2H"},
+		{d: 48 * time.Hour, want: "P2D"},
+		{d: 49*time.Hour + 5*time.Second, want: "P2DT1H5S"},
+		{d: -15 * time.Minute, want: "-PT15M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, icalDuration(tt.d))
+		})
+	}
+}
@@ -39,3 +39,96 @@ type FormatsHandler interface {
 	// supported for the sake of aliases, like "yaml" and "yml".
 	Formats() []string
 }
+
+// CommentHandler is an optional interface that can be implemented by Handler
+// implementations for formats which support line comments. It is used by the
+// Renderer to write a Header at the top of rendered output.
+type CommentHandler interface {
+	// CommentPrefix returns the string which starts a line comment in the
+	// format, including any trailing whitespace, for example "# ".
+	CommentPrefix() string
+}
+
+// DescribedHandler is an optional interface that can be implemented by Handler
+// implementations to provide a short human-readable description of the format
+// they render. It is used by Renderer.Formats, which is useful for building
+// help screens and listing export options in user interfaces.
+type DescribedHandler interface {
+	// Description returns a short human-readable description of the format,
+	// for example "JSON, machine readable".
+	Description() string
+}
+
+// OptionsHandler is an optional interface that can be implemented by Handler
+// implementations to support per call Options, like a custom indentation.
+type OptionsHandler interface {
+	// WithOptions returns a Handler configured with the given options. It
+	// must not modify the receiver, as handlers are shared between calls,
+	// and it should return the receiver as is if no options apply to it.
+	WithOptions(opts *Options) Handler
+}
+
+// EncoderHandler is an optional interface that can be implemented by Handler
+// implementations for formats which support encoding multiple values to the
+// same writer, with an encoder which can be reused across values.
+type EncoderHandler interface {
+	// NewEncoder returns a ValueEncoder which writes values to w, using the
+	// pretty variant of the format if pretty is true.
+	NewEncoder(w io.Writer, pretty bool) ValueEncoder
+}
+
+// ValueEncoder encodes values to an underlying writer, as returned by the
+// NewEncoder method of EncoderHandler implementations. If it also implements
+// io.Closer, it is closed when the Encoder using it is closed.
+type ValueEncoder interface {
+	// Encode writes v to the underlying writer.
+	Encode(v any) error
+}
+
+// StreamHandler is an optional interface that can be implemented by Handler
+// implementations to render a sequence of values incrementally, as each value
+// is produced, instead of requiring all values to be held in memory at once.
+type StreamHandler interface {
+	// RenderStream writes each value yielded by seq into w, using the pretty
+	// variant of the format if pretty is true. The output as a whole should be
+	// equivalent to rendering a slice of all values with Render or
+	// RenderPretty.
+	//
+	// The seq function has the same signature as iter.Seq[any], and can be
+	// passed one directly.
+	RenderStream(w io.Writer, pretty bool, seq func(yield func(any) bool)) error
+}
+
+// ProbeHandler is an optional interface that can be implemented by Handler
+// implementations to check if a value can be rendered, without rendering it.
+// It is used by Renderer.CanRender, and is useful for handlers where rendering
+// is expensive, or consumes the value, like when reading from a io.Reader.
+type ProbeHandler interface {
+	// Probe returns nil if v can be rendered by the Handler. If v cannot be
+	// rendered to the format in question, then a ErrCannotRender error must
+	// be returned.
+	Probe(v any) error
+}
+
+// ContentTypeHandler is an optional interface that can be implemented by
+// Handler implementations to return the MIME type of the format they render.
+// It is used for content negotiation by Respond, and to set the Content-Type
+// header of HTTP responses.
+type ContentTypeHandler interface {
+	// ContentType returns the MIME type of the format, for example
+	// "application/json", optionally with parameters like
+	// "text/plain; charset=utf-8".
+	ContentType() string
+}
+
+// ParseHandler is an optional interface that can be implemented by Handler
+// implementations to decode values from the format they render. It allows
+// converting between formats with Convert.
+type ParseHandler interface {
+	// Parse decodes a value in the format that the Handler supports from r,
+	// and stores the result in the value pointed to by v.
+	//
+	// If v cannot be decoded into, then a ErrCannotRender error must be
+	// returned. Any other errors should be returned as is.
+	Parse(r io.Reader, v any) error
+}
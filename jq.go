@@ -0,0 +1,476 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrQuery is returned when a query given with WithQuery is invalid, or cannot
+// be applied to the rendered value.
+var ErrQuery = fmt.Errorf("%w: query", ErrFailed)
+
+// WithQuery applies a jq style query expression to the value before it is
+// rendered, like ".items[].name". The value is converted to its JSON
+// representation before the query is applied, so fields are named as they are
+// in JSON output.
+//
+// A subset of jq is supported: the identity ".", object keys like ".name" or
+// ".\"full name\"", array indexes like ".[0]" or ".[-1]", array slices like
+// ".[1:3]", iteration with ".[]", optional steps with a "?" suffix, like
+// ".items[]?", and pipes of the above, like ".items[] | .name".
+//
+// If the query produces a single result, it is rendered on its own. Otherwise
+// all results are rendered as a list.
+func WithQuery(query string) Option {
+	return func(o *Options) {
+		o.Query = query
+	}
+}
+
+// jqStepKind is the kind of a single jqStep.
+type jqStepKind int
+
+const (
+	jqKey jqStepKind = iota
+	jqIndex
+	jqSlice
+	jqIterate
+)
+
+// jqStep is a single step of a parsed query, applied to each result of the
+// previous step.
+type jqStep struct {
+	kind     jqStepKind
+	key      string
+	index    int
+	from     *int
+	to       *int
+	optional bool
+}
+
+// jqQuery is a parsed query expression.
+type jqQuery []jqStep
+
+// parseQuery parses the jq style query expression s.
+func parseQuery(s string) (jqQuery, error) {
+	p := &jqParser{s: s}
+	q, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrQuery, s, err)
+	}
+
+	return q, nil
+}
+
+// jqParser parses query expressions.
+type jqParser struct {
+	s   string
+	pos int
+}
+
+func (p *jqParser) parse() (jqQuery, error) {
+	var q jqQuery
+	for {
+		p.skipSpace()
+		if !p.consume('.') {
+			return nil, p.errorf("expected '.'")
+		}
+
+		// A leading key may directly follow the dot, like ".name".
+		if p.pos < len(p.s) &&
+			(isIdentStart(p.s[p.pos]) || p.s[p.pos] == '"') {
+			step, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			q = append(q, step)
+		}
+
+		for {
+			step, ok, err := p.suffix()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			q = append(q, step)
+		}
+
+		p.skipSpace()
+		if p.pos == len(p.s) {
+			return q, nil
+		}
+		if !p.consume('|') {
+			return nil, p.errorf("unexpected %q", p.s[p.pos])
+		}
+	}
+}
+
+// suffix parses a step following a leading key or another step, and reports
+// if there was one.
+func (p *jqParser) suffix() (jqStep, bool, error) {
+	if p.pos >= len(p.s) {
+		return jqStep{}, false, nil
+	}
+
+	var step jqStep
+	switch {
+	case p.s[p.pos] == '.' && p.pos+1 < len(p.s) &&
+		(isIdentStart(p.s[p.pos+1]) || p.s[p.pos+1] == '"'):
+		p.pos++
+
+		var err error
+		if step, err = p.key(); err != nil {
+			return jqStep{}, false, err
+		}
+	case p.s[p.pos] == '.' && p.pos+1 < len(p.s) && p.s[p.pos+1] == '[':
+		p.pos++
+
+		return p.suffix()
+	case p.s[p.pos] == '[':
+		var err error
+		if step, err = p.brackets(); err != nil {
+			return jqStep{}, false, err
+		}
+	default:
+		return jqStep{}, false, nil
+	}
+
+	if p.consume('?') {
+		step.optional = true
+	}
+
+	return step, true, nil
+}
+
+// key parses an object key, either an identifier or a quoted string.
+func (p *jqParser) key() (jqStep, error) {
+	if p.s[p.pos] == '"' {
+		s, err := p.str()
+		if err != nil {
+			return jqStep{}, err
+		}
+
+		return jqStep{kind: jqKey, key: s}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+		p.pos++
+	}
+
+	return jqStep{kind: jqKey, key: p.s[start:p.pos]}, nil
+}
+
+// brackets parses an iteration, index, slice, or quoted key in brackets.
+func (p *jqParser) brackets() (jqStep, error) {
+	p.pos++
+	p.skipSpace()
+
+	if p.consume(']') {
+		return jqStep{kind: jqIterate}, nil
+	}
+
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		s, err := p.str()
+		if err != nil {
+			return jqStep{}, err
+		}
+		p.skipSpace()
+		if !p.consume(']') {
+			return jqStep{}, p.errorf("expected ']'")
+		}
+
+		return jqStep{kind: jqKey, key: s}, nil
+	}
+
+	from, err := p.int()
+	if err != nil {
+		return jqStep{}, err
+	}
+	p.skipSpace()
+
+	if !p.consume(':') {
+		if from == nil {
+			return jqStep{}, p.errorf("expected index")
+		}
+		if !p.consume(']') {
+			return jqStep{}, p.errorf("expected ']'")
+		}
+
+		return jqStep{kind: jqIndex, index: *from}, nil
+	}
+
+	to, err := p.int()
+	if err != nil {
+		return jqStep{}, err
+	}
+	p.skipSpace()
+	if !p.consume(']') {
+		return jqStep{}, p.errorf("expected ']'")
+	}
+
+	return jqStep{kind: jqSlice, from: from, to: to}, nil
+}
+
+// int parses an optional integer, returning nil if there is none.
+func (p *jqParser) int() (*int, error) {
+	p.skipSpace()
+
+	start := p.pos
+	if p.pos < len(p.s) && p.s[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("invalid index %q", p.s[start:p.pos])
+	}
+
+	return &n, nil
+}
+
+// str parses a double quoted string with Go escape sequences.
+func (p *jqParser) str() (string, error) {
+	start := p.pos
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch p.s[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.s[start:p.pos])
+			if err != nil {
+				return "", p.errorf("invalid string %s", p.s[start:p.pos])
+			}
+
+			return s, nil
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jqParser) consume(c byte) bool {
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *jqParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jqParser) errorf(format string, args ...any) error {
+	return fmt.Errorf(
+		"%s at position %d", fmt.Sprintf(format, args...), p.pos+1,
+	)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// apply applies the query to the JSON representation of v. A single result is
+// returned as is, and any other number of results as a []any. The identity
+// query returns v as is.
+func (q jqQuery) apply(v any) (any, error) {
+	if len(q) == 0 {
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var data any
+	if err = dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	results := []any{data}
+	for _, step := range q {
+		var next []any
+		for _, r := range results {
+			out, err := step.apply(r)
+			if err != nil {
+				if step.optional {
+					continue
+				}
+
+				return nil, fmt.Errorf("%w: %w", ErrQuery, err)
+			}
+			next = append(next, out...)
+		}
+		results = next
+	}
+
+	for i, r := range results {
+		results[i] = jqNumbers(r)
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	if results == nil {
+		results = []any{}
+	}
+
+	return results, nil
+}
+
+// apply returns the results of applying the step to v.
+func (s jqStep) apply(v any) ([]any, error) {
+	switch s.kind {
+	case jqKey:
+		switch x := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case map[string]any:
+			return []any{x[s.key]}, nil
+		}
+
+		return nil, fmt.Errorf("cannot index %s with %q", jqType(v), s.key)
+	case jqIndex:
+		switch x := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			i := s.index
+			if i < 0 {
+				i += len(x)
+			}
+			if i < 0 || i >= len(x) {
+				return []any{nil}, nil
+			}
+
+			return []any{x[i]}, nil
+		}
+
+		return nil, fmt.Errorf("cannot index %s with number", jqType(v))
+	case jqSlice:
+		switch x := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			from := jqBound(s.from, 0, len(x))
+			to := jqBound(s.to, len(x), len(x))
+			if to < from {
+				to = from
+			}
+
+			return []any{x[from:to]}, nil
+		}
+
+		return nil, fmt.Errorf("cannot slice %s", jqType(v))
+	default:
+		switch x := v.(type) {
+		case []any:
+			return x, nil
+		case map[string]any:
+			keys := make([]string, 0, len(x))
+			for k := range x {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			out := make([]any, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, x[k])
+			}
+
+			return out, nil
+		}
+
+		return nil, fmt.Errorf("cannot iterate over %s", jqType(v))
+	}
+}
+
+// jqBound returns the slice bound n for a list of the given length, resolving
+// negative bounds from the end, or def if n is nil.
+func jqBound(n *int, def, length int) int {
+	if n == nil {
+		return def
+	}
+
+	i := *n
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+
+	return i
+}
+
+// jqType returns the jq name of the type of the JSON value v.
+func jqType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// jqNumbers returns v with all json.Number values converted to int64 or
+// float64 values, so they are rendered as numbers in all formats.
+func jqNumbers(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n
+		}
+		if f, err := x.Float64(); err == nil {
+			return f
+		}
+
+		return x.String()
+	case []any:
+		for i, e := range x {
+			x[i] = jqNumbers(e)
+		}
+	case map[string]any:
+		for k, e := range x {
+			x[k] = jqNumbers(e)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,191 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQuery(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+	value := map[string]any{
+		"items": []item{{"a", 1}, {"b", 2}, {"c", 3}},
+		"meta":  map[string]any{"total count": 3, "empty": nil},
+	}
+
+	tests := []struct {
+		name      string
+		format    string
+		query     string
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "identity",
+			format: "json",
+			query:  ".",
+			value:  item{"a", 1},
+			want:   `{"name":"a","size":1}` + "\n",
+		},
+		{
+			name:   "key",
+			format: "json",
+			query:  ".meta",
+			value:  value,
+			want:   `{"empty":null,"total count":3}` + "\n",
+		},
+		{
+			name:   "quoted key",
+			format: "json",
+			query:  `.meta."total count"`,
+			value:  value,
+			want:   "3\n",
+		},
+		{
+			name:   "bracketed key",
+			format: "json",
+			query:  `.meta["total count"]`,
+			value:  value,
+			want:   "3\n",
+		},
+		{
+			name:   "missing key",
+			format: "json",
+			query:  ".nope.deeper",
+			value:  value,
+			want:   "null\n",
+		},
+		{
+			name:   "index",
+			format: "json",
+			query:  ".items[1].name",
+			value:  value,
+			want:   `"b"` + "\n",
+		},
+		{
+			name:   "negative index",
+			format: "json",
+			query:  ".items[-1].name",
+			value:  value,
+			want:   `"c"` + "\n",
+		},
+		{
+			name:   "index out of range",
+			format: "json",
+			query:  ".items[5]",
+			value:  value,
+			want:   "null\n",
+		},
+		{
+			name:   "slice",
+			format: "json",
+			query:  ".items[1:].name",
+			value:  value,
+			wantErr: "render: failed: query: " +
+				`cannot index array with "name"`,
+			wantErrIs: []error{Err, ErrFailed, ErrQuery},
+		},
+		{
+			name:   "slice iterated",
+			format: "json",
+			query:  ".items[:2][] | .size",
+			value:  value,
+			want:   "[1,2]\n",
+		},
+		{
+			name:   "iterate",
+			format: "json",
+			query:  ".items[].name",
+			value:  value,
+			want:   `["a","b","c"]` + "\n",
+		},
+		{
+			name:   "iterate object values",
+			format: "json",
+			query:  ".items[0][]",
+			value:  value,
+			want:   `["a",1]` + "\n",
+		},
+		{
+			name:   "pipe",
+			format: "json",
+			query:  ".items | .[0] | .name",
+			value:  value,
+			want:   `"a"` + "\n",
+		},
+		{
+			name:   "optional",
+			format: "json",
+			query:  ".items[].name[]?",
+			value:  value,
+			want:   "[]\n",
+		},
+		{
+			name:   "numbers in yaml",
+			format: "yaml",
+			query:  ".items[0]",
+			value:  value,
+			want:   "name: a\nsize: 1\n",
+		},
+		{
+			name:   "invalid query",
+			format: "json",
+			query:  ".items[",
+			value:  value,
+			wantErr: `render: failed: query: ".items[": ` +
+				"expected index at position 8",
+			wantErrIs: []error{Err, ErrFailed, ErrQuery},
+		},
+		{
+			name:   "unexpected character",
+			format: "json",
+			query:  ".items !",
+			value:  value,
+			wantErr: `render: failed: query: ".items !": ` +
+				`unexpected '!' at position 8`,
+			wantErrIs: []error{Err, ErrFailed, ErrQuery},
+		},
+		{
+			name:      "cannot iterate",
+			format:    "json",
+			query:     ".meta.empty[]",
+			value:     value,
+			wantErr:   "render: failed: query: cannot iterate over null",
+			wantErrIs: []error{Err, ErrFailed, ErrQuery},
+		},
+		{
+			name:      "unmarshalable value",
+			format:    "json",
+			query:     ".a",
+			value:     map[string]any{"a": make(chan int)},
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Base.Render(
+				&buf, tt.format, false, tt.value, WithQuery(tt.query),
+			)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -1,16 +1,27 @@
 package render
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"reflect"
+	"strconv"
 )
 
 // JSONDefualtIndent is the default indentation string used by JSON instances
 // when pretty rendering if no Indent value is set on the JSON instance itself.
 var JSONDefualtIndent = "  "
 
+// JSONDefaultFlushEvery is the default number of elements after which the
+// writer is flushed when streaming slices with JSON instances which have
+// Stream set, if no FlushEvery value is set on the JSON instance itself.
+var JSONDefaultFlushEvery = 100
+
 // JSON is a Handler that marshals values to JSON.
+//
+// Map keys are always sorted, so output is byte-stable across runs.
 type JSON struct {
 	// Prefix is the prefix added to each level of indentation when pretty
 	// rendering.
@@ -19,17 +30,192 @@ type JSON struct {
 	// Indent is the string added to each level of indentation when pretty
 	// rendering. If empty, two spaces will be used instead.
 	Indent string
+
+	// NoEscapeHTML disables escaping of the HTML characters "<", ">", and "&"
+	// in JSON strings as "\u003c", "\u003e", and "\u0026", keeping URLs and
+	// other text readable in CLI output. Escaping is enabled by default, so
+	// output can be safely embedded in HTML.
+	NoEscapeHTML bool
+
+	// NoTrailingNewline omits the newline which is otherwise written after
+	// the rendered JSON value, allowing byte-exact embedding of the output in
+	// other documents. It does not apply to encoders from NewEncoder, where
+	// newlines separate values.
+	NoTrailingNewline bool
+
+	// SortKeys sorts the keys of all JSON objects lexicographically, including
+	// objects rendered from structs, which are otherwise rendered in field
+	// order. This produces canonical output for consumers which diff or hash
+	// it. Map keys are always sorted regardless.
+	SortKeys bool
+
+	// PassThrough renders []byte and string values which contain valid JSON
+	// as is, instead of as JSON strings, only compacting or indenting them as
+	// needed. This allows re-rendering JSON received from elsewhere. Values of
+	// type json.RawMessage are always rendered as is.
+	PassThrough bool
+
+	// Stream renders slice and array values element by element, directly to
+	// the writer, instead of encoding the whole array in memory first. The
+	// writer is flushed every FlushEvery elements if it has a Flush method,
+	// like bufio.Writer or http.ResponseWriter. This keeps memory use flat
+	// when rendering very large slices.
+	Stream bool
+
+	// FlushEvery is the number of elements after which the writer is flushed
+	// when rendering with Stream. If zero, JSONDefaultFlushEvery is used.
+	FlushEvery int
+
+	// NonFinite controls how NaN and infinite float values are rendered,
+	// which JSON cannot represent. By default they fail to render.
+	NonFinite JSONNonFinite
+
+	// Color syntax-highlights keys, strings, numbers, booleans, and nulls with
+	// ANSI escape sequences when pretty rendering, for readability in
+	// terminals. Output is buffered in full before it is written, even with
+	// Stream set.
+	Color bool
+
+	// Engine is the JSON implementation used to encode and decode values. If
+	// nil, encoding/json is used.
+	Engine JSONEngine
+}
+
+// JSONNonFinite is a mode for rendering NaN and infinite float values with the
+// JSON handler.
+type JSONNonFinite int
+
+const (
+	// JSONNonFiniteError fails to render values containing NaN or infinite
+	// floats, as encoding/json does.
+	JSONNonFiniteError JSONNonFinite = iota
+
+	// JSONNonFiniteNull renders NaN and infinite floats as null.
+	JSONNonFiniteNull
+
+	// JSONNonFiniteString renders NaN and infinite floats as the strings
+	// "NaN", "+Inf", and "-Inf".
+	JSONNonFiniteString
+)
+
+// JSONEngine is a JSON implementation used by the JSON handler. It allows
+// replacing encoding/json with alternatives like goccy/go-json or jsoniter,
+// which provide compatible encoder and decoder types, usually with a small
+// adapter type:
+//
+//	type goccyEngine struct{}
+//
+//	func (goccyEngine) NewEncoder(w io.Writer) render.JSONEncoder {
+//		return gojson.NewEncoder(w)
+//	}
+//
+//	func (goccyEngine) NewDecoder(r io.Reader) render.JSONDecoder {
+//		return gojson.NewDecoder(r)
+//	}
+type JSONEngine interface {
+	// NewEncoder returns a JSONEncoder which writes to w.
+	NewEncoder(w io.Writer) JSONEncoder
+
+	// NewDecoder returns a JSONDecoder which reads from r.
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONEncoder is the interface of a JSON encoder returned by a JSONEngine, as
+// implemented by *json.Encoder.
+type JSONEncoder interface {
+	// Encode writes the JSON encoding of v, followed by a newline.
+	Encode(v any) error
+
+	// SetIndent sets the prefix and indent used to indent the output.
+	SetIndent(prefix, indent string)
+
+	// SetEscapeHTML sets if HTML characters are escaped in JSON strings.
+	SetEscapeHTML(on bool)
+}
+
+// JSONDecoder is the interface of a JSON decoder returned by a JSONEngine, as
+// implemented by *json.Decoder.
+type JSONDecoder interface {
+	// Decode reads the next JSON value into v.
+	Decode(v any) error
+}
+
+// stdJSONEngine is a JSONEngine using encoding/json.
+type stdJSONEngine struct{}
+
+func (stdJSONEngine) NewEncoder(w io.Writer) JSONEncoder {
+	return json.NewEncoder(w)
+}
+
+func (stdJSONEngine) NewDecoder(r io.Reader) JSONDecoder {
+	return json.NewDecoder(r)
 }
 
 var (
-	_ Handler        = (*JSON)(nil)
-	_ PrettyHandler  = (*JSON)(nil)
-	_ FormatsHandler = (*JSON)(nil)
+	_ Handler            = (*JSON)(nil)
+	_ PrettyHandler      = (*JSON)(nil)
+	_ FormatsHandler     = (*JSON)(nil)
+	_ DescribedHandler   = (*JSON)(nil)
+	_ ContentTypeHandler = (*JSON)(nil)
+	_ OptionsHandler     = (*JSON)(nil)
+	_ EncoderHandler     = (*JSON)(nil)
+	_ StreamHandler      = (*JSON)(nil)
+	_ ParseHandler       = (*JSON)(nil)
 )
 
 // Render marshals the given value to JSON.
 func (jr *JSON) Render(w io.Writer, v any) error {
-	err := json.NewEncoder(w).Encode(v)
+	return jr.render(w, v, false)
+}
+
+// RenderPretty marshals the given value to JSON with line breaks and
+// indentation.
+func (jr *JSON) RenderPretty(w io.Writer, v any) error {
+	return jr.render(w, v, true)
+}
+
+// render marshals v to w, with indentation if pretty is true.
+func (jr *JSON) render(w io.Writer, v any, pretty bool) error {
+	if pretty && jr.Color {
+		var buf bytes.Buffer
+		c := *jr
+		c.Color = false
+		if err := c.render(&buf, v, pretty); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(jsonColorize(buf.Bytes())); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+
+		return nil
+	}
+
+	if jr.Stream {
+		if rv, ok := jsonStreamable(v); ok {
+			return jr.renderSlice(w, rv, pretty)
+		}
+	}
+
+	out := w
+	var buf bytes.Buffer
+	if jr.NoTrailingNewline {
+		out = &buf
+	}
+
+	enc := jr.newEncoder(out)
+	if pretty {
+		indent := jr.Indent
+		if indent == "" {
+			indent = JSONDefualtIndent
+		}
+		enc.SetIndent(jr.Prefix, indent)
+	}
+
+	err := jr.encode(enc, v)
+	if err == nil && jr.NoTrailingNewline {
+		_, err = w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
@@ -37,19 +223,148 @@ func (jr *JSON) Render(w io.Writer, v any) error {
 	return nil
 }
 
-// RenderPretty marshals the given value to JSON with line breaks and
-// indentation.
-func (jr *JSON) RenderPretty(w io.Writer, v any) error {
-	prefix := jr.Prefix
+// renderSlice renders the elements of the slice or array rv to w one at a time
+// with RenderStream, flushing w every FlushEvery elements.
+func (jr *JSON) renderSlice(w io.Writer, rv reflect.Value, pretty bool) error {
+	every := jr.FlushEvery
+	if every <= 0 {
+		every = JSONDefaultFlushEvery
+	}
+
+	var flushErr error
+	err := jr.RenderStream(w, pretty, func(yield func(any) bool) {
+		for i := 0; i < rv.Len(); i++ {
+			if !yield(rv.Index(i).Interface()) {
+				return
+			}
+			if (i+1)%every == 0 {
+				if flushErr = flush(w); flushErr != nil {
+					return
+				}
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return flushErr
+}
+
+// jsonStreamable returns the reflect.Value of v if it is a non-nil slice or an
+// array which encoding/json renders as a JSON array, and can therefore be
+// streamed element by element.
+func jsonStreamable(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Type().Implements(jsonMarshalerType) ||
+		rv.Type().Implements(textMarshalerType) {
+		return reflect.Value{}, false
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Slice:
+		return rv, !rv.IsNil() && rv.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return rv, rv.Type().Elem().Kind() != reflect.Uint8
+	}
+
+	return reflect.Value{}, false
+}
+
+// NewEncoder returns a ValueEncoder which writes values to w, with
+// indentation configured if pretty is true. Values are encoded the same way as
+// by Render, applying PassThrough, NonFinite, and SortKeys as configured.
+func (jr *JSON) NewEncoder(w io.Writer, pretty bool) ValueEncoder {
+	enc := jr.newEncoder(w)
+	if pretty {
+		indent := jr.Indent
+		if indent == "" {
+			indent = JSONDefualtIndent
+		}
+		enc.SetIndent(jr.Prefix, indent)
+	}
+
+	return &jsonValueEncoder{jr: jr, enc: enc}
+}
+
+// jsonValueEncoder is a ValueEncoder which encodes values with the encode
+// method of a JSON handler.
+type jsonValueEncoder struct {
+	jr  *JSON
+	enc JSONEncoder
+}
+
+func (e *jsonValueEncoder) Encode(v any) error {
+	return e.jr.encode(e.enc, v)
+}
+
+// RenderStream writes each value yielded by seq to w as an element of a JSON
+// array, as they are yielded.
+func (jr *JSON) RenderStream(
+	w io.Writer,
+	pretty bool,
+	seq func(yield func(any) bool),
+) error {
 	indent := jr.Indent
 	if indent == "" {
 		indent = JSONDefualtIndent
 	}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent(prefix, indent)
+	sep := "["
+	if pretty {
+		sep = "[\n" + jr.Prefix + indent
+	}
+
+	var buf bytes.Buffer
+	enc := jr.newEncoder(&buf)
+	if pretty {
+		enc.SetIndent(jr.Prefix+indent, indent)
+	}
+
+	var err error
+	n := 0
+	seq(func(v any) bool {
+		buf.Reset()
+		buf.WriteString(sep)
+		if err = jr.encode(enc, v); err != nil {
+			return false
+		}
+
+		_, err = w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+		n++
+		sep = ","
+		if pretty {
+			sep = ",\n" + jr.Prefix + indent
+		}
+
+		return err == nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	end := "]"
+	switch {
+	case n == 0:
+		end = "[]"
+	case pretty:
+		end = "\n" + jr.Prefix + "]"
+	}
+	if !jr.NoTrailingNewline {
+		end += "\n"
+	}
+
+	_, err = io.WriteString(w, end)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
 
-	err := enc.Encode(v)
+// Parse decodes a JSON value from r into v.
+func (jr *JSON) Parse(r io.Reader, v any) error {
+	err := jr.engine().NewDecoder(r).Decode(v)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
@@ -57,7 +372,230 @@ func (jr *JSON) RenderPretty(w io.Writer, v any) error {
 	return nil
 }
 
+// encode writes v with enc, applying PassThrough, NonFinite, and SortKeys as
+// configured.
+func (jr *JSON) encode(enc JSONEncoder, v any) error {
+	if jr.PassThrough {
+		switch x := v.(type) {
+		case []byte:
+			if json.Valid(x) {
+				v = json.RawMessage(x)
+			}
+		case string:
+			if json.Valid([]byte(x)) {
+				v = json.RawMessage(x)
+			}
+		}
+	}
+
+	if jr.NonFinite != JSONNonFiniteError {
+		v = jr.finite(v)
+	}
+
+	if !jr.SortKeys {
+		return enc.Encode(v)
+	}
+
+	var buf bytes.Buffer
+	if err := jr.newEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+
+	// Decoding to a generic value turns all objects into maps, which are
+	// encoded with sorted keys. Numbers are kept as is with json.Number.
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+
+	var sorted any
+	if err := dec.Decode(&sorted); err != nil {
+		return err
+	}
+
+	return enc.Encode(sorted)
+}
+
+// finite returns v with all NaN and infinite float values replaced as
+// configured by NonFinite. If there are none, v is returned as is.
+func (jr *JSON) finite(v any) any {
+	rv := reflect.ValueOf(v)
+	if !hasNonFinite(rv, 0) {
+		return v
+	}
+
+	vt := &valueTransformer{leaf: func(rv reflect.Value) (any, bool) {
+		if rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+			return nil, false
+		}
+
+		f := rv.Float()
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return nil, false
+		}
+		if jr.NonFinite == JSONNonFiniteString {
+			return strconv.FormatFloat(f, 'g', -1, 64), true
+		}
+
+		return nil, true
+	}}
+
+	return vt.apply(v)
+}
+
+// hasNonFinite reports if rv contains any NaN or infinite float values.
+func hasNonFinite(rv reflect.Value, depth int) bool {
+	if !rv.IsValid() || depth > maxDepth {
+		return false
+	}
+
+	switch x := rv.Interface().(type) {
+	case *viewValue:
+		return hasNonFinite(reflect.ValueOf(x.values), depth+1)
+	case viewList:
+		for _, vv := range x {
+			if hasNonFinite(reflect.ValueOf(vv.values), depth+1) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+
+		return math.IsNaN(f) || math.IsInf(f, 0)
+	case reflect.Pointer, reflect.Interface:
+		return !rv.IsNil() && hasNonFinite(rv.Elem(), depth+1)
+	case reflect.Struct:
+		for _, f := range structFields(rv.Type(), "json") {
+			if hasNonFinite(f.value(rv), depth+1) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if hasNonFinite(rv.Index(i), depth+1) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			if hasNonFinite(iter.Value(), depth+1) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// newEncoder returns a JSONEncoder from the engine of the handler, with HTML
+// escaping configured.
+func (jr *JSON) newEncoder(w io.Writer) JSONEncoder {
+	enc := jr.engine().NewEncoder(w)
+	enc.SetEscapeHTML(!jr.NoEscapeHTML)
+
+	return enc
+}
+
+// jsonMarshal marshals v to JSON like json.Marshal, but without escaping HTML
+// characters. It is used to build documents which are rendered by the JSON
+// handler, which escapes them itself unless NoEscapeHTML is set.
+func jsonMarshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonWith returns a copy of base, or of a JSON handler with default options
+// if base is nil, with prefix and indent set if they are not empty. It is used
+// by handlers which wrap a JSON handler, and have their own Prefix and Indent
+// fields which override those of the wrapped handler.
+func jsonWith(base *JSON, prefix, indent string) *JSON {
+	jr := JSON{}
+	if base != nil {
+		jr = *base
+	}
+	if prefix != "" {
+		jr.Prefix = prefix
+	}
+	if indent != "" {
+		jr.Indent = indent
+	}
+
+	return &jr
+}
+
+// engine returns the JSONEngine of the handler, defaulting to encoding/json.
+func (jr *JSON) engine() JSONEngine {
+	if jr.Engine != nil {
+		return jr.Engine
+	}
+
+	return stdJSONEngine{}
+}
+
 // Formats returns a list of format strings that this Handler supports.
 func (jr *JSON) Formats() []string {
 	return []string{"json"}
 }
+
+// WithOptions returns a copy of the JSON handler with the Prefix and Indent
+// options applied. The "sort_keys", "pass_through", "stream", and "color"
+// parameters set the SortKeys, PassThrough, Stream, and Color fields, if they
+// are valid booleans. The "escape_html" parameter sets NoEscapeHTML to the
+// inverse of its value, like "json;escape_html=false".
+func (jr *JSON) WithOptions(opts *Options) Handler {
+	escapeHTML, hasEscapeHTML := opts.boolParam("escape_html")
+	sortKeys, hasSortKeys := opts.boolParam("sort_keys")
+	passThrough, hasPassThrough := opts.boolParam("pass_through")
+	stream, hasStream := opts.boolParam("stream")
+	color, hasColor := opts.boolParam("color")
+
+	if opts.Prefix == "" && opts.Indent == "" && !hasEscapeHTML &&
+		!hasSortKeys && !hasPassThrough && !hasStream && !hasColor {
+		return jr
+	}
+
+	c := *jr
+	if opts.Prefix != "" {
+		c.Prefix = opts.Prefix
+	}
+	if opts.Indent != "" {
+		c.Indent = opts.Indent
+	}
+	if hasEscapeHTML {
+		c.NoEscapeHTML = !escapeHTML
+	}
+	if hasSortKeys {
+		c.SortKeys = sortKeys
+	}
+	if hasPassThrough {
+		c.PassThrough = passThrough
+	}
+	if hasStream {
+		c.Stream = stream
+	}
+	if hasColor {
+		c.Color = color
+	}
+
+	return &c
+}
+
+// Description returns a short human-readable description of the format.
+func (jr *JSON) Description() string {
+	return "JSON, machine readable"
+}
+
+// ContentType returns the MIME type of the format.
+func (jr *JSON) ContentType() string {
+	return "application/json"
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,22 +24,71 @@ func (mjm *mockJSONMarshaler) MarshalJSON() ([]byte, error) {
 	return mjm.data, mjm.err
 }
 
+// mockJSONEngine is a JSONEngine which wraps encoding/json, upper casing all
+// encoded output, and recording decoded values.
+type mockJSONEngine struct {
+	decoded []any
+}
+
+var _ JSONEngine = (*mockJSONEngine)(nil)
+
+func (m *mockJSONEngine) NewEncoder(w io.Writer) JSONEncoder {
+	return json.NewEncoder(&mockUpperWriter{w: w})
+}
+
+func (m *mockJSONEngine) NewDecoder(r io.Reader) JSONDecoder {
+	return &mockJSONDecoder{engine: m, dec: json.NewDecoder(r)}
+}
+
+type mockJSONDecoder struct {
+	engine *mockJSONEngine
+	dec    *json.Decoder
+}
+
+func (m *mockJSONDecoder) Decode(v any) error {
+	err := m.dec.Decode(v)
+	m.engine.decoded = append(m.engine.decoded, v)
+
+	return err
+}
+
+type mockUpperWriter struct {
+	w io.Writer
+}
+
+func (m *mockUpperWriter) Write(p []byte) (int, error) {
+	return m.w.Write(bytes.ToUpper(p))
+}
+
 func TestJSON_Render(t *testing.T) {
 	tests := []struct {
-		name       string
-		prefix     string
-		indent     string
-		value      any
-		want       string
-		wantPretty string
-		wantErr    string
-		wantErrIs  []error
+		name         string
+		prefix       string
+		indent       string
+		noEscapeHTML bool
+		value        any
+		want         string
+		wantPretty   string
+		wantErr      string
+		wantErrIs    []error
 	}{
 		{
 			name:  "simple object",
 			value: map[string]int{"age": 30},
 			want:  "{\"age\":30}\n",
 		},
+		{
+			name:  "escapes HTML by default",
+			value: "https://example.com/?a=<b>&c=d",
+			want: "\"https://example.com/?a=" +
+				"\\u003cb\\u003e\\u0026c=d\"\n",
+		},
+		{
+			name:         "does not escape HTML",
+			noEscapeHTML: true,
+			value:        "https://example.com/?a=<b>&c=d",
+			want:         "\"https://example.com/?a=<b>&c=d\"\n",
+		},
 		{
 			name:   "ignores prefix and indent",
 			prefix: "// ",
@@ -64,8 +116,9 @@ func TestJSON_Render(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			j := &JSON{
-				Prefix: tt.prefix,
-				Indent: tt.indent,
+				Prefix:       tt.prefix,
+				Indent:       tt.indent,
+				NoEscapeHTML: tt.noEscapeHTML,
 			}
 			var buf bytes.Buffer
 
@@ -160,3 +213,451 @@ func TestJSON_Formats(t *testing.T) {
 
 	assert.Equal(t, []string{"json"}, h.Formats())
 }
+
+func TestJSON_WithOptions(t *testing.T) {
+	h := &JSON{Prefix: "//", Indent: "  "}
+
+	assert.Same(t, h, h.WithOptions(&Options{Pretty: true}))
+	assert.Equal(t,
+		&JSON{Prefix: "//", Indent: "\t"},
+		h.WithOptions(&Options{Indent: "\t"}),
+	)
+	assert.Equal(t,
+		&JSON{Prefix: "> ", Indent: "  "},
+		h.WithOptions(&Options{Prefix: "> "}),
+	)
+	assert.Equal(t,
+		&JSON{Prefix: "//", Indent: "  ", NoEscapeHTML: true},
+		h.WithOptions(&Options{Params: map[string]string{"escape_html": "0"}}),
+	)
+	assert.Equal(t,
+		&JSON{Prefix: "//", Indent: "  "},
+		h.WithOptions(&Options{Params: map[string]string{"escape_html": "1"}}),
+	)
+	assert.Same(t,
+		h,
+		h.WithOptions(&Options{Params: map[string]string{"escape_html": "?"}}),
+	)
+	assert.Equal(t, &JSON{Prefix: "//", Indent: "  "}, h)
+}
+
+func TestJSON_NewEncoder(t *testing.T) {
+	h := &JSON{Prefix: "//", Indent: "\t"}
+
+	var buf bytes.Buffer
+	enc := h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode(map[string]int{"a": 1}))
+	require.NoError(t, enc.Encode(2))
+	assert.Equal(t, "{\"a\":1}\n2\n", buf.String())
+
+	buf.Reset()
+	enc = h.NewEncoder(&buf, true)
+	require.NoError(t, enc.Encode(map[string]int{"a": 1}))
+	assert.Equal(t, "{\n//\t\"a\": 1\n//}\n", buf.String())
+}
+
+func TestJSON_Parse(t *testing.T) {
+	h := &JSON{}
+
+	var got struct {
+		Name string `json:"name"`
+	}
+	err := h.Parse(strings.NewReader(`{"name":"foo"}`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Name)
+
+	err = h.Parse(strings.NewReader(`{`), &got)
+	assert.EqualError(t, err, "render: failed: unexpected EOF")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestJSON_Engine(t *testing.T) {
+	engine := &mockJSONEngine{}
+	h := &JSON{Engine: engine, Indent: "\t"}
+	value := map[string]any{"a": []string{"b"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, h.Render(&buf, value))
+	assert.Equal(t, `{"A":["B"]}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, h.RenderPretty(&buf, value))
+	assert.Equal(t, "{\n\t\"A\": [\n\t\t\"B\"\n\t]\n}\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, h.NewEncoder(&buf, false).Encode("x"))
+	assert.Equal(t, `"X"`+"\n", buf.String())
+
+	buf.Reset()
+	err := h.RenderStream(&buf, false, func(yield func(any) bool) {
+		_ = yield("a") && yield("b")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `["A","B"]`+"\n", buf.String())
+
+	var got map[string]int
+	require.NoError(t, h.Parse(strings.NewReader(`{"a":1}`), &got))
+	assert.Equal(t, map[string]int{"a": 1}, got)
+	assert.Equal(t, []any{&got}, engine.decoded)
+}
+
+func TestJSON_NoTrailingNewline(t *testing.T) {
+	h := &JSON{NoTrailingNewline: true}
+	value := map[string]int{"a": 1}
+
+	var buf bytes.Buffer
+	require.NoError(t, h.Render(&buf, value))
+	assert.Equal(t, `{"a":1}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, h.RenderPretty(&buf, value))
+	assert.Equal(t, "{\n  \"a\": 1\n}", buf.String())
+
+	buf.Reset()
+	err := h.RenderStream(&buf, false, func(yield func(any) bool) {
+		yield(1)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[1]", buf.String())
+
+	buf.Reset()
+	err = h.RenderStream(&buf, false, func(func(any) bool) {})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", buf.String())
+
+	err = h.Render(&mockWriter{WriteErr: errors.New("write error")}, value)
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestJSON_SortKeys(t *testing.T) {
+	type item struct {
+		Zeta  string  `json:"zeta"`
+		Alpha float64 `json:"alpha"`
+		Mid   any     `json:"mid"`
+	}
+	value := item{
+		Zeta:  "<z>",
+		Alpha: 12345678901234567890,
+		Mid:   item{Zeta: "y", Alpha: 0.5},
+	}
+	h := &JSON{SortKeys: true}
+
+	var buf bytes.Buffer
+	require.NoError(t, h.Render(&buf, value))
+	assert.Equal(t,
+		`{"alpha":12345678901234567000,"mid":{"alpha":0.5,"mid":null,`+
+			`"zeta":"y"},"zeta":"\u003cz\u003e"}`+"\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	require.NoError(t, h.RenderPretty(&buf, item{Zeta: "a", Alpha: 1}))
+	assert.Equal(t,
+		"{\n  \"alpha\": 1,\n  \"mid\": null,\n  \"zeta\": \"a\"\n}\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	enc := h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode(item{Zeta: "a"}))
+	assert.Equal(t, `{"alpha":0,"mid":null,"zeta":"a"}`+"\n", buf.String())
+
+	buf.Reset()
+	err := h.RenderStream(&buf, false, func(yield func(any) bool) {
+		yield(item{Zeta: "a"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"alpha":0,"mid":null,"zeta":"a"}]`+"\n", buf.String())
+
+	err = h.Render(&buf, make(chan int))
+	assert.EqualError(t,
+		err, "render: failed: json: unsupported type: chan int",
+	)
+
+	assert.Equal(t,
+		&JSON{SortKeys: true},
+		(&JSON{}).WithOptions(&Options{
+			Params: map[string]string{"sort_keys": "true"},
+		}),
+	)
+}
+
+func TestJSON_NonFinite(t *testing.T) {
+	type metric struct {
+		Name  string    `json:"name"`
+		Value float64   `json:"value"`
+		Min   float32   `json:"min,omitempty"`
+		Hist  []float64 `json:"hist"`
+	}
+	value := map[string]any{
+		"ok": metric{Name: "a", Value: 1.5},
+		"bad": &metric{
+			Name:  "b",
+			Value: math.NaN(),
+			Hist:  []float64{1, math.Inf(-1)},
+		},
+		"view": &viewValue{labels: []string{"x"}, values: []any{math.Inf(1)}},
+	}
+
+	tests := []struct {
+		name      string
+		nonFinite JSONNonFinite
+		value     any
+		want      string
+		wantErr   string
+	}{
+		{
+			name:    "error",
+			value:   value,
+			wantErr: "render: failed: json: unsupported value: NaN",
+		},
+		{
+			name:      "null",
+			nonFinite: JSONNonFiniteNull,
+			value:     value,
+			want: `{"bad":{"name":"b","value":null,"hist":[1,null]},` +
+				`"ok":{"name":"a","value":1.5,"hist":null},` +
+				`"view":{"x":null}}` + "\n",
+		},
+		{
+			name:      "string",
+			nonFinite: JSONNonFiniteString,
+			value:     value,
+			want: `{"bad":{"name":"b","value":"NaN","hist":[1,"-Inf"]},` +
+				`"ok":{"name":"a","value":1.5,"hist":null},` +
+				`"view":{"x":"+Inf"}}` + "\n",
+		},
+		{
+			name:      "finite values are unchanged",
+			nonFinite: JSONNonFiniteNull,
+			value:     metric{Name: "a", Value: 1},
+			want:      `{"name":"a","value":1,"hist":null}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &JSON{NonFinite: tt.nonFinite}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestJSON_PassThrough(t *testing.T) {
+	tests := []struct {
+		name        string
+		passThrough bool
+		pretty      bool
+		value       any
+		want        string
+	}{
+		{
+			name:  "raw message",
+			value: json.RawMessage(`{ "a" : [1, 2] }`),
+			want:  `{"a":[1,2]}` + "\n",
+		},
+		{
+			name:  "bytes without pass through",
+			value: []byte(`{"a":1}`),
+			want:  `"eyJhIjoxfQ=="` + "\n",
+		},
+		{
+			name:  "string without pass through",
+			value: `{"a":1}`,
+			want:  `"{\"a\":1}"` + "\n",
+		},
+		{
+			name:        "bytes",
+			passThrough: true,
+			value:       []byte(`{ "a" : 1 }`),
+			want:        `{"a":1}` + "\n",
+		},
+		{
+			name:        "string pretty",
+			passThrough: true,
+			pretty:      true,
+			value:       `{"a":[1]}`,
+			want:        "{\n  \"a\": [\n    1\n  ]\n}\n",
+		},
+		{
+			name:        "invalid JSON string",
+			passThrough: true,
+			value:       `{"a":`,
+			want:        `"{\"a\":"` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &JSON{PassThrough: tt.passThrough}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_jsonPassThroughParam(t *testing.T) {
+	got, err := Base.String("json;pass_through", false, `[1, 2]`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "[1,2]\n", got)
+}
+
+func TestJSON_Color(t *testing.T) {
+	value := map[string]any{"a": []any{"b", 1, true, nil}}
+	h := &JSON{Color: true}
+
+	var buf bytes.Buffer
+	err := h.RenderPretty(&buf, value)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"{\n  \x1b[1;34m\"a\"\x1b[0m: [\n"+
+			"    \x1b[32m\"b\"\x1b[0m,\n"+
+			"    \x1b[36m1\x1b[0m,\n"+
+			"    \x1b[33mtrue\x1b[0m,\n"+
+			"    \x1b[90mnull\x1b[0m\n"+
+			"  ]\n}\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	err = h.Render(&buf, value)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":["b",1,true,null]}`+"\n", buf.String())
+}
+
+func TestRenderer_Render_jsonColorParam(t *testing.T) {
+	got, err := Base.String("json;color", true, []int{1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "[\n  \x1b[36m1\x1b[0m\n]\n", got)
+}
+
+func TestJSON_Stream(t *testing.T) {
+	tests := []struct {
+		name        string
+		h           *JSON
+		pretty      bool
+		value       any
+		want        string
+		wantFlushed []string
+		wantErr     string
+	}{
+		{
+			name:  "slice",
+			h:     &JSON{Stream: true},
+			value: []int{1, 2, 3},
+			want:  "[1,2,3]\n",
+		},
+		{
+			name:   "slice pretty",
+			h:      &JSON{Stream: true},
+			pretty: true,
+			value:  []any{map[string]int{"a": 1}, "b"},
+			want:   "[\n  {\n    \"a\": 1\n  },\n  \"b\"\n]\n",
+		},
+		{
+			name:  "array",
+			h:     &JSON{Stream: true},
+			value: [2]string{"a", "b"},
+			want:  `["a","b"]` + "\n",
+		},
+		{
+			name:  "empty slice",
+			h:     &JSON{Stream: true},
+			value: []int{},
+			want:  "[]\n",
+		},
+		{
+			name:  "nil slice",
+			h:     &JSON{Stream: true},
+			value: []int(nil),
+			want:  "null\n",
+		},
+		{
+			name:  "bytes",
+			h:     &JSON{Stream: true},
+			value: []byte("hi"),
+			want:  `"aGk="` + "\n",
+		},
+		{
+			name:  "non-slice",
+			h:     &JSON{Stream: true},
+			value: map[string]int{"a": 1},
+			want:  `{"a":1}` + "\n",
+		},
+		{
+			name:        "flushes every n elements",
+			h:           &JSON{Stream: true, FlushEvery: 2},
+			value:       []int{1, 2, 3, 4, 5},
+			want:        "[1,2,3,4,5]\n",
+			wantFlushed: []string{"[1,2", "[1,2,3,4"},
+		},
+		{
+			name:    "element error",
+			h:       &JSON{Stream: true},
+			value:   []any{1, make(chan int)},
+			wantErr: "render: failed: json: unsupported type: chan int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &streamTestFlusher{}
+
+			var err error
+			if tt.pretty {
+				err = tt.h.RenderPretty(w, tt.value)
+			} else {
+				err = tt.h.Render(w, tt.value)
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrFailed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+			assert.Equal(t, tt.wantFlushed, w.flushed)
+		})
+	}
+}
+
+func TestJSON_Stream_flushError(t *testing.T) {
+	h := &JSON{Stream: true, FlushEvery: 1}
+	w := &streamTestFlusher{err: errors.New("flush error")}
+
+	err := h.Render(w, []int{1, 2})
+
+	assert.EqualError(t, err, "render: failed: flush error")
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.Equal(t, []string{"[1"}, w.flushed)
+}
+
+func TestRenderer_Render_jsonStreamParam(t *testing.T) {
+	got, err := Base.String("json;stream", true, []int{1, 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, "[\n  1,\n  2\n]\n", got)
+}
@@ -0,0 +1,273 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// JSONAPI is a Handler that renders structs, and slices or arrays of structs,
+// as JSON:API (https://jsonapi.org) documents.
+//
+// Structs describe their resource representation with "jsonapi" struct tags:
+//
+//   - `jsonapi:"primary,articles"` marks the resource ID field, and gives the
+//     resource type. It is required.
+//   - `jsonapi:"attr,title"` renders the field as the "title" attribute. The
+//     "omitempty" option skips zero values, for example
+//     `jsonapi:"attr,title,omitempty"`.
+//   - `jsonapi:"relation,author"` renders the field as the "author"
+//     relationship. The field must be a struct, or a slice of structs, with a
+//     primary tag. Related resources are also added to the "included" section
+//     of the document.
+//
+// If the value is not a struct with a primary tag, or a slice or array of them,
+// a ErrCannotRender error will be returned.
+type JSONAPI struct {
+	// Prefix is the prefix added to each level of indentation when pretty
+	// rendering.
+	Prefix string
+
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, the Indent of the JSON handler is used.
+	Indent string
+
+	// JSON encodes the documents. If nil, a JSON handler with default options
+	// is used.
+	JSON *JSON
+}
+
+var (
+	_ Handler            = (*JSONAPI)(nil)
+	_ PrettyHandler      = (*JSONAPI)(nil)
+	_ FormatsHandler     = (*JSONAPI)(nil)
+	_ DescribedHandler   = (*JSONAPI)(nil)
+	_ ContentTypeHandler = (*JSONAPI)(nil)
+	_ OptionsHandler     = (*JSONAPI)(nil)
+)
+
+// Render marshals the given value to a JSON:API document.
+func (ja *JSONAPI) Render(w io.Writer, v any) error {
+	doc, err := newJSONAPIDocument(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(ja.JSON, ja.Prefix, ja.Indent).Render(w, doc)
+}
+
+// RenderPretty marshals the given value to a JSON:API document with line
+// breaks and indentation.
+func (ja *JSONAPI) RenderPretty(w io.Writer, v any) error {
+	doc, err := newJSONAPIDocument(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(ja.JSON, ja.Prefix, ja.Indent).RenderPretty(w, doc)
+}
+
+// WithOptions returns a copy of the JSONAPI handler, with the options applied
+// to its JSON handler.
+func (ja *JSONAPI) WithOptions(opts *Options) Handler {
+	jr := jsonWith(ja.JSON, ja.Prefix, ja.Indent)
+
+	c := *ja
+	c.JSON, _ = jr.WithOptions(opts).(*JSON)
+	c.Prefix = ""
+	c.Indent = ""
+
+	return &c
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (ja *JSONAPI) Formats() []string {
+	return []string{"jsonapi"}
+}
+
+// Description returns a short human-readable description of the format.
+func (ja *JSONAPI) Description() string {
+	return "JSON:API document"
+}
+
+// ContentType returns the MIME type of the format.
+func (ja *JSONAPI) ContentType() string {
+	return "application/vnd.api+json"
+}
+
+type jsonAPIDocument struct {
+	Data     any                `json:"data"`
+	Included []*jsonAPIResource `json:"included,omitempty"`
+}
+
+type jsonAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id,omitempty"`
+	Attributes    map[string]any                 `json:"attributes,omitempty"`
+	Relationships map[string]jsonAPIRelationship `json:"relationships,omitempty"`
+}
+
+type jsonAPIRelationship struct {
+	Data any `json:"data"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// jsonAPIField is a struct field with a parsed "jsonapi" tag.
+type jsonAPIField struct {
+	structField
+	kind      string
+	name      string
+	omitempty bool
+}
+
+func jsonAPIFields(
+	t reflect.Type,
+) (primary *jsonAPIField, fields []jsonAPIField) {
+	for _, f := range structFields(t, "") {
+		tag := t.FieldByIndex(f.index).Tag.Get("jsonapi")
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 {
+			continue
+		}
+
+		jf := jsonAPIField{structField: f, kind: parts[0], name: parts[1]}
+		for _, opt := range parts[2:] {
+			if opt == "omitempty" {
+				jf.omitempty = true
+			}
+		}
+
+		if jf.kind == "primary" {
+			primary = &jf
+		} else {
+			fields = append(fields, jf)
+		}
+	}
+
+	return primary, fields
+}
+
+// jsonAPIBuilder builds a JSON:API document, keeping track of included
+// resources.
+type jsonAPIBuilder struct {
+	seen     map[jsonAPIIdentifier]bool
+	included []*jsonAPIResource
+}
+
+func newJSONAPIDocument(v any) (*jsonAPIDocument, error) {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+	if primary, _ := jsonAPIFields(t); primary == nil {
+		return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	b := &jsonAPIBuilder{seen: map[jsonAPIIdentifier]bool{}}
+
+	resources := make([]*jsonAPIResource, 0, len(rows))
+	for _, row := range rows {
+		b.seen[jsonAPIIdentifierOf(row)] = true
+	}
+	for _, row := range rows {
+		resources = append(resources, b.resource(row))
+	}
+
+	doc := &jsonAPIDocument{Data: resources, Included: b.included}
+
+	rv := indirect(reflect.ValueOf(v))
+	if rv.Kind() == reflect.Struct {
+		doc.Data = resources[0]
+	}
+
+	return doc, nil
+}
+
+func jsonAPIIdentifierOf(rv reflect.Value) jsonAPIIdentifier {
+	primary, _ := jsonAPIFields(rv.Type())
+	if primary == nil {
+		return jsonAPIIdentifier{}
+	}
+
+	id := ""
+	if fv := indirect(primary.value(rv)); fv.IsValid() && !fv.IsZero() {
+		id = fmt.Sprint(fv.Interface())
+	}
+
+	return jsonAPIIdentifier{Type: primary.name, ID: id}
+}
+
+func (b *jsonAPIBuilder) resource(rv reflect.Value) *jsonAPIResource {
+	_, fields := jsonAPIFields(rv.Type())
+	ident := jsonAPIIdentifierOf(rv)
+
+	res := &jsonAPIResource{Type: ident.Type, ID: ident.ID}
+
+	for _, f := range fields {
+		fv := f.value(rv)
+
+		switch f.kind {
+		case "attr":
+			if !fv.IsValid() || (f.omitempty && fv.IsZero()) {
+				continue
+			}
+			if res.Attributes == nil {
+				res.Attributes = map[string]any{}
+			}
+			res.Attributes[f.name] = fv.Interface()
+		case "relation":
+			if res.Relationships == nil {
+				res.Relationships = map[string]jsonAPIRelationship{}
+			}
+			res.Relationships[f.name] = b.relationship(fv)
+		}
+	}
+
+	return res
+}
+
+// relationship returns the relationship for the given field value, adding any
+// related resources to the included resources of the document.
+func (b *jsonAPIBuilder) relationship(fv reflect.Value) jsonAPIRelationship {
+	fv = indirect(fv)
+	if !fv.IsValid() {
+		return jsonAPIRelationship{Data: nil}
+	}
+
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		idents := make([]jsonAPIIdentifier, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			if ev := indirect(fv.Index(i)); ev.IsValid() {
+				idents = append(idents, b.include(ev))
+			}
+		}
+
+		return jsonAPIRelationship{Data: idents}
+	}
+
+	return jsonAPIRelationship{Data: b.include(fv)}
+}
+
+// include adds the resource for rv to the included resources, unless it has
+// already been seen, and returns its identifier.
+func (b *jsonAPIBuilder) include(rv reflect.Value) jsonAPIIdentifier {
+	ident := jsonAPIIdentifierOf(rv)
+	if b.seen[ident] || ident.Type == "" {
+		return ident
+	}
+
+	b.seen[ident] = true
+
+	// Reserve the position of the resource before building it, so it is
+	// included before any resources it relates to.
+	res := &jsonAPIResource{}
+	b.included = append(b.included, res)
+	*res = *b.resource(rv)
+
+	return ident
+}
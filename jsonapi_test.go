@@ -0,0 +1,219 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonAPITestPerson struct {
+	ID   int    `jsonapi:"primary,people"`
+	Name string `jsonapi:"attr,name"`
+}
+
+type jsonAPITestComment struct {
+	ID     string             `jsonapi:"primary,comments"`
+	Body   string             `jsonapi:"attr,body"`
+	Author *jsonAPITestPerson `jsonapi:"relation,author"`
+}
+
+type jsonAPITestArticle struct {
+	ID       string                `jsonapi:"primary,articles"`
+	Title    string                `jsonapi:"attr,title"`
+	Subtitle string                `jsonapi:"attr,subtitle,omitempty"`
+	Views    int                   `jsonapi:"attr,views"`
+	Author   *jsonAPITestPerson    `jsonapi:"relation,author"`
+	Comments []*jsonAPITestComment `jsonapi:"relation,comments"`
+	Internal string
+}
+
+func TestJSONAPI_Render(t *testing.T) {
+	john := &jsonAPITestPerson{ID: 9, Name: "John"}
+	jane := &jsonAPITestPerson{ID: 10, Name: "Jane"}
+
+	tests := []struct {
+		name      string
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "single resource with relationships",
+			value: &jsonAPITestArticle{
+				ID:     "1",
+				Title:  "Hello",
+				Views:  42,
+				Author: john,
+				Comments: []*jsonAPITestComment{
+					{ID: "5", Body: "First!", Author: jane},
+					{ID: "6", Body: "Nice", Author: john},
+				},
+				Internal: "hidden",
+			},
+			want: `{"data":{"type":"articles","id":"1",` +
+				`"attributes":{"title":"Hello","views":42},` +
+				`"relationships":{` +
+				`"author":{"data":{"type":"people","id":"9"}},` +
+				`"comments":{"data":[` +
+				`{"type":"comments","id":"5"},` +
+				`{"type":"comments","id":"6"}]}}},` +
+				`"included":[` +
+				`{"type":"people","id":"9","attributes":{"name":"John"}},` +
+				`{"type":"comments","id":"5","attributes":{"body":"First!"},` +
+				`"relationships":{"author":{"data":` +
+				`{"type":"people","id":"10"}}}},` +
+				`{"type":"people","id":"10","attributes":{"name":"Jane"}},` +
+				`{"type":"comments","id":"6","attributes":{"body":"Nice"},` +
+				`"relationships":{"author":{"data":` +
+				`{"type":"people","id":"9"}}}}]}` + "\n",
+		},
+		{
+			name: "collection with empty relationships",
+			value: []jsonAPITestArticle{
+				{ID: "1", Title: "Hello", Subtitle: "World"},
+				{ID: "2", Title: "Bye"},
+			},
+			want: `{"data":[` +
+				`{"type":"articles","id":"1",` +
+				`"attributes":{"subtitle":"World","title":"Hello",` +
+				`"views":0},` +
+				`"relationships":{"author":{"data":null},` +
+				`"comments":{"data":[]}}},` +
+				`{"type":"articles","id":"2",` +
+				`"attributes":{"title":"Bye","views":0},` +
+				`"relationships":{"author":{"data":null},` +
+				`"comments":{"data":[]}}}]}` + "\n",
+		},
+		{
+			name:   "pretty",
+			pretty: true,
+			value:  john,
+			want: `{
+  "data": {
+    "type": "people",
+    "id": "9",
+    "attributes": {
+      "name": "John"
+    }
+  }
+}
+`,
+		},
+		{
+			name:   "empty collection",
+			value:  []jsonAPITestPerson{},
+			want:   `{"data":[]}` + "\n",
+			pretty: false,
+		},
+		{
+			name:      "struct without primary tag",
+			value:     struct{ Name string }{Name: "John"},
+			wantErr:   "render: cannot render: struct { Name string }",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "not struct based",
+			value:     "hello",
+			wantErr:   "render: cannot render: string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &JSONAPI{}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestJSONAPI_Formats(t *testing.T) {
+	h := &JSONAPI{}
+
+	assert.Equal(t, []string{"jsonapi"}, h.Formats())
+}
+
+func TestJSONAPI_WithOptions(t *testing.T) {
+	h := &JSONAPI{Prefix: "//", JSON: &JSON{NoEscapeHTML: true}}
+
+	got := h.WithOptions(&Options{
+		Indent: "\t",
+		Params: map[string]string{"sort_keys": "true"},
+	})
+
+	assert.Equal(t,
+		&JSONAPI{JSON: &JSON{
+			Prefix: "//", Indent: "\t", NoEscapeHTML: true, SortKeys: true,
+		}},
+		got,
+	)
+	assert.Equal(t,
+		&JSONAPI{Prefix: "//", JSON: &JSON{NoEscapeHTML: true}}, h,
+	)
+}
+
+func TestRenderer_Render_jsonAPIOptions(t *testing.T) {
+	r := New(map[string]Handler{"jsonapi": &JSONAPI{}})
+	person := &jsonAPITestPerson{ID: 9, Name: "<John>"}
+
+	tests := []struct {
+		name   string
+		format string
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "escapes HTML by default",
+			format: "jsonapi",
+			want: `{"data":{"type":"people","id":"9",` +
+				`"attributes":{"name":"\u003cJohn\u003e"}}}` + "\n",
+		},
+		{
+			name:   "format parameters",
+			format: "jsonapi+pretty;indent=1;escape_html=false",
+			want: "{\n \"data\": {\n  \"type\": \"people\",\n" +
+				"  \"id\": \"9\",\n  \"attributes\": {\n" +
+				"   \"name\": \"<John>\"\n  }\n }\n}\n",
+		},
+		{
+			name:   "WithIndent",
+			format: "jsonapi",
+			opts:   []Option{WithPretty(), WithIndent("\t")},
+			want: "{\n\t\"data\": {\n\t\t\"type\": \"people\",\n" +
+				"\t\t\"id\": \"9\",\n\t\t\"attributes\": {\n" +
+				"\t\t\t\"name\": \"\\u003cJohn\\u003e\"\n\t\t}\n\t}\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, person, tt.opts...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
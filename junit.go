@@ -0,0 +1,304 @@
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// JUnitTestSuite is a suite of test results rendered by the JUnit handler.
+type JUnitTestSuite struct {
+	// Name is the name of the test suite.
+	Name string
+
+	// Time is the total time taken by the suite. If zero, the sum of the time
+	// taken by all test cases is used.
+	Time time.Duration
+
+	// Timestamp is the time the suite started. Zero times are omitted.
+	Timestamp time.Time
+
+	// Properties are arbitrary key/value properties of the suite, like
+	// environment details.
+	Properties map[string]string
+
+	// Cases are the test cases of the suite.
+	Cases []JUnitTestCase
+
+	// SystemOut and SystemErr are the standard output and error of the suite.
+	SystemOut string
+	SystemErr string
+}
+
+// JUnitTestCase is a single test result within a JUnitTestSuite.
+type JUnitTestCase struct {
+	// Name is the name of the test case.
+	Name string
+
+	// ClassName is the class, package, or other grouping of the test case.
+	ClassName string
+
+	// Time is the time taken by the test case.
+	Time time.Duration
+
+	// Failure is set if the test case failed.
+	Failure *JUnitResult
+
+	// Error is set if the test case could not be run due to an error.
+	Error *JUnitResult
+
+	// Skipped is set if the test case was skipped.
+	Skipped *JUnitResult
+
+	// SystemOut and SystemErr are the standard output and error of the test
+	// case.
+	SystemOut string
+	SystemErr string
+}
+
+// JUnitResult describes a failed, errored, or skipped JUnitTestCase.
+type JUnitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitProvider is an optional interface that can be implemented by values
+// rendered by the JUnit handler, to convert domain specific test results to
+// JUnit test suites.
+type JUnitProvider interface {
+	// JUnit returns the test suites to render.
+	JUnit() []JUnitTestSuite
+}
+
+// JUnit is a Handler that renders test results as JUnit XML reports, which are
+// supported by most CI systems.
+//
+// It supports JUnitTestSuite values, slices of them, pointers to either, and
+// values which implement JUnitProvider. Test, failure, error, and skipped
+// counts are calculated from the test cases.
+//
+// If the value is not a supported type, a ErrCannotRender error will be
+// returned.
+type JUnit struct {
+	// Name is the name of the report, set on the top-level testsuites element.
+	Name string
+
+	// Prefix is the prefix added to each level of indentation when pretty
+	// rendering.
+	Prefix string
+
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, XMLDefualtIndent is used.
+	Indent string
+}
+
+var (
+	_ Handler            = (*JUnit)(nil)
+	_ PrettyHandler      = (*JUnit)(nil)
+	_ FormatsHandler     = (*JUnit)(nil)
+	_ DescribedHandler   = (*JUnit)(nil)
+	_ ContentTypeHandler = (*JUnit)(nil)
+)
+
+// Render writes v as a JUnit XML report to w.
+func (ju *JUnit) Render(w io.Writer, v any) error {
+	return ju.render(w, v, false)
+}
+
+// RenderPretty writes v as a JUnit XML report to w with line breaks and
+// indentation.
+func (ju *JUnit) RenderPretty(w io.Writer, v any) error {
+	return ju.render(w, v, true)
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (ju *JUnit) Formats() []string {
+	return []string{"junit"}
+}
+
+// Description returns a short human-readable description of the format.
+func (ju *JUnit) Description() string {
+	return "JUnit XML test report"
+}
+
+// ContentType returns the MIME type of the format.
+func (ju *JUnit) ContentType() string {
+	return "application/xml"
+}
+
+func (ju *JUnit) render(w io.Writer, v any, pretty bool) error {
+	var suites []JUnitTestSuite
+	switch x := v.(type) {
+	case JUnitProvider:
+		suites = x.JUnit()
+	case JUnitTestSuite:
+		suites = []JUnitTestSuite{x}
+	case *JUnitTestSuite:
+		if x == nil {
+			return fmt.Errorf("%w: %T", ErrCannotRender, v)
+		}
+		suites = []JUnitTestSuite{*x}
+	case []JUnitTestSuite:
+		suites = x
+	case []*JUnitTestSuite:
+		for _, s := range x {
+			if s != nil {
+				suites = append(suites, *s)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	report := newJUnitReport(ju.Name, suites)
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	enc := xml.NewEncoder(w)
+	if pretty {
+		indent := ju.Indent
+		if indent == "" {
+			indent = XMLDefualtIndent
+		}
+		enc.Indent(ju.Prefix, indent)
+	}
+
+	err = enc.Encode(report)
+	if err == nil {
+		_, err = io.WriteString(w, "\n")
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+type junitXMLCounts struct {
+	Tests    int    `xml:"tests,attr"`
+	Failures int    `xml:"failures,attr"`
+	Errors   int    `xml:"errors,attr"`
+	Skipped  int    `xml:"skipped,attr"`
+	Time     string `xml:"time,attr"`
+}
+
+type junitXMLReport struct {
+	XMLName xml.Name `xml:"testsuites"`
+	Name    string   `xml:"name,attr,omitempty"`
+	junitXMLCounts
+	Suites []junitXMLSuite `xml:"testsuite"`
+}
+
+type junitXMLSuite struct {
+	Name string `xml:"name,attr"`
+	junitXMLCounts
+	Timestamp  string              `xml:"timestamp,attr,omitempty"`
+	Properties *junitXMLProperties `xml:"properties,omitempty"`
+	Cases      []junitXMLCase      `xml:"testcase"`
+	SystemOut  string              `xml:"system-out,omitempty"`
+	SystemErr  string              `xml:"system-err,omitempty"`
+}
+
+type junitXMLProperties struct {
+	Properties []junitXMLProperty `xml:"property"`
+}
+
+type junitXMLProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitXMLCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr,omitempty"`
+	Time      string       `xml:"time,attr"`
+	Failure   *JUnitResult `xml:"failure,omitempty"`
+	Error     *JUnitResult `xml:"error,omitempty"`
+	Skipped   *JUnitResult `xml:"skipped,omitempty"`
+	SystemOut string       `xml:"system-out,omitempty"`
+	SystemErr string       `xml:"system-err,omitempty"`
+}
+
+func newJUnitReport(name string, suites []JUnitTestSuite) *junitXMLReport {
+	report := &junitXMLReport{Name: name}
+
+	var total time.Duration
+	for _, s := range suites {
+		suite := junitXMLSuite{
+			Name:      s.Name,
+			SystemOut: s.SystemOut,
+			SystemErr: s.SystemErr,
+		}
+		if !s.Timestamp.IsZero() {
+			suite.Timestamp = s.Timestamp.Format("2006-01-02T15:04:05")
+		}
+
+		if len(s.Properties) > 0 {
+			keys := make([]string, 0, len(s.Properties))
+			for k := range s.Properties {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			suite.Properties = &junitXMLProperties{}
+			for _, k := range keys {
+				suite.Properties.Properties = append(
+					suite.Properties.Properties,
+					junitXMLProperty{Name: k, Value: s.Properties[k]},
+				)
+			}
+		}
+
+		var elapsed time.Duration
+		for _, c := range s.Cases {
+			suite.Cases = append(suite.Cases, junitXMLCase{
+				Name:      c.Name,
+				ClassName: c.ClassName,
+				Time:      junitTime(c.Time),
+				Failure:   c.Failure,
+				Error:     c.Error,
+				Skipped:   c.Skipped,
+				SystemOut: c.SystemOut,
+				SystemErr: c.SystemErr,
+			})
+
+			suite.Tests++
+			switch {
+			case c.Error != nil:
+				suite.Errors++
+			case c.Failure != nil:
+				suite.Failures++
+			case c.Skipped != nil:
+				suite.Skipped++
+			}
+			elapsed += c.Time
+		}
+		if s.Time != 0 {
+			elapsed = s.Time
+		}
+		suite.Time = junitTime(elapsed)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		total += elapsed
+
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitTime(total)
+
+	return report
+}
+
+// junitTime formats d as seconds with millisecond precision.
+func junitTime(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
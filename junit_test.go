@@ -0,0 +1,177 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type junitTestResults map[string]bool
+
+func (r junitTestResults) JUnit() []JUnitTestSuite {
+	suite := JUnitTestSuite{Name: "provided"}
+	for _, name := range []string{"a", "b"} {
+		c := JUnitTestCase{Name: name}
+		if !r[name] {
+			c.Failure = &JUnitResult{Message: "failed"}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	return []JUnitTestSuite{suite}
+}
+
+func TestJUnit_Render(t *testing.T) {
+	suite := JUnitTestSuite{
+		Name:      "pkg/foo",
+		Timestamp: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Properties: map[string]string{
+			"os":   "linux",
+			"arch": "amd64",
+		},
+		Cases: []JUnitTestCase{
+			{Name: "TestOK", ClassName: "foo", Time: 1500 * time.Millisecond},
+			{
+				Name:      "TestFail",
+				ClassName: "foo",
+				Time:      250 * time.Millisecond,
+				Failure: &JUnitResult{
+					Message: "expected 1",
+					Type:    "assert",
+					Text:    "foo_test.go:12: expected 1, got 2",
+				},
+			},
+			{
+				Name:  "TestErr",
+				Error: &JUnitResult{Message: "panic"},
+			},
+			{
+				Name:    "TestSkip",
+				Skipped: &JUnitResult{Message: "short mode"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		handler   *JUnit
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "single suite",
+			value: suite,
+			want: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<testsuites tests="4" failures="1" errors="1" ` +
+				`skipped="1" time="1.750">` +
+				`<testsuite name="pkg/foo" tests="4" failures="1" ` +
+				`errors="1" skipped="1" time="1.750" ` +
+				`timestamp="2024-03-01T12:30:00">` +
+				`<properties>` +
+				`<property name="arch" value="amd64"></property>` +
+				`<property name="os" value="linux"></property>` +
+				`</properties>` +
+				`<testcase name="TestOK" classname="foo" time="1.500">` +
+				`</testcase>` +
+				`<testcase name="TestFail" classname="foo" time="0.250">` +
+				`<failure message="expected 1" type="assert">` +
+				`foo_test.go:12: expected 1, got 2</failure></testcase>` +
+				`<testcase name="TestErr" time="0.000">` +
+				`<error message="panic"></error></testcase>` +
+				`<testcase name="TestSkip" time="0.000">` +
+				`<skipped message="short mode"></skipped></testcase>` +
+				`</testsuite></testsuites>` + "\n",
+		},
+		{
+			name:    "multiple suites with name and suite time",
+			handler: &JUnit{Name: "all"},
+			value: []*JUnitTestSuite{
+				{Name: "a", Time: 2 * time.Second},
+				nil,
+				{
+					Name:      "b",
+					SystemOut: "out",
+					Cases:     []JUnitTestCase{{Name: "T", Time: time.Second}},
+				},
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<testsuites name="all" tests="1" failures="0" errors="0" ` +
+				`skipped="0" time="3.000">` +
+				`<testsuite name="a" tests="0" failures="0" errors="0" ` +
+				`skipped="0" time="2.000"></testsuite>` +
+				`<testsuite name="b" tests="1" failures="0" errors="0" ` +
+				`skipped="0" time="1.000">` +
+				`<testcase name="T" time="1.000"></testcase>` +
+				`<system-out>out</system-out></testsuite>` +
+				`</testsuites>` + "\n",
+		},
+		{
+			name:   "pretty provider",
+			pretty: true,
+			value:  junitTestResults{"a": true},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1" errors="0" skipped="0" time="0.000">
+  <testsuite name="provided" tests="2" failures="1" errors="0" ` +
+				`skipped="0" time="0.000">
+    <testcase name="a" time="0.000"></testcase>
+    <testcase name="b" time="0.000">
+      <failure message="failed"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:      "nil suite",
+			value:     (*JUnitTestSuite)(nil),
+			wantErr:   "render: cannot render: *render.JUnitTestSuite",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "unsupported value",
+			value:     "hello",
+			wantErr:   "render: cannot render: string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.handler
+			if h == nil {
+				h = &JUnit{}
+			}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestJUnit_Formats(t *testing.T) {
+	h := &JUnit{}
+
+	assert.Equal(t, []string{"junit"}, h.Formats())
+}
@@ -0,0 +1,462 @@
+package render
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// KeyCase is a casing style which map keys and struct field names are
+// converted to when rendering with WithKeyCase.
+type KeyCase int
+
+const (
+	// SnakeCase converts keys to snake_case.
+	SnakeCase KeyCase = iota + 1
+
+	// CamelCase converts keys to camelCase.
+	CamelCase
+
+	// KebabCase converts keys to kebab-case.
+	KebabCase
+
+	// PascalCase converts keys to PascalCase.
+	PascalCase
+)
+
+// WithKeyCase converts all map keys and struct field names to the given casing
+// style, regardless of struct tags. This allows JSON and YAML output to follow
+// different naming conventions from the same values.
+//
+// Struct fields are named by the struct tag of each format if set, like json
+// or yaml, or otherwise the Go field name, before being converted. Other tag
+// options, like "omitempty" and XML's "attr", are kept. Values which implement
+// json.Marshaler, yaml.Marshaler, xml.Marshaler, or encoding.TextMarshaler are
+// rendered as is.
+func WithKeyCase(c KeyCase) Option {
+	return func(o *Options) {
+		o.KeyCase = c
+	}
+}
+
+// keyCaseTags are the struct tag keys of formats whose field names are
+// converted with WithKeyCase.
+var keyCaseTags = []string{
+	"json", "yaml", "xml", "table", "csv", "xlsx", "query", "arrow",
+}
+
+// apply returns v with all map keys and struct field names converted.
+func (c KeyCase) apply(v any) any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+
+	kc := &keyCaser{
+		convert:     c.convert,
+		types:       map[reflect.Type]reflect.Type{},
+		structs:     map[reflect.Type]*structProjection{},
+		projections: map[reflect.Type]*structProjection{},
+	}
+	nv := kc.value(rv, kc.typ(rv.Type()), 0)
+
+	return rootValue(nv, kc.projection).Interface()
+}
+
+// keyCaser converts map keys and struct field names of values. Structs are
+// converted to values of derived struct types with the names in their struct
+// tags converted, so each format renders them with its own tags and options.
+// Maps, slices, arrays, and pointers are converted to the same kind of type,
+// with converted element types.
+type keyCaser struct {
+	convert func(string) string
+
+	// types holds the converted type of each source type. It is nil while the
+	// type is being converted, so recursive types are converted to any when
+	// they refer to themselves.
+	types map[reflect.Type]reflect.Type
+
+	// structs holds the projection of each converted struct type, keyed by
+	// the source type.
+	structs map[reflect.Type]*structProjection
+
+	// projections holds the same projections as structs, keyed by their own
+	// type.
+	projections map[reflect.Type]*structProjection
+}
+
+// projection returns the projection with the type t, or nil if there is none.
+func (kc *keyCaser) projection(t reflect.Type) *structProjection {
+	return kc.projections[t]
+}
+
+// typ returns the type values of type t are converted to.
+func (kc *keyCaser) typ(t reflect.Type) reflect.Type {
+	if nt, ok := kc.types[t]; ok {
+		if nt == nil {
+			return anyType
+		}
+
+		return nt
+	}
+
+	kc.types[t] = nil
+	nt := kc.newType(t)
+	kc.types[t] = nt
+
+	return nt
+}
+
+func (kc *keyCaser) newType(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Interface && marshals(t) {
+		return t
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Interface:
+		return anyType
+	case reflect.Pointer:
+		switch et := kc.typ(t.Elem()); et {
+		case t.Elem():
+			return t
+		case anyType:
+			return anyType
+		default:
+			return reflect.PointerTo(et)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return t
+		}
+
+		et := kc.typ(t.Elem())
+		switch {
+		case et == t.Elem():
+			return t
+		case t.Kind() == reflect.Array:
+			return reflect.ArrayOf(t.Len(), et)
+		default:
+			return reflect.SliceOf(et)
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return t
+		}
+		if et := kc.typ(t.Elem()); et != t.Elem() {
+			return reflect.MapOf(t.Key(), et)
+		}
+
+		return t
+	case reflect.Struct:
+		sp := newStructProjection(t,
+			func(f reflect.StructField) (reflect.StructField, bool) {
+				f.Tag = kc.tag(f)
+				f.Type = kc.typ(f.Type)
+
+				return f, true
+			},
+		)
+		kc.structs[t] = sp
+		kc.projections[sp.typ] = sp
+
+		return sp.typ
+	}
+
+	return t
+}
+
+// value returns rv converted to the type to, which must be the converted type
+// of rv, or an interface type.
+func (kc *keyCaser) value(
+	rv reflect.Value,
+	to reflect.Type,
+	depth int,
+) reflect.Value {
+	if !rv.IsValid() {
+		return reflect.Zero(to)
+	}
+
+	if to.Kind() == reflect.Interface {
+		// Nil values are kept as nil interfaces, so fields with the
+		// "omitempty" option are still omitted.
+		for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Zero(to)
+			}
+			if rv.Kind() == reflect.Pointer && kc.typ(rv.Type()) != anyType {
+				break
+			}
+			rv = rv.Elem()
+		}
+		if (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) &&
+			rv.IsNil() {
+			return reflect.Zero(to)
+		}
+
+		nv := reflect.New(to).Elem()
+		nv.Set(kc.value(rv, kc.typ(rv.Type()), depth+1))
+
+		return nv
+	}
+
+	if depth > maxDepth {
+		if rv.Type().AssignableTo(to) {
+			return rv
+		}
+
+		return reflect.Zero(to)
+	}
+	if rv.Type() == to && marshals(to) {
+		return rv
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return reflect.Zero(to)
+		}
+
+		p := reflect.New(to.Elem())
+		p.Elem().Set(kc.value(rv.Elem(), to.Elem(), depth+1))
+
+		return p
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return reflect.Zero(to)
+		}
+
+		var s reflect.Value
+		if rv.Kind() == reflect.Slice {
+			s = reflect.MakeSlice(to, rv.Len(), rv.Len())
+		} else {
+			s = reflect.New(to).Elem()
+		}
+		for i := 0; i < rv.Len(); i++ {
+			s.Index(i).Set(kc.value(rv.Index(i), to.Elem(), depth+1))
+		}
+
+		return s
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return rv
+		}
+		if rv.IsNil() {
+			return reflect.Zero(to)
+		}
+
+		m := reflect.MakeMapWithSize(to, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := reflect.ValueOf(kc.convert(iter.Key().String()))
+			m.SetMapIndex(
+				k.Convert(to.Key()),
+				kc.value(iter.Value(), to.Elem(), depth+1),
+			)
+		}
+
+		return m
+	case reflect.Struct:
+		sp := kc.structs[rv.Type()]
+
+		return sp.value(rv, func(i int, fv reflect.Value) reflect.Value {
+			return kc.value(fv, sp.typ.Field(i).Type, depth+1)
+		})
+	}
+
+	return rv
+}
+
+// tag returns the struct tag of f with the field names of all keyCaseTags
+// converted. Tags which are not set are added with the converted field name.
+func (kc *keyCaser) tag(f reflect.StructField) reflect.StructTag {
+	pairs := structTagPairs(f.Tag)
+	for _, key := range keyCaseTags {
+		value, ok := f.Tag.Lookup(key)
+		value = kc.tagValue(key, value, f.Name)
+
+		if !ok {
+			pairs = append(pairs, [2]string{key, value})
+
+			continue
+		}
+		for i := range pairs {
+			if pairs[i][0] == key {
+				pairs[i][1] = value
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p[0] + ":" + strconv.Quote(p[1]))
+	}
+
+	return reflect.StructTag(b.String())
+}
+
+// tagValue returns the struct tag value of the given key with its field name
+// converted, using the Go field name if the tag does not name the field.
+func (kc *keyCaser) tagValue(key, value, field string) string {
+	name, opts, hasOpts := strings.Cut(value, ",")
+	if name == "-" && !hasOpts {
+		return value
+	}
+	if name == "" && hasOpts {
+		for _, opt := range strings.Split(opts, ",") {
+			switch {
+			case key == "yaml" && opt == "inline",
+				key == "xml" && (opt == "chardata" || opt == "cdata" ||
+					opt == "innerxml" || opt == "comment" || opt == "any"):
+				return value
+			}
+		}
+	}
+	if name == "" {
+		name = field
+	}
+
+	if key == "xml" {
+		// XML names may have a namespace, like "ns name", or be a path of
+		// nested elements, like "a>b".
+		space, local, ok := strings.Cut(name, " ")
+		if !ok {
+			space, local = "", name
+		}
+		parts := strings.Split(local, ">")
+		for i, p := range parts {
+			parts[i] = kc.convert(p)
+		}
+		name = strings.Join(parts, ">")
+		if ok {
+			name = space + " " + name
+		}
+	} else {
+		name = kc.convert(name)
+	}
+
+	if hasOpts {
+		return name + "," + opts
+	}
+
+	return name
+}
+
+// structTagPairs returns the key/value pairs of tag in order, as parsed by
+// reflect.StructTag's Lookup method. Parsing stops at the first malformed
+// pair.
+func structTagPairs(tag reflect.StructTag) [][2]string {
+	var pairs [][2]string
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' &&
+			tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(string(tag[:i+1]))
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+
+		pairs = append(pairs, [2]string{key, value})
+	}
+
+	return pairs
+}
+
+// convert returns s converted to the casing style.
+func (c KeyCase) convert(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return s
+	}
+
+	switch c {
+	case SnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	case CamelCase, PascalCase:
+		var b strings.Builder
+		for i, w := range words {
+			w = strings.ToLower(w)
+			if i > 0 || c == PascalCase {
+				r := []rune(w)
+				r[0] = unicode.ToUpper(r[0])
+				w = string(r)
+			}
+			b.WriteString(w)
+		}
+
+		return b.String()
+	default:
+		return s
+	}
+}
+
+// splitWords splits s into words at underscores, dashes, spaces, and dots, and
+// at case changes, like "userID" into "user" and "ID", and "HTTPServer" into
+// "HTTP" and "Server".
+func splitWords(s string) []string {
+	runes := []rune(s)
+
+	var words []string
+	start := 0
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+
+			continue
+		}
+
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
@@ -0,0 +1,267 @@
+package render
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCase_convert(t *testing.T) {
+	tests := []struct {
+		in     string
+		snake  string
+		camel  string
+		kebab  string
+		pascal string
+	}{
+		{
+			in: "userID", snake: "user_id", camel: "userId",
+			kebab: "user-id", pascal: "UserId",
+		},
+		{
+			in: "HTTPServer", snake: "http_server", camel: "httpServer",
+			kebab: "http-server", pascal: "HttpServer",
+		},
+		{
+			in: "created_at", snake: "created_at", camel: "createdAt",
+			kebab: "created-at", pascal: "CreatedAt",
+		},
+		{
+			in: "max-retry-count", snake: "max_retry_count",
+			camel: "maxRetryCount", kebab: "max-retry-count",
+			pascal: "MaxRetryCount",
+		},
+		{
+			in: "Version2Name", snake: "version2_name", camel: "version2Name",
+			kebab: "version2-name", pascal: "Version2Name",
+		},
+		{in: "a", snake: "a", camel: "a", kebab: "a", pascal: "A"},
+		{in: "__", snake: "__", camel: "__", kebab: "__", pascal: "__"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.snake, SnakeCase.convert(tt.in))
+			assert.Equal(t, tt.camel, CamelCase.convert(tt.in))
+			assert.Equal(t, tt.kebab, KebabCase.convert(tt.in))
+			assert.Equal(t, tt.pascal, PascalCase.convert(tt.in))
+			assert.Equal(t, tt.in, KeyCase(0).convert(tt.in))
+		})
+	}
+}
+
+type keyCaseTestUser struct {
+	UserID    int       `json:"user_id"`
+	FullName  string    `yaml:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Tags      map[string]any
+	Friends   []*keyCaseTestUser `json:",omitempty"`
+	Secret    string             `json:"-"`
+}
+
+func TestRenderer_Render_withKeyCase(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+	})
+	user := &keyCaseTestUser{
+		UserID:    1,
+		FullName:  "John",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tags:      map[string]any{"isAdmin": true, "x": nil},
+		Friends:   []*keyCaseTestUser{{UserID: 2}},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "struct to kebab-case JSON",
+			format: "json",
+			value:  user,
+			opts:   []Option{WithKeyCase(KebabCase)},
+			want: `{"user-id":1,"full-name":"John",` +
+				`"created-at":"2024-01-02T03:04:05Z",` +
+				`"tags":{"is-admin":true,"x":null},` +
+				`"friends":[{"user-id":2,"full-name":"",` +
+				`"created-at":"0001-01-01T00:00:00Z","tags":null}]}` + "\n",
+		},
+		{
+			name:   "map to snake_case YAML",
+			format: "yaml",
+			value: map[string]any{
+				"firstName": "John",
+				"addressInfo": []map[string]string{
+					{"streetName": "Main"},
+				},
+			},
+			opts: []Option{WithKeyCase(SnakeCase)},
+			want: "address_info:\n  - street_name: Main\n" +
+				"first_name: John\n",
+		},
+		{
+			name:   "with fields",
+			format: "json",
+			value:  user,
+			opts: []Option{
+				WithFields("user_id", "createdAt"),
+				WithKeyCase(PascalCase),
+			},
+			want: `{"UserId":1,"CreatedAt":"2024-01-02T03:04:05Z"}` + "\n",
+		},
+		{
+			name:   "scalars and bytes",
+			format: "json",
+			value:  []any{1, "a", []byte("hi"), nil},
+			opts:   []Option{WithKeyCase(CamelCase)},
+			want:   `[1,"a","aGk=",null]` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := r.Render(&buf, tt.format, false, tt.value, tt.opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+type keyCaseTestAddress struct {
+	StreetName string `xml:"streetName,attr"`
+	PostCode   string `yaml:"zip,omitempty"`
+}
+
+type keyCaseTestOrder struct {
+	OrderID     int `json:"orderId" xml:"OrderID,attr" table:"Order ID"`
+	ShipTo      keyCaseTestAddress
+	GiftMessage string `json:",omitempty" yaml:"message,omitempty"`
+}
+
+func TestRenderer_Render_withKeyCase_formats(t *testing.T) {
+	order := keyCaseTestOrder{
+		OrderID: 7,
+		ShipTo:  keyCaseTestAddress{StreetName: "Main"},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		want   string
+	}{
+		{
+			name:   "json",
+			format: "json",
+			value:  order,
+			want: `{"order_id":7,"ship_to":{"street_name":"Main",` +
+				`"post_code":""}}` + "\n",
+		},
+		{
+			name:   "yaml uses yaml tags and options",
+			format: "yaml",
+			value:  order,
+			want:   "order_id: 7\nship_to:\n  street_name: Main\n",
+		},
+		{
+			name:   "xml keeps attributes and field element names",
+			format: "xml",
+			value:  &order,
+			want: `<keyCaseTestOrder order_id="7">` +
+				`<ship_to street_name="Main">` +
+				`<post_code></post_code></ship_to>` +
+				`<gift_message></gift_message></keyCaseTestOrder>`,
+		},
+		{
+			name:   "xml slice",
+			format: "xml",
+			value:  []keyCaseTestAddress{{StreetName: "Main"}},
+			want: `<keyCaseTestAddress street_name="Main">` +
+				`<post_code></post_code></keyCaseTestAddress>`,
+		},
+		{
+			name:   "table",
+			format: "table",
+			value:  []*keyCaseTestOrder{&order},
+			want: "order_id  ship_to  gift_message\n" +
+				"7         {Main }\n",
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			value:  order,
+			want:   "order_id,ship_to,gift_message\n7,{Main },\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Base.String(
+				tt.format, false, tt.value, WithKeyCase(SnakeCase),
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeyCase_apply_recursive(t *testing.T) {
+	type node struct {
+		Name     string
+		Children []*node `json:",omitempty"`
+	}
+	value := &node{Name: "a", Children: []*node{{Name: "b"}}}
+
+	got, err := Base.String("json", false, value, WithKeyCase(PascalCase))
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"Name":"a","Children":[{"Name":"b"}]}`+"\n", got)
+}
+
+func Test_keyCaser_tag(t *testing.T) {
+	kc := &keyCaser{convert: KebabCase.convert}
+
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		want reflect.StructTag
+	}{
+		{
+			name: "untagged",
+			want: `json:"user-id" yaml:"user-id" xml:"user-id" ` +
+				`table:"user-id" csv:"user-id" xlsx:"user-id" ` +
+				`query:"user-id" arrow:"user-id"`,
+		},
+		{
+			name: "tagged with options and other tags",
+			tag: `render:"redact" json:"userID,omitempty" yaml:"-" ` +
+				`xml:"ns userID>id,attr" table:",order=1"`,
+			want: `render:"redact" json:"user-id,omitempty" yaml:"-" ` +
+				`xml:"ns user-id>id,attr" table:"user-id,order=1" ` +
+				`csv:"user-id" xlsx:"user-id" query:"user-id" ` +
+				`arrow:"user-id"`,
+		},
+		{
+			name: "unnamed special fields",
+			tag:  `yaml:",inline" xml:",chardata"`,
+			want: `yaml:",inline" xml:",chardata" json:"user-id" ` +
+				`table:"user-id" csv:"user-id" xlsx:"user-id" ` +
+				`query:"user-id" arrow:"user-id"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kc.tag(reflect.StructField{Name: "UserID", Tag: tt.tag})
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ErrLint is returned when rendered output is rejected by a Linter.
+var ErrLint = fmt.Errorf("%w: lint", ErrFailed)
+
+// Linter is a function which inspects rendered output before it is written. It
+// is given the format string used, and the complete rendered output. Returning
+// a non-nil error vetoes the output, causing nothing to be written.
+//
+// Linters allow enforcing output style policies centrally across all formats.
+type Linter func(format string, output []byte) error
+
+// MaxLineLength returns a Linter which rejects output containing lines longer
+// than n characters.
+func MaxLineLength(n int) Linter {
+	return func(_ string, output []byte) error {
+		for i, line := range bytes.Split(output, []byte("\n")) {
+			line = bytes.TrimSuffix(line, []byte("\r"))
+			if l := utf8.RuneCount(line); l > n {
+				return fmt.Errorf(
+					"line %d is %d characters long, maximum is %d",
+					i+1, l, n,
+				)
+			}
+		}
+
+		return nil
+	}
+}
+
+// NoTabs returns a Linter which rejects output containing tab characters.
+func NoTabs() Linter {
+	return func(_ string, output []byte) error {
+		for i, line := range bytes.Split(output, []byte("\n")) {
+			if bytes.IndexByte(line, '\t') >= 0 {
+				return fmt.Errorf("line %d contains a tab character", i+1)
+			}
+		}
+
+		return nil
+	}
+}
+
+// RequireTrailingNewline returns a Linter which rejects non-empty output that
+// does not end with a newline.
+func RequireTrailingNewline() Linter {
+	return func(_ string, output []byte) error {
+		if len(output) > 0 && output[len(output)-1] != '\n' {
+			return errors.New("output does not end with a newline")
+		}
+
+		return nil
+	}
+}
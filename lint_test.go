@@ -0,0 +1,204 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxLineLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		max     int
+		output  string
+		wantErr string
+	}{
+		{
+			name:   "empty output",
+			max:    5,
+			output: "",
+		},
+		{
+			name:   "lines within limit",
+			max:    5,
+			output: "hello\nworld\n",
+		},
+		{
+			name:   "multi-byte characters within limit",
+			max:    5,
+			output: "héllo\r\nwörld",
+		},
+		{
+			name:    "line exceeds limit",
+			max:     5,
+			output:  "hello\nhello world\n",
+			wantErr: "line 2 is 11 characters long, maximum is 5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MaxLineLength(tt.max)("text", []byte(tt.output))
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNoTabs(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantErr string
+	}{
+		{
+			name:   "empty output",
+			output: "",
+		},
+		{
+			name:   "no tabs",
+			output: "{\n  \"age\": 30\n}\n",
+		},
+		{
+			name:    "tabs",
+			output:  "{\n\t\"age\": 30\n}\n",
+			wantErr: "line 2 contains a tab character",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NoTabs()("json", []byte(tt.output))
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequireTrailingNewline(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantErr string
+	}{
+		{
+			name:   "empty output",
+			output: "",
+		},
+		{
+			name:   "trailing newline",
+			output: "hello\n",
+		},
+		{
+			name:    "no trailing newline",
+			output:  "hello",
+			wantErr: "output does not end with a newline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequireTrailingNewline()("text", []byte(tt.output))
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_linters(t *testing.T) {
+	tests := []struct {
+		name      string
+		linters   []Linter
+		handler   Handler
+		writeErr  error
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:    "all linters pass",
+			linters: []Linter{NoTabs(), RequireTrailingNewline()},
+			handler: &mockHandler{output: "hello\n"},
+			want:    "hello\n",
+		},
+		{
+			name:    "linter rejects output",
+			linters: []Linter{NoTabs(), RequireTrailingNewline()},
+			handler: &mockHandler{output: "hello"},
+			wantErr: "render: failed: lint: " +
+				"output does not end with a newline",
+			wantErrIs: []error{Err, ErrFailed, ErrLint},
+		},
+		{
+			name: "linter receives format",
+			linters: []Linter{
+				func(format string, _ []byte) error {
+					return errors.New("format: " + format)
+				},
+			},
+			handler:   &mockHandler{output: "hello"},
+			wantErr:   "render: failed: lint: format: mock",
+			wantErrIs: []error{Err, ErrFailed, ErrLint},
+		},
+		{
+			name:      "handler fails",
+			linters:   []Linter{NoTabs()},
+			handler:   &mockHandler{output: "hello", err: errors.New("oops")},
+			wantErr:   "render: failed: oops",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "error writing to writer",
+			linters:   []Linter{NoTabs()},
+			handler:   &mockHandler{output: "hello"},
+			writeErr:  errors.New("write error!!1"),
+			wantErr:   "render: failed: write error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Renderer{
+				Handlers: map[string]Handler{"mock": tt.handler},
+				Linters:  tt.linters,
+			}
+			w := &mockWriter{WriteErr: tt.writeErr}
+
+			err := r.Render(w, "mock", false, struct{}{})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, w.String())
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, w.String())
+			}
+		})
+	}
+}
+
+func TestRenderer_NewWith_keepsLinters(t *testing.T) {
+	r := New(map[string]Handler{"mock": &mockHandler{output: "\t"}})
+	r.Linters = []Linter{NoTabs()}
+
+	var buf bytes.Buffer
+	err := r.NewWith("mock").Render(&buf, "mock", false, struct{}{})
+
+	assert.ErrorIs(t, err, ErrLint)
+}
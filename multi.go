@@ -1,9 +1,11 @@
 package render
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 )
 
 // Multi is a Handler that tries multiple handlers until one succeeds.
@@ -12,18 +14,55 @@ type Multi struct {
 }
 
 var (
-	_ Handler        = (*Multi)(nil)
-	_ PrettyHandler  = (*Multi)(nil)
-	_ FormatsHandler = (*Multi)(nil)
+	_ Handler            = (*Multi)(nil)
+	_ PrettyHandler      = (*Multi)(nil)
+	_ FormatsHandler     = (*Multi)(nil)
+	_ DescribedHandler   = (*Multi)(nil)
+	_ OptionsHandler     = (*Multi)(nil)
+	_ ProbeHandler       = (*Multi)(nil)
+	_ ContentTypeHandler = (*Multi)(nil)
 )
 
 // Render tries each handler in order until one succeeds. If none succeed,
 // ErrCannotRender is returned. If a handler returns an error that is not
 // ErrCannotRender, that error is returned.
+//
+// Each handler renders to a buffer, and only the output of the handler which
+// succeeds is written to w.
 func (mr *Multi) Render(w io.Writer, v any) error {
+	return mr.render(w, v, false)
+}
+
+// RenderPretty tries each handler in order until one succeeds. If none
+// succeed, ErrCannotRender is returned. If a handler returns an error that is
+// not ErrCannotRender, that error is returned.
+//
+// If a handler implements PrettyHandler, then the RenderPretty method is used
+// instead of Render. Otherwise, the Render method is used.
+//
+// Each handler renders to a buffer, and only the output of the handler which
+// succeeds is written to w.
+func (mr *Multi) RenderPretty(w io.Writer, v any) error {
+	return mr.render(w, v, true)
+}
+
+func (mr *Multi) render(w io.Writer, v any, pretty bool) error {
+	var buf bytes.Buffer
 	for _, r := range mr.Handlers {
-		err := r.Render(w, v)
+		buf.Reset()
+
+		var err error
+		if x, ok := r.(PrettyHandler); ok && pretty {
+			err = x.RenderPretty(&buf, v)
+		} else {
+			err = r.Render(&buf, v)
+		}
 		if err == nil {
+			_, err = w.Write(buf.Bytes())
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrFailed, err)
+			}
+
 			return nil
 		}
 		if !errors.Is(err, ErrCannotRender) {
@@ -34,20 +73,15 @@ func (mr *Multi) Render(w io.Writer, v any) error {
 	return fmt.Errorf("%w: %T", ErrCannotRender, v)
 }
 
-// RenderPretty tries each handler in order until one succeeds. If none
-// succeed, ErrCannotRender is returned. If a handler returns an error that is
-// not ErrCannotRender, that error is returned.
+// Probe checks each handler in order, returning nil as soon as one of them can
+// render v. If none can, ErrCannotRender is returned. If a handler returns an
+// error that is not ErrCannotRender, that error is returned.
 //
-// If a handler implements PrettyHandler, then the RenderPretty method is used
-// instead of Render. Otherwise, the Render method is used.
-func (mr *Multi) RenderPretty(w io.Writer, v any) error {
+// Handlers which implement ProbeHandler are checked with their Probe method,
+// others by rendering v to io.Discard.
+func (mr *Multi) Probe(v any) error {
 	for _, r := range mr.Handlers {
-		var err error
-		if x, ok := r.(PrettyHandler); ok {
-			err = x.RenderPretty(w, v)
-		} else {
-			err = r.Render(w, v)
-		}
+		err := probe(r, v)
 		if err == nil {
 			return nil
 		}
@@ -78,3 +112,55 @@ func (mr *Multi) Formats() []string {
 
 	return result
 }
+
+// Description returns the description of the first handler which implements
+// DescribedHandler and has a non-empty description.
+func (mr *Multi) Description() string {
+	for _, r := range mr.Handlers {
+		if x, ok := r.(DescribedHandler); ok {
+			if d := x.Description(); d != "" {
+				return d
+			}
+		}
+	}
+
+	return ""
+}
+
+// ContentType returns the content type of the first handler which implements
+// ContentTypeHandler and has a non-empty content type.
+func (mr *Multi) ContentType() string {
+	for _, r := range mr.Handlers {
+		if x, ok := r.(ContentTypeHandler); ok {
+			if ct := x.ContentType(); ct != "" {
+				return ct
+			}
+		}
+	}
+
+	return ""
+}
+
+// WithOptions returns a copy of the Multi handler, with the options applied to
+// all handlers which implement OptionsHandler. If no handler is changed by the
+// options, the Multi handler is returned as is.
+func (mr *Multi) WithOptions(opts *Options) Handler {
+	changed := false
+	handlers := make([]Handler, 0, len(mr.Handlers))
+	for _, r := range mr.Handlers {
+		if x, ok := r.(OptionsHandler); ok {
+			h := x.WithOptions(opts)
+			if !reflect.TypeOf(r).Comparable() || h != r {
+				changed = true
+			}
+			r = h
+		}
+		handlers = append(handlers, r)
+	}
+
+	if !changed {
+		return mr
+	}
+
+	return &Multi{Handlers: handlers}
+}
@@ -80,6 +80,19 @@ var multiHandlerTestCases = []struct {
 		want:       "first output",
 		wantPretty: "pretty first output",
 	},
+	{
+		name: "partial output before cannot render",
+		handlers: []Handler{
+			&mockHandler{output: "partial", err: ErrCannotRender},
+			&mockPrettyHandler{
+				output:       "success output",
+				prettyOutput: "pretty success output",
+			},
+		},
+		value:      struct{}{},
+		want:       "success output",
+		wantPretty: "pretty success output",
+	},
 	{
 		name: "first handler fails",
 		handlers: []Handler{
@@ -178,6 +191,32 @@ func TestMulti_RenderPretty(t *testing.T) {
 	}
 }
 
+func TestMulti_Render_partialOutputOnError(t *testing.T) {
+	mr := &Multi{
+		Handlers: []Handler{
+			&mockHandler{output: "partial", err: errors.New("mock error")},
+		},
+	}
+	var buf bytes.Buffer
+
+	err := mr.Render(&buf, struct{}{})
+
+	assert.EqualError(t, err, "mock error")
+	assert.Empty(t, buf.String())
+}
+
+func TestMulti_Render_writeError(t *testing.T) {
+	mr := &Multi{
+		Handlers: []Handler{&mockHandler{output: "output"}},
+	}
+	w := &mockWriter{WriteErr: errors.New("write error")}
+
+	err := mr.Render(w, struct{}{})
+
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
 func TestMulti_Formats(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -236,3 +275,116 @@ func TestMulti_Formats(t *testing.T) {
 		})
 	}
 }
+
+func TestMulti_Description(t *testing.T) {
+	tests := []struct {
+		name     string
+		handlers []Handler
+		want     string
+	}{
+		{
+			name:     "no handlers",
+			handlers: []Handler{},
+			want:     "",
+		},
+		{
+			name:     "handlers without a Description method",
+			handlers: []Handler{&mockHandler{}, &mockFormatsHandler{}},
+			want:     "",
+		},
+		{
+			name: "first handler with a non-empty description",
+			handlers: []Handler{
+				&mockHandler{},
+				&mockDescribedHandler{},
+				&mockDescribedHandler{description: "first"},
+				&mockDescribedHandler{description: "second"},
+			},
+			want: "first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := &Multi{Handlers: tt.handlers}
+
+			got := mr.Description()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMulti_WithOptions(t *testing.T) {
+	plain := &mockHandler{}
+	mr := &Multi{Handlers: []Handler{&JSON{}, plain}}
+
+	got := mr.WithOptions(&Options{Indent: "\t"})
+
+	assert.Equal(t,
+		&Multi{Handlers: []Handler{&JSON{Indent: "\t"}, plain}}, got,
+	)
+	assert.Equal(t, &Multi{Handlers: []Handler{&JSON{}, plain}}, mr)
+}
+
+func TestMulti_WithOptions_unchanged(t *testing.T) {
+	mr := &Multi{Handlers: []Handler{&JSON{}, &mockHandler{}}}
+
+	got := mr.WithOptions(&Options{})
+
+	assert.Same(t, mr, got)
+}
+
+func TestMulti_Probe(t *testing.T) {
+	tests := []struct {
+		name     string
+		handlers []Handler
+		value    any
+		wantErr  string
+	}{
+		{
+			name:     "probe handler can render",
+			handlers: []Handler{&mockHandler{err: ErrCannotRender}, &Text{}},
+			value:    "hello",
+		},
+		{
+			name:     "handler without Probe can render",
+			handlers: []Handler{&Text{}, &JSON{}},
+			value:    map[string]int{"a": 1},
+		},
+		{
+			name:     "no handler can render",
+			handlers: []Handler{&Text{}, &mockHandler{err: ErrCannotRender}},
+			value:    struct{}{},
+			wantErr:  "render: cannot render: struct {}",
+		},
+		{
+			name: "handler error",
+			handlers: []Handler{
+				&Text{},
+				&mockHandler{err: errors.New("mock error")},
+			},
+			value:   struct{}{},
+			wantErr: "mock error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := &Multi{Handlers: tt.handlers}
+
+			err := mr.Probe(tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMulti_ContentType(t *testing.T) {
+	mr := &Multi{Handlers: []Handler{&mockHandler{}, &YAML{}, &JSON{}}}
+
+	assert.Equal(t, "application/yaml", mr.ContentType())
+	assert.Equal(t, "", (&Multi{}).ContentType())
+}
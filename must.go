@@ -0,0 +1,82 @@
+package render
+
+import "io"
+
+// MustRender is like Render, but panics if an error occurs. It is intended
+// for use in tests and templates, where errors are programmer bugs.
+func (r *Renderer) MustRender(
+	w io.Writer,
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) {
+	if err := r.Render(w, format, pretty, v, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// MustCompact is like Compact, but panics if an error occurs.
+func (r *Renderer) MustCompact(
+	w io.Writer,
+	format string,
+	v any,
+	opts ...Option,
+) {
+	r.MustRender(w, format, false, v, opts...)
+}
+
+// MustPretty is like Pretty, but panics if an error occurs.
+func (r *Renderer) MustPretty(
+	w io.Writer,
+	format string,
+	v any,
+	opts ...Option,
+) {
+	r.MustRender(w, format, true, v, opts...)
+}
+
+// MustString is like String, but panics if an error occurs.
+func (r *Renderer) MustString(
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) string {
+	s, err := r.String(format, pretty, v, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// MustRender is a convenience function that calls the Default renderer's
+// MustRender method. It panics if an error occurs.
+func MustRender(
+	w io.Writer,
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) {
+	defaultRenderer().MustRender(w, format, pretty, v, opts...)
+}
+
+// MustCompact is a convenience function that calls the Default renderer's
+// MustCompact method. It panics if an error occurs.
+func MustCompact(w io.Writer, format string, v any, opts ...Option) {
+	defaultRenderer().MustCompact(w, format, v, opts...)
+}
+
+// MustPretty is a convenience function that calls the Default renderer's
+// MustPretty method. It panics if an error occurs.
+func MustPretty(w io.Writer, format string, v any, opts ...Option) {
+	defaultRenderer().MustPretty(w, format, v, opts...)
+}
+
+// MustString is a convenience function that calls the Default renderer's
+// MustString method. It panics if an error occurs.
+func MustString(format string, pretty bool, v any, opts ...Option) string {
+	return defaultRenderer().MustString(format, pretty, v, opts...)
+}
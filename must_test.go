@@ -0,0 +1,101 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Must(t *testing.T) {
+	r := &Renderer{Handlers: map[string]Handler{
+		"mock": &mockPrettyHandler{
+			output:       "plain output",
+			prettyOutput: "pretty output",
+		},
+		"fail": &mockHandler{err: errors.New("mock error")},
+	}}
+
+	tests := []struct {
+		name      string
+		fn        func(format string) string
+		format    string
+		want      string
+		wantPanic string
+	}{
+		{
+			name: "MustRender",
+			fn: func(format string) string {
+				var buf bytes.Buffer
+				r.MustRender(&buf, format, true, nil)
+
+				return buf.String()
+			},
+			want: "pretty output",
+		},
+		{
+			name: "MustCompact",
+			fn: func(format string) string {
+				var buf bytes.Buffer
+				r.MustCompact(&buf, format, nil)
+
+				return buf.String()
+			},
+			want: "plain output",
+		},
+		{
+			name: "MustPretty",
+			fn: func(format string) string {
+				var buf bytes.Buffer
+				r.MustPretty(&buf, format, nil)
+
+				return buf.String()
+			},
+			want: "pretty output",
+		},
+		{
+			name: "MustString",
+			fn: func(format string) string {
+				return r.MustString(format, false, nil)
+			},
+			want: "plain output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fn("mock"))
+
+			assert.PanicsWithError(t, "render: failed: mock error", func() {
+				tt.fn("fail")
+			})
+			assert.PanicsWithError(t, "render: unsupported format: nope",
+				func() {
+					tt.fn("nope")
+				},
+			)
+		})
+	}
+}
+
+func TestMust(t *testing.T) {
+	value := map[string]int{"age": 42}
+
+	var buf bytes.Buffer
+	MustRender(&buf, "json", false, value)
+	assert.Equal(t, `{"age":42}`+"\n", buf.String())
+
+	buf.Reset()
+	MustCompact(&buf, "yaml", value)
+	assert.Equal(t, "age: 42\n", buf.String())
+
+	buf.Reset()
+	MustPretty(&buf, "json", value)
+	assert.Equal(t, "{\n  \"age\": 42\n}\n", buf.String())
+
+	assert.Equal(t, `{"age":42}`+"\n", MustString("json", false, value))
+
+	assert.PanicsWithError(t, "render: unsupported format: nope", func() {
+		MustString("nope", false, value)
+	})
+}
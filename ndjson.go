@@ -0,0 +1,137 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// NDJSON is a Handler that renders values as newline delimited JSON
+// (https://github.com/ndjson/ndjson-spec), with one compact JSON value per
+// line.
+//
+// Slices and arrays are rendered with each element on its own line, while all
+// other values are rendered as a single line. Byte slices are rendered as a
+// single JSON string, as with the JSON handler. Map keys are always sorted, so
+// output is byte-stable across runs.
+type NDJSON struct{}
+
+var (
+	_ Handler            = (*NDJSON)(nil)
+	_ FormatsHandler     = (*NDJSON)(nil)
+	_ DescribedHandler   = (*NDJSON)(nil)
+	_ ContentTypeHandler = (*NDJSON)(nil)
+	_ EncoderHandler     = (*NDJSON)(nil)
+	_ StreamHandler      = (*NDJSON)(nil)
+	_ ParseHandler       = (*NDJSON)(nil)
+)
+
+// Render writes v to w as newline delimited JSON.
+func (nd *NDJSON) Render(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) ||
+		rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nd.encode(enc, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := nd.encode(enc, rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderStream writes each value yielded by seq to w as a line of JSON, as they
+// are yielded. The pretty argument is ignored, as NDJSON values must be written
+// on a single line.
+func (nd *NDJSON) RenderStream(
+	w io.Writer,
+	_ bool,
+	seq func(yield func(any) bool),
+) error {
+	enc := json.NewEncoder(w)
+
+	var err error
+	seq(func(v any) bool {
+		err = nd.encode(enc, v)
+
+		return err == nil
+	})
+
+	return err
+}
+
+// Parse decodes each line of JSON from r, into the slice pointed to by v. If v
+// is a *any, it is set to a []any slice of the decoded values. If v is not a
+// pointer to a slice or a *any, a ErrCannotRender error is returned.
+func (nd *NDJSON) Parse(r io.Reader, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	dst := rv.Elem()
+	var elemType reflect.Type
+	switch {
+	case dst.Kind() == reflect.Slice:
+		elemType = dst.Type().Elem()
+	case dst.Kind() == reflect.Interface && dst.NumMethod() == 0:
+		elemType = dst.Type()
+	default:
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	values := reflect.MakeSlice(reflect.SliceOf(elemType), 0, 0)
+	dec := json.NewDecoder(r)
+	for {
+		ev := reflect.New(elemType)
+		err := dec.Decode(ev.Interface())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+		values = reflect.Append(values, ev.Elem())
+	}
+
+	dst.Set(values)
+
+	return nil
+}
+
+// NewEncoder returns a json.Encoder which writes each value to w as a line of
+// JSON. The pretty argument is ignored.
+func (nd *NDJSON) NewEncoder(w io.Writer, _ bool) ValueEncoder {
+	return json.NewEncoder(w)
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (nd *NDJSON) Formats() []string {
+	return []string{"ndjson", "jsonl"}
+}
+
+// Description returns a short human-readable description of the format.
+func (nd *NDJSON) Description() string {
+	return "Newline delimited JSON, one value per line"
+}
+
+// ContentType returns the MIME type of the format.
+func (nd *NDJSON) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (nd *NDJSON) encode(enc *json.Encoder, v any) error {
+	err := enc.Encode(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSON_Render(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeErr  error
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "slice",
+			value: []any{map[string]int{"a": 1}, "foo", 2},
+			want:  "{\"a\":1}\n\"foo\"\n2\n",
+		},
+		{
+			name:  "array",
+			value: [2]int{1, 2},
+			want:  "1\n2\n",
+		},
+		{
+			name:  "empty slice",
+			value: []string{},
+			want:  "",
+		},
+		{
+			name:  "map",
+			value: map[string]any{"a": []int{1, 2}},
+			want:  "{\"a\":[1,2]}\n",
+		},
+		{
+			name:  "byte slice",
+			value: []byte("foo"),
+			want:  "\"Zm9v\"\n",
+		},
+		{
+			name:  "nil",
+			value: nil,
+			want:  "null\n",
+		},
+		{
+			name:      "unsupported element",
+			value:     []any{1, make(chan int)},
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "write error",
+			writeErr:  errors.New("write error"),
+			value:     []int{1},
+			wantErr:   "render: failed: write error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockWriter{WriteErr: tt.writeErr}
+			h := &NDJSON{}
+
+			err := h.Render(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNDJSON_RenderStream(t *testing.T) {
+	var buf bytes.Buffer
+	h := &NDJSON{}
+
+	err := h.RenderStream(&buf, true, func(yield func(any) bool) {
+		for _, v := range []any{map[string]int{"a": 1}, []int{1, 2}} {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "{\"a\":1}\n[1,2]\n", buf.String())
+}
+
+func TestNDJSON_NewEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := (&NDJSON{}).NewEncoder(&buf, true)
+
+	require.NoError(t, enc.Encode(map[string]int{"a": 1}))
+	require.NoError(t, enc.Encode("foo"))
+
+	assert.Equal(t, "{\"a\":1}\n\"foo\"\n", buf.String())
+}
+
+func TestNDJSON_Formats(t *testing.T) {
+	h := &NDJSON{}
+
+	assert.Equal(t, []string{"ndjson", "jsonl"}, h.Formats())
+}
+
+func TestNDJSON_Parse(t *testing.T) {
+	h := &NDJSON{}
+	src := "{\"a\":1}\n{\"a\":2}\n"
+
+	var items []map[string]int
+	require.NoError(t, h.Parse(strings.NewReader(src), &items))
+	assert.Equal(t, []map[string]int{{"a": 1}, {"a": 2}}, items)
+
+	var values any
+	require.NoError(t, h.Parse(strings.NewReader("1\n\"x\"\n"), &values))
+	assert.Equal(t, []any{float64(1), "x"}, values)
+
+	var empty []int
+	require.NoError(t, h.Parse(strings.NewReader(""), &empty))
+	assert.Equal(t, []int{}, empty)
+
+	err := h.Parse(strings.NewReader("1\n{"), &empty)
+	assert.EqualError(t, err, "render: failed: unexpected EOF")
+	assert.ErrorIs(t, err, ErrFailed)
+
+	var m map[string]int
+	err = h.Parse(strings.NewReader(src), &m)
+	assert.ErrorIs(t, err, ErrCannotRender)
+
+	err = h.Parse(strings.NewReader(src), items)
+	assert.ErrorIs(t, err, ErrCannotRender)
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// normalize converts common container types which most format handlers cannot
+// render into plain maps and slices. Specifically *sync.Map and map[any]any
+// values are converted to map[string]any, and *list.List values are converted
+// to []any.
+//
+// Nested values within converted containers, map[string]any, and []any values
+// are normalized recursively. Values which do not need to be converted are
+// returned as is.
+func normalize(v any) any {
+	nv, _ := normalizeValue(v)
+
+	return nv
+}
+
+// normalizeValue does the heavy lifting for normalize, and also reports if the
+// returned value differs from the given value. This allows map[string]any and
+// []any values to only be copied when one of their elements was converted.
+func normalizeValue(v any) (any, bool) {
+	switch x := v.(type) {
+	case *sync.Map:
+		if x == nil {
+			return v, false
+		}
+
+		m := map[string]any{}
+		x.Range(func(key, value any) bool {
+			m[normalizeKey(key)] = normalize(value)
+
+			return true
+		})
+
+		return m, true
+	case *list.List:
+		if x == nil {
+			return v, false
+		}
+
+		s := make([]any, 0, x.Len())
+		for e := x.Front(); e != nil; e = e.Next() {
+			s = append(s, normalize(e.Value))
+		}
+
+		return s, true
+	case map[any]any:
+		if x == nil {
+			return map[string]any(nil), true
+		}
+
+		m := make(map[string]any, len(x))
+		for key, value := range x {
+			m[normalizeKey(key)] = normalize(value)
+		}
+
+		return m, true
+	case map[string]any:
+		var m map[string]any
+		for key, value := range x {
+			nv, changed := normalizeValue(value)
+			if !changed {
+				continue
+			}
+			if m == nil {
+				m = make(map[string]any, len(x))
+				for k, v := range x {
+					m[k] = v
+				}
+			}
+			m[key] = nv
+		}
+		if m == nil {
+			return v, false
+		}
+
+		return m, true
+	case []any:
+		var s []any
+		for i, value := range x {
+			nv, changed := normalizeValue(value)
+			if !changed {
+				continue
+			}
+			if s == nil {
+				s = make([]any, len(x))
+				copy(s, x)
+			}
+			s[i] = nv
+		}
+		if s == nil {
+			return v, false
+		}
+
+		return s, true
+	}
+
+	return v, false
+}
+
+// normalizeKey returns a string representation of the given map key.
+func normalizeKey(key any) string {
+	if s, ok := key.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(key)
+}
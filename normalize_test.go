@@ -0,0 +1,134 @@
+package render
+
+import (
+	"container/list"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyncMap(kv map[any]any) *sync.Map {
+	m := &sync.Map{}
+	for k, v := range kv {
+		m.Store(k, v)
+	}
+
+	return m
+}
+
+func newList(values ...any) *list.List {
+	l := list.New()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+
+	return l
+}
+
+func Test_normalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "nil",
+			value: nil,
+			want:  nil,
+		},
+		{
+			name:  "string",
+			value: "foo",
+			want:  "foo",
+		},
+		{
+			name:  "struct",
+			value: struct{ Name string }{Name: "foo"},
+			want:  struct{ Name string }{Name: "foo"},
+		},
+		{
+			name:  "nil *sync.Map",
+			value: (*sync.Map)(nil),
+			want:  (*sync.Map)(nil),
+		},
+		{
+			name:  "empty *sync.Map",
+			value: &sync.Map{},
+			want:  map[string]any{},
+		},
+		{
+			name:  "*sync.Map",
+			value: newSyncMap(map[any]any{"foo": 1, 2: "bar"}),
+			want:  map[string]any{"foo": 1, "2": "bar"},
+		},
+		{
+			name:  "nil *list.List",
+			value: (*list.List)(nil),
+			want:  (*list.List)(nil),
+		},
+		{
+			name:  "empty *list.List",
+			value: list.New(),
+			want:  []any{},
+		},
+		{
+			name:  "*list.List",
+			value: newList("foo", 1, true),
+			want:  []any{"foo", 1, true},
+		},
+		{
+			name:  "nil map[any]any",
+			value: map[any]any(nil),
+			want:  map[string]any(nil),
+		},
+		{
+			name:  "map[any]any",
+			value: map[any]any{"foo": 1, 2: "bar", true: 3.5},
+			want:  map[string]any{"foo": 1, "2": "bar", "true": 3.5},
+		},
+		{
+			name: "nested containers",
+			value: map[any]any{
+				"map":  map[any]any{"a": newList(1, 2)},
+				"list": newList(newSyncMap(map[any]any{"b": 3})),
+			},
+			want: map[string]any{
+				"map":  map[string]any{"a": []any{1, 2}},
+				"list": []any{map[string]any{"b": 3}},
+			},
+		},
+		{
+			name: "map[string]any with nested containers",
+			value: map[string]any{
+				"foo": "bar",
+				"baz": map[any]any{1: "one"},
+			},
+			want: map[string]any{
+				"foo": "bar",
+				"baz": map[string]any{"1": "one"},
+			},
+		},
+		{
+			name:  "[]any with nested containers",
+			value: []any{"foo", newList("bar")},
+			want:  []any{"foo", []any{"bar"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalize(tt.value)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_normalize_doesNotCopyUnchanged(t *testing.T) {
+	m := map[string]any{"foo": "bar", "list": []any{1, 2}}
+
+	got := normalize(m)
+
+	got.(map[string]any)["added"] = true
+	assert.Equal(t, true, m["added"])
+}
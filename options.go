@@ -0,0 +1,183 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Options are per call rendering options, which override the configuration of
+// handlers for a single Render call. They are set with Option functions, like
+// WithPretty and WithIndent.
+//
+// Handlers receive options through the OptionsHandler interface. Options which
+// do not apply to a format are ignored.
+type Options struct {
+	// Pretty forces pretty rendering, even if the pretty argument of the
+	// Render call is false.
+	Pretty bool
+
+	// Prefix overrides the prefix added to each level of indentation when
+	// pretty rendering, if not empty.
+	Prefix string
+
+	// Indent overrides the string added to each level of indentation when
+	// pretty rendering, if not empty.
+	Indent string
+
+	// Fields restricts which fields of struct and map values are rendered.
+	// Set with WithFields.
+	Fields []string
+
+	// OmitFields excludes fields of struct and map values from rendering.
+	// Set with WithoutFields.
+	OmitFields []string
+
+	// KeyCase converts map keys and struct field names to a casing style, if
+	// set. Set with WithKeyCase.
+	KeyCase KeyCase
+
+	// Query is a jq style query expression applied to the value before it is
+	// rendered, if not empty. Set with WithQuery.
+	Query string
+
+	// Params are format specific parameters, keyed by lowercase name. They
+	// are set with WithParam, or with parameters in the format string given
+	// to Render, like "json;indent=4".
+	Params map[string]string
+}
+
+// Option configures Options for a single Render call.
+type Option func(*Options)
+
+// WithPretty renders the value pretty, if the format supports it.
+func WithPretty() Option {
+	return func(o *Options) {
+		o.Pretty = true
+	}
+}
+
+// WithPrefix sets the prefix added to each level of indentation when pretty
+// rendering.
+func WithPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.Prefix = prefix
+	}
+}
+
+// WithIndent sets the string added to each level of indentation when pretty
+// rendering. Formats which only support indenting with spaces, like YAML, use
+// the length of indent as the number of spaces, and ignore it if it contains
+// any other characters.
+func WithIndent(indent string) Option {
+	return func(o *Options) {
+		o.Indent = indent
+	}
+}
+
+// WithSortKeys sorts the keys of all objects, including those rendered from
+// structs, which are otherwise rendered in field order. It sets the
+// "sort_keys" parameter, which is supported by the JSON handler.
+func WithSortKeys() Option {
+	return WithParam("sort_keys", "true")
+}
+
+// WithParam sets a format specific parameter. Handlers which do not recognize
+// the parameter ignore it.
+func WithParam(key, value string) Option {
+	return func(o *Options) {
+		if o.Params == nil {
+			o.Params = map[string]string{}
+		}
+		o.Params[strings.ToLower(key)] = value
+	}
+}
+
+// formatParams splits parameters off the given format string, returning the
+// plain format, and an Option for each parameter. Parameters are separated by
+// semicolons, like "json;prefix=//;indent=\t". Values may contain Go escape
+// sequences, and a parameter without a value is set to "true".
+//
+// The "pretty", "prefix", and "indent" parameters set the matching Options
+// fields. An indent consisting only of digits is used as the number of spaces
+// to indent with. All other parameters are set with WithParam.
+func formatParams(format string) (string, []Option) {
+	format, rest, ok := strings.Cut(format, ";")
+	if !ok {
+		return format, nil
+	}
+
+	var opts []Option
+	for _, p := range strings.Split(rest, ";") {
+		key, value, ok := strings.Cut(p, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if !ok {
+			value = "true"
+		}
+		if s, err := strconv.Unquote(`"` + value + `"`); err == nil {
+			value = s
+		}
+
+		switch key {
+		case "pretty":
+			if b, err := strconv.ParseBool(value); err == nil && b {
+				opts = append(opts, WithPretty())
+			}
+		case "prefix":
+			opts = append(opts, WithPrefix(value))
+		case "indent":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				value = strings.Repeat(" ", n)
+			}
+			opts = append(opts, WithIndent(value))
+		default:
+			opts = append(opts, WithParam(key, value))
+		}
+	}
+
+	return format, opts
+}
+
+// boolParam returns the boolean value of the named parameter, and true if it is
+// set to a valid boolean.
+func (o *Options) boolParam(name string) (bool, bool) {
+	b, err := strconv.ParseBool(o.Params[name])
+
+	return b, err == nil
+}
+
+// transform applies the value transformations of the options to v, like
+// queries, field selection, and key casing.
+func (o *Options) transform(v any) (any, error) {
+	if o.Query != "" {
+		q, err := parseQuery(o.Query)
+		if err != nil {
+			return nil, err
+		}
+		if v, err = q.apply(v); err != nil {
+			return nil, err
+		}
+	}
+	if fs := newFieldSelector(o); fs != nil {
+		v = fs.apply(v)
+	}
+	if o.KeyCase != 0 {
+		v = o.KeyCase.apply(v)
+	}
+
+	return v, nil
+}
+
+// newOptions returns Options with all given Option functions applied.
+func newOptions(opts []Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	return o
+}
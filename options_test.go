@@ -0,0 +1,213 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want *Options
+	}{
+		{
+			name: "no options",
+			want: &Options{},
+		},
+		{
+			name: "all options",
+			opts: []Option{WithPretty(), WithPrefix("//"), WithIndent("\t")},
+			want: &Options{Pretty: true, Prefix: "//", Indent: "\t"},
+		},
+		{
+			name: "later options override earlier ones",
+			opts: []Option{WithIndent("\t"), nil, WithIndent("    ")},
+			want: &Options{Indent: "    "},
+		},
+		{
+			name: "WithSortKeys",
+			opts: []Option{WithSortKeys()},
+			want: &Options{Params: map[string]string{"sort_keys": "true"}},
+		},
+		{
+			name: "WithParam",
+			opts: []Option{WithParam("Foo", "bar"), WithParam("baz", "")},
+			want: &Options{Params: map[string]string{"foo": "bar", "baz": ""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newOptions(tt.opts)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formatParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		wantFormat string
+		want       *Options
+	}{
+		{
+			name:       "no params",
+			format:     "json",
+			wantFormat: "json",
+			want:       &Options{},
+		},
+		{
+			name:       "empty params",
+			format:     "json;;",
+			wantFormat: "json",
+			want:       &Options{},
+		},
+		{
+			name:       "known params",
+			format:     "json;pretty;prefix=//;indent=\\t",
+			wantFormat: "json",
+			want:       &Options{Pretty: true, Prefix: "//", Indent: "\t"},
+		},
+		{
+			name:       "numeric indent",
+			format:     "yaml;indent=4",
+			wantFormat: "yaml",
+			want:       &Options{Indent: "    "},
+		},
+		{
+			name:       "pretty false",
+			format:     "json;pretty=false",
+			wantFormat: "json",
+			want:       &Options{},
+		},
+		{
+			name:       "other params",
+			format:     "json+pretty; Escape_HTML = false ;foo",
+			wantFormat: "json+pretty",
+			want: &Options{Params: map[string]string{
+				"escape_html": " false ",
+				"foo":         "true",
+			}},
+		},
+		{
+			name:       "invalid escape sequence",
+			format:     "json;indent=\\q",
+			wantFormat: "json",
+			want:       &Options{Indent: "\\q"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, opts := formatParams(tt.format)
+
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.want, newOptions(opts))
+		})
+	}
+}
+
+func TestRenderer_Render_withOptions(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+		"mock": &mockPrettyHandler{
+			output:       "plain output",
+			prettyOutput: "pretty output",
+		},
+	})
+	value := map[string]any{"a": map[string]int{"b": 1}}
+
+	tests := []struct {
+		name   string
+		format string
+		pretty bool
+		value  any
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "no options",
+			format: "json",
+			want:   `{"a":{"b":1}}` + "\n",
+		},
+		{
+			name:   "WithPretty",
+			format: "json",
+			opts:   []Option{WithPretty()},
+			want:   "{\n  \"a\": {\n    \"b\": 1\n  }\n}\n",
+		},
+		{
+			name:   "WithIndent and WithPrefix",
+			format: "json",
+			pretty: true,
+			opts:   []Option{WithIndent("\t"), WithPrefix("> ")},
+			want:   "{\n> \t\"a\": {\n> \t\t\"b\": 1\n> \t}\n> }\n",
+		},
+		{
+			name:   "WithIndent without pretty",
+			format: "json",
+			opts:   []Option{WithIndent("\t")},
+			want:   `{"a":{"b":1}}` + "\n",
+		},
+		{
+			name:   "WithIndent on YAML",
+			format: "yaml",
+			opts:   []Option{WithIndent("    ")},
+			want:   "a:\n    b: 1\n",
+		},
+		{
+			name:   "WithSortKeys",
+			format: "json",
+			value: struct {
+				B int
+				A map[string]int
+			}{B: 1, A: map[string]int{"d": 2, "c": 3}},
+			opts: []Option{WithSortKeys()},
+			want: `{"A":{"c":3,"d":2},"B":1}` + "\n",
+		},
+		{
+			name:   "WithPretty on handler without options support",
+			format: "mock",
+			opts:   []Option{WithPretty(), WithIndent("\t")},
+			want:   "pretty output",
+		},
+		{
+			name:   "format params",
+			format: "json;pretty;indent=\\t",
+			want:   "{\n\t\"a\": {\n\t\t\"b\": 1\n\t}\n}\n",
+		},
+		{
+			name:   "format params override options",
+			format: "yaml;indent=4",
+			opts:   []Option{WithIndent("  ")},
+			want:   "a:\n    b: 1\n",
+		},
+		{
+			name:   "format params with modifier",
+			format: "json+pretty;prefix=> ",
+			want:   "{\n>   \"a\": {\n>     \"b\": 1\n>   }\n> }\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value
+			if v == nil {
+				v = value
+			}
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, tt.pretty, v, tt.opts...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	// Handlers must not be modified by per call options.
+	assert.Equal(t, &JSON{}, r.Handlers["json"])
+	assert.Equal(t, &YAML{}, r.Handlers["yaml"])
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"fmt"
+	"io"
+)
+
+// Parse decodes a value in the given format from src, and stores the result
+// in the value pointed to by into. It is the counterpart of Render, allowing
+// the same formats to be used for reading values, like configuration files.
+//
+// If the format is not supported, its Handler does not implement
+// ParseHandler, or the Handler cannot decode into the given value, a
+// ErrUnsupportedFormat error is returned.
+func (r *Renderer) Parse(src io.Reader, format string, into any) error {
+	handler, err := r.handler(format)
+	if err != nil {
+		return err
+	}
+
+	parser, ok := handler.(ParseHandler)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+
+	return renderError(format, parser.Parse(src, into))
+}
+
+// Parse is a convenience function that calls the Default renderer's Parse
+// method.
+func Parse(src io.Reader, format string, into any) error {
+	return defaultRenderer().Parse(src, format, into)
+}
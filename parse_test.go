@@ -0,0 +1,125 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parseTestConfig struct {
+	Name  string   `json:"name" yaml:"name" xml:"name"`
+	Ports []int    `json:"ports" yaml:"ports" xml:"port"`
+	Tags  []string `json:"tags,omitempty" yaml:"tags,omitempty" xml:"-"`
+}
+
+func TestRenderer_Parse(t *testing.T) {
+	r := NewWith("json", "yaml", "xml", "binary")
+
+	tests := []struct {
+		name      string
+		format    string
+		src       string
+		into      func() any
+		want      any
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "json into struct",
+			format: "json",
+			src:    `{"name":"web","ports":[80,443]}`,
+			into:   func() any { return &parseTestConfig{} },
+			want:   &parseTestConfig{Name: "web", Ports: []int{80, 443}},
+		},
+		{
+			name:   "yaml into struct",
+			format: "YML",
+			src:    "name: web\nports: [80, 443]\ntags: [a]\n",
+			into:   func() any { return &parseTestConfig{} },
+			want: &parseTestConfig{
+				Name:  "web",
+				Ports: []int{80, 443},
+				Tags:  []string{"a"},
+			},
+		},
+		{
+			name:   "xml into struct",
+			format: "xml",
+			src: "<config><name>web</name>" +
+				"<port>80</port><port>443</port></config>",
+			into: func() any { return &parseTestConfig{} },
+			want: &parseTestConfig{Name: "web", Ports: []int{80, 443}},
+		},
+		{
+			name:   "yaml into any",
+			format: "yaml",
+			src:    "a: [1, x]\n",
+			into:   func() any { var v any; return &v },
+			want: func() any {
+				var v any = map[string]any{"a": []any{1, "x"}}
+
+				return &v
+			}(),
+		},
+		{
+			name:      "unsupported format",
+			format:    "toml",
+			src:       "",
+			into:      func() any { return &parseTestConfig{} },
+			wantErr:   "render: unsupported format: toml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "format without parse support",
+			format:    "binary",
+			src:       "",
+			into:      func() any { return &parseTestConfig{} },
+			wantErr:   "render: unsupported format: binary",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "value not supported by format",
+			format:    "xml",
+			src:       "<a/>",
+			into:      func() any { var v any; return &v },
+			wantErr:   "render: unsupported format: xml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "invalid input",
+			format:    "json",
+			src:       `{"name":1}`,
+			into:      func() any { return &parseTestConfig{} },
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.into()
+
+			err := r.Parse(strings.NewReader(tt.src), tt.format, got)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	var got map[string]int
+
+	err := Parse(strings.NewReader("a: 1\n"), "yaml", &got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, got)
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PostProcessor is a function which transforms rendered output before it is
+// written. It is given the format string used, and the complete rendered
+// output, and returns the output to write instead. Returning a non-nil error
+// causes nothing to be written.
+//
+// Post-processors allow applying output transformations uniformly across all
+// formats.
+type PostProcessor func(format string, output []byte) ([]byte, error)
+
+// TrailingNewline returns a PostProcessor which ensures non-empty output ends
+// with exactly one newline.
+func TrailingNewline() PostProcessor {
+	return func(_ string, output []byte) ([]byte, error) {
+		if len(output) == 0 {
+			return output, nil
+		}
+
+		output = bytes.TrimRight(output, "\r\n")
+
+		return append(output, '\n'), nil
+	}
+}
+
+// NoTrailingNewline returns a PostProcessor which removes all trailing newlines
+// from output, allowing byte-exact embedding of the output in other documents.
+func NoTrailingNewline() PostProcessor {
+	return func(_ string, output []byte) ([]byte, error) {
+		return bytes.TrimRight(output, "\r\n"), nil
+	}
+}
+
+// PrefixLines returns a PostProcessor which adds prefix to the start of every
+// line of output. A trailing newline does not start a new line.
+func PrefixLines(prefix string) PostProcessor {
+	return func(_ string, output []byte) ([]byte, error) {
+		if len(output) == 0 {
+			return output, nil
+		}
+
+		var buf bytes.Buffer
+		for _, line := range bytes.SplitAfter(output, []byte("\n")) {
+			if len(line) > 0 {
+				buf.WriteString(prefix)
+				buf.Write(line)
+			}
+		}
+
+		return buf.Bytes(), nil
+	}
+}
+
+// MinifyJSON returns a PostProcessor which removes insignificant whitespace
+// from output which is a single valid JSON value. All other output is returned
+// unchanged, so it is safe to use with any format.
+func MinifyJSON() PostProcessor {
+	return func(_ string, output []byte) ([]byte, error) {
+		if !json.Valid(output) {
+			return output, nil
+		}
+
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, output); err != nil {
+			return nil, err
+		}
+		if bytes.HasSuffix(output, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+
+		return buf.Bytes(), nil
+	}
+}
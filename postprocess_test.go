@@ -0,0 +1,196 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrailingNewline(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "empty output", output: "", want: ""},
+		{name: "no newline", output: "hello", want: "hello\n"},
+		{name: "one newline", output: "hello\n", want: "hello\n"},
+		{name: "many newlines", output: "hello\n\r\n\n", want: "hello\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TrailingNewline()("text", []byte(tt.output))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestNoTrailingNewline(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "empty output", output: "", want: ""},
+		{name: "no newline", output: "hello", want: "hello"},
+		{name: "many newlines", output: "a\nb\r\n\n", want: "a\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NoTrailingNewline()("json", []byte(tt.output))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestPrefixLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "empty output", output: "", want: ""},
+		{name: "single line", output: "hello", want: "> hello"},
+		{
+			name:   "multiple lines",
+			output: "hello\n\nworld\n",
+			want:   "> hello\n> \n> world\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrefixLines("> ")("text", []byte(tt.output))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMinifyJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "pretty JSON",
+			output: "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n",
+			want:   "{\"a\":[1,2]}\n",
+		},
+		{
+			name:   "without trailing newline",
+			output: "[ 1, 2 ]",
+			want:   "[1,2]",
+		},
+		{
+			name:   "not JSON",
+			output: "a: 1\nb: 2\n",
+			want:   "a: 1\nb: 2\n",
+		},
+		{
+			name:   "multiple JSON values",
+			output: "{\"a\": 1}\n{\"b\": 2}\n",
+			want:   "{\"a\": 1}\n{\"b\": 2}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MinifyJSON()("json", []byte(tt.output))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRenderer_Render_postProcessors(t *testing.T) {
+	tests := []struct {
+		name           string
+		postProcessors []PostProcessor
+		linters        []Linter
+		handler        Handler
+		want           string
+		wantErr        string
+		wantErrIs      []error
+	}{
+		{
+			name: "applied in order",
+			postProcessors: []PostProcessor{
+				TrailingNewline(),
+				PrefixLines("# "),
+			},
+			handler: &mockHandler{output: "hello\nworld"},
+			want:    "# hello\n# world\n",
+		},
+		{
+			name: "receives format",
+			postProcessors: []PostProcessor{
+				func(format string, _ []byte) ([]byte, error) {
+					return []byte(strings.ToUpper(format)), nil
+				},
+			},
+			handler: &mockHandler{output: "hello"},
+			want:    "MOCK",
+		},
+		{
+			name:           "applied before linters",
+			postProcessors: []PostProcessor{TrailingNewline()},
+			linters:        []Linter{RequireTrailingNewline()},
+			handler:        &mockHandler{output: "hello"},
+			want:           "hello\n",
+		},
+		{
+			name: "post-processor fails",
+			postProcessors: []PostProcessor{
+				func(string, []byte) ([]byte, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			handler:   &mockHandler{output: "hello"},
+			wantErr:   "render: failed: oops",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Renderer{
+				Handlers:       map[string]Handler{"mock": tt.handler},
+				PostProcessors: tt.postProcessors,
+				Linters:        tt.linters,
+			}
+			w := &mockWriter{}
+
+			err := r.Render(w, "mock", false, struct{}{})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, w.String())
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, w.String())
+			}
+		})
+	}
+}
+
+func TestRenderer_NewWith_keepsPostProcessors(t *testing.T) {
+	r := New(map[string]Handler{"mock": &mockHandler{output: "hello"}})
+	r.PostProcessors = []PostProcessor{TrailingNewline()}
+
+	got, err := r.NewWith("mock").String("mock", false, struct{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", got)
+}
@@ -0,0 +1,48 @@
+package render
+
+import "io"
+
+// CanRender checks if v can be rendered to the given format, without writing
+// any output. It allows validating a user supplied format before doing any
+// expensive work.
+//
+// If the Handler implements ProbeHandler, its Probe method is used. Otherwise
+// the value is rendered to io.Discard. Channels are not received from, and are
+// only checked for the format being supported.
+//
+// Errors returned are the same as those returned by Render, with a
+// ErrUnsupportedFormat error if the format is not supported, or the value
+// cannot be rendered to the format.
+func (r *Renderer) CanRender(format string, v any) (err error) {
+	handler, err := r.lookup(format)
+	if err != nil {
+		return err
+	}
+	format, _, o := r.configure(format, false, nil)
+	handler = withOptions(handler, o)
+
+	if _, ok := chanSeq(v); ok {
+		return nil
+	}
+
+	defer func() { err = renderError(format, err) }()
+	defer r.recoverPanic(format, &err)
+
+	return probe(handler, r.prepare(v))
+}
+
+// CanRender is a convenience function that calls the Default renderer's
+// CanRender method.
+func CanRender(format string, v any) error {
+	return defaultRenderer().CanRender(format, v)
+}
+
+// probe checks if handler can render v, using the Probe method if handler
+// implements ProbeHandler, and rendering to io.Discard otherwise.
+func probe(handler Handler, v any) error {
+	if x, ok := handler.(ProbeHandler); ok {
+		return x.Probe(v)
+	}
+
+	return handler.Render(io.Discard, v)
+}
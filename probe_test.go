@@ -0,0 +1,163 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_CanRender(t *testing.T) {
+	var deprecated []string
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"text": &Text{},
+		"mock": &mockHandler{err: errors.New("mock error")},
+		"panic": &mockPanicHandler{
+			value: "boom",
+		},
+	})
+	r.Deprecate("txt", "text")
+	r.OnDeprecated = func(format, _ string) {
+		deprecated = append(deprecated, format)
+	}
+	r.RecoverPanics = true
+
+	tests := []struct {
+		name      string
+		format    string
+		value     any
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "supported value",
+			format: "json",
+			value:  map[string]int{"a": 1},
+		},
+		{
+			name:   "probe handler",
+			format: "TEXT",
+			value:  "hello",
+		},
+		{
+			name:   "deprecated format",
+			format: "txt",
+			value:  "hello",
+		},
+		{
+			name:   "format with modifier",
+			format: "json+pretty",
+			value:  map[string]int{"a": 1},
+		},
+		{
+			name:   "format with parameters",
+			format: "json+pretty;indent=4;escape_html",
+			value:  map[string]int{"a": 1},
+		},
+		{
+			name:   "deprecated format with modifier",
+			format: "txt+compact",
+			value:  "hello",
+		},
+		{
+			name:   "channel",
+			format: "json",
+			value:  make(chan int),
+		},
+		{
+			name:      "unsupported format",
+			format:    "yaml",
+			value:     "hello",
+			wantErr:   "render: unsupported format: yaml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "unsupported format with modifier",
+			format:    "yaml+pretty",
+			value:     "hello",
+			wantErr:   "render: unsupported format: yaml+pretty",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "unsupported format with parameters",
+			format:    "yaml;indent=4",
+			value:     "hello",
+			wantErr:   "render: unsupported format: yaml;indent=4",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "channel with unsupported format",
+			format:    "yaml",
+			value:     make(chan int),
+			wantErr:   "render: unsupported format: yaml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "value not supported by probe handler",
+			format:    "text",
+			value:     map[string]int{"a": 1},
+			wantErr:   "render: unsupported format: text",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "value which fails to render",
+			format:    "json",
+			value:     make(chan<- int),
+			wantErr:   "render: failed: json: unsupported type: chan<- int",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "handler error",
+			format:    "mock",
+			value:     "hello",
+			wantErr:   "render: failed: mock error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "handler panic",
+			format:    "panic",
+			value:     "hello",
+			wantErr:   "render: failed: panic: panic: boom",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.CanRender(tt.format, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Empty(t, deprecated, "OnDeprecated must not be called")
+}
+
+func TestRenderer_CanRender_doesNotConsumeReaders(t *testing.T) {
+	r := NewWith("text")
+	reader := strings.NewReader("hello")
+	var buf bytes.Buffer
+
+	err := r.CanRender("text", reader)
+	assert.NoError(t, err)
+
+	err = r.Render(&buf, "text", false, reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", buf.String())
+}
+
+func TestCanRender(t *testing.T) {
+	assert.NoError(t, CanRender("yaml", map[string]int{"a": 1}))
+	assert.ErrorIs(t, CanRender("xml", 1), ErrUnsupportedFormat)
+}
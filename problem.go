@@ -0,0 +1,202 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ProblemDetails is a RFC 7807 problem details object, describing an error in
+// a machine readable way.
+//
+// ProblemDetails implements the error interface, so it can be returned as an
+// error, and then rendered by the Problem handler as is, even when wrapped.
+type ProblemDetails struct {
+	// Type is a URI reference which identifies the problem type. When
+	// empty, it is assumed to be "about:blank".
+	Type string `json:"type,omitempty"`
+
+	// Title is a short human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code of the problem.
+	Status int `json:"status,omitempty"`
+
+	// Detail is a human-readable explanation specific to this occurrence of
+	// the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference which identifies the specific occurrence of
+	// the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// Extensions are additional members added to the problem details object.
+	// Members with the same name as standard members are ignored.
+	Extensions map[string]any `json:"-"`
+}
+
+var _ error = (*ProblemDetails)(nil)
+
+// Error returns the title and detail of the problem.
+func (pd *ProblemDetails) Error() string {
+	switch {
+	case pd.Title == "":
+		return pd.Detail
+	case pd.Detail == "":
+		return pd.Title
+	default:
+		return pd.Title + ": " + pd.Detail
+	}
+}
+
+// MarshalJSON marshals the problem details, including any extension members.
+func (pd ProblemDetails) MarshalJSON() ([]byte, error) {
+	type problemDetails ProblemDetails
+	b, err := jsonMarshal(problemDetails(pd))
+	if err != nil {
+		return nil, err
+	}
+
+	ext := make(map[string]any, len(pd.Extensions))
+	for k, v := range pd.Extensions {
+		switch k {
+		case "type", "title", "status", "detail", "instance":
+		default:
+			ext[k] = v
+		}
+	}
+	if len(ext) == 0 {
+		return b, nil
+	}
+
+	eb, err := jsonMarshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 2 {
+		return eb, nil
+	}
+
+	// Join the two JSON objects, dropping the closing brace of the first, and
+	// the opening brace of the second.
+	return append(append(b[:len(b)-1], ','), eb[1:]...), nil
+}
+
+// Problem is a Handler that renders error values and ProblemDetails as RFC
+// 7807 "application/problem+json" documents.
+//
+// ProblemDetails values, and errors which wrap a *ProblemDetails, are rendered
+// as is. All other errors are rendered as a problem with the HTTP status code
+// given by the Status field, a title of the matching HTTP status text, and the
+// error message as the detail.
+//
+// If the value is not an error or ProblemDetails, a ErrCannotRender error will
+// be returned.
+type Problem struct {
+	// Status is the HTTP status code used for errors which are not a
+	// ProblemDetails. If zero, http.StatusInternalServerError is used.
+	Status int
+
+	// Prefix is the prefix added to each level of indentation when pretty
+	// rendering.
+	Prefix string
+
+	// Indent is the string added to each level of indentation when pretty
+	// rendering. If empty, the Indent of the JSON handler is used.
+	Indent string
+
+	// JSON is the handler which encodes problem details, allowing JSON
+	// options like SortKeys to be set. If nil, a JSON handler with default
+	// options is used.
+	JSON *JSON
+}
+
+var (
+	_ Handler            = (*Problem)(nil)
+	_ PrettyHandler      = (*Problem)(nil)
+	_ FormatsHandler     = (*Problem)(nil)
+	_ DescribedHandler   = (*Problem)(nil)
+	_ ContentTypeHandler = (*Problem)(nil)
+	_ OptionsHandler     = (*Problem)(nil)
+)
+
+// Render marshals the given value to a problem details JSON document.
+func (pr *Problem) Render(w io.Writer, v any) error {
+	pd, err := pr.problem(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(pr.JSON, pr.Prefix, pr.Indent).Render(w, pd)
+}
+
+// RenderPretty marshals the given value to a problem details JSON document
+// with line breaks and indentation.
+func (pr *Problem) RenderPretty(w io.Writer, v any) error {
+	pd, err := pr.problem(v)
+	if err != nil {
+		return err
+	}
+
+	return jsonWith(pr.JSON, pr.Prefix, pr.Indent).RenderPretty(w, pd)
+}
+
+// WithOptions returns a copy of the Problem handler, with the options applied
+// to its JSON handler.
+func (pr *Problem) WithOptions(opts *Options) Handler {
+	jr := jsonWith(pr.JSON, pr.Prefix, pr.Indent)
+
+	c := *pr
+	c.JSON, _ = jr.WithOptions(opts).(*JSON)
+	c.Prefix = ""
+	c.Indent = ""
+
+	return &c
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (pr *Problem) Formats() []string {
+	return []string{"problem", "problem+json"}
+}
+
+// Description returns a short human-readable description of the format.
+func (pr *Problem) Description() string {
+	return "RFC 7807 problem details JSON"
+}
+
+// ContentType returns the MIME type of the format.
+func (pr *Problem) ContentType() string {
+	return "application/problem+json"
+}
+
+func (pr *Problem) problem(v any) (*ProblemDetails, error) {
+	switch x := v.(type) {
+	case ProblemDetails:
+		return &x, nil
+	case *ProblemDetails:
+		if x == nil {
+			return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+		}
+
+		return x, nil
+	case error:
+		var pd *ProblemDetails
+		if errors.As(x, &pd) && pd != nil {
+			return pd, nil
+		}
+
+		status := pr.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+
+		return &ProblemDetails{
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: x.Error(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+}
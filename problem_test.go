@@ -0,0 +1,232 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetails_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   *ProblemDetails
+		want string
+	}{
+		{
+			name: "title and detail",
+			pd:   &ProblemDetails{Title: "Not Found", Detail: "no such user"},
+			want: "Not Found: no such user",
+		},
+		{
+			name: "title only",
+			pd:   &ProblemDetails{Title: "Not Found"},
+			want: "Not Found",
+		},
+		{
+			name: "detail only",
+			pd:   &ProblemDetails{Detail: "no such user"},
+			want: "no such user",
+		},
+		{
+			name: "empty",
+			pd:   &ProblemDetails{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pd.Error())
+		})
+	}
+}
+
+func TestProblem_Render(t *testing.T) {
+	notFound := &ProblemDetails{
+		Type:     "https://example.com/probs/not-found",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "no such user",
+		Instance: "/users/42",
+	}
+
+	tests := []struct {
+		name      string
+		problem   *Problem
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "problem details",
+			value: notFound,
+			want: `{"type":"https://example.com/probs/not-found",` +
+				`"title":"Not Found","status":404,"detail":"no such user",` +
+				`"instance":"/users/42"}` + "\n",
+		},
+		{
+			name: "problem details value with extensions",
+			value: ProblemDetails{
+				Title: "Out of credit",
+				Extensions: map[string]any{
+					"balance": 30,
+					"title":   "ignored",
+				},
+			},
+			want: `{"title":"Out of credit","balance":30}` + "\n",
+		},
+		{
+			name: "only extensions",
+			value: ProblemDetails{
+				Extensions: map[string]any{"balance": 30},
+			},
+			want: `{"balance":30}` + "\n",
+		},
+		{
+			name:  "wrapped problem details",
+			value: fmt.Errorf("lookup failed: %w", notFound),
+			want: `{"type":"https://example.com/probs/not-found",` +
+				`"title":"Not Found","status":404,"detail":"no such user",` +
+				`"instance":"/users/42"}` + "\n",
+		},
+		{
+			name:  "plain error",
+			value: errors.New("something broke"),
+			want: `{"title":"Internal Server Error","status":500,` +
+				`"detail":"something broke"}` + "\n",
+		},
+		{
+			name:    "plain error with custom status",
+			problem: &Problem{Status: http.StatusBadRequest},
+			value:   errors.New("invalid input"),
+			want: `{"title":"Bad Request","status":400,` +
+				`"detail":"invalid input"}` + "\n",
+		},
+		{
+			name:   "pretty",
+			pretty: true,
+			value:  &ProblemDetails{Title: "Not Found", Status: 404},
+			want: `{
+  "title": "Not Found",
+  "status": 404
+}
+`,
+		},
+		{
+			name:      "nil problem details",
+			value:     (*ProblemDetails)(nil),
+			wantErr:   "render: cannot render: *render.ProblemDetails",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "not an error",
+			value:     "hello",
+			wantErr:   "render: cannot render: string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.problem
+			if h == nil {
+				h = &Problem{}
+			}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProblem_Formats(t *testing.T) {
+	h := &Problem{}
+
+	assert.Equal(t, []string{"problem", "problem+json"}, h.Formats())
+}
+
+func TestProblem_WithOptions(t *testing.T) {
+	h := &Problem{Status: http.StatusBadRequest, Prefix: "//"}
+
+	got := h.WithOptions(&Options{
+		Indent: "\t",
+		Params: map[string]string{"sort_keys": "1"},
+	})
+
+	assert.Equal(t,
+		&Problem{
+			Status: http.StatusBadRequest,
+			JSON:   &JSON{Prefix: "//", Indent: "\t", SortKeys: true},
+		},
+		got,
+	)
+	assert.Equal(t, &Problem{Status: http.StatusBadRequest, Prefix: "//"}, h)
+}
+
+func TestRenderer_Render_problemOptions(t *testing.T) {
+	r := New(map[string]Handler{"problem": &Problem{}})
+	pd := &ProblemDetails{
+		Title:      "Bad <Request>",
+		Status:     http.StatusBadRequest,
+		Extensions: map[string]any{"field": "a&b"},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "escapes HTML by default",
+			format: "problem",
+			want: `{"title":"Bad \u003cRequest\u003e","status":400,` +
+				`"field":"a\u0026b"}` + "\n",
+		},
+		{
+			name:   "format parameters",
+			format: "problem;escape_html=false;sort_keys",
+			want: `{"field":"a&b","status":400,` +
+				`"title":"Bad <Request>"}` + "\n",
+		},
+		{
+			name:   "WithIndent",
+			format: "problem",
+			opts:   []Option{WithPretty(), WithIndent("\t")},
+			want: "{\n\t\"title\": \"Bad \\u003cRequest\\u003e\",\n" +
+				"\t\"status\": 400,\n\t\"field\": \"a\\u0026b\"\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, pd, tt.opts...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
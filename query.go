@@ -0,0 +1,241 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a Handler that renders flat structs and maps as URL query strings,
+// in the application/x-www-form-urlencoded format.
+//
+// url.Values, map[string][]string, and other maps with string keys are
+// rendered with their keys sorted. Struct fields are rendered in the order
+// they are defined, using the "query" struct tag for the key if present,
+// otherwise the field name. Fields tagged with `query:"-"` are skipped, and
+// fields with the "omitempty" option are skipped if they have a zero value,
+// for example `query:"page,omitempty"`.
+//
+// Slice and array values are rendered as repeated keys. time.Time values are
+// formatted as RFC 3339, fmt.Stringer values with their String method, and all
+// other values with fmt.Sprint. Nil values are skipped.
+//
+// If the value is not a struct or a map with string keys, or it contains
+// nested structs or maps, a ErrCannotRender error will be returned.
+type Query struct{}
+
+var (
+	_ Handler            = (*Query)(nil)
+	_ FormatsHandler     = (*Query)(nil)
+	_ DescribedHandler   = (*Query)(nil)
+	_ ContentTypeHandler = (*Query)(nil)
+	_ ParseHandler       = (*Query)(nil)
+)
+
+// Render writes v as a URL encoded query string to w.
+func (q *Query) Render(w io.Writer, v any) error {
+	pairs, ok := queryPairs(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	var buf strings.Builder
+	for _, p := range pairs {
+		if buf.Len() > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(url.QueryEscape(p[0]))
+		buf.WriteByte('=')
+		buf.WriteString(url.QueryEscape(p[1]))
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Parse decodes a URL encoded query string from r into v, which must be a
+// *url.Values, *map[string][]string, *map[string]string, or *any. Only the
+// first value of repeated keys is kept with *map[string]string. With *any, v
+// is set to a map[string]any, with values of repeated keys as a []any slice,
+// and all other values as strings.
+func (q *Query) Parse(r io.Reader, v any) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	values, err := url.ParseQuery(strings.TrimSpace(string(b)))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	switch x := v.(type) {
+	case *url.Values:
+		*x = values
+	case *map[string][]string:
+		*x = values
+	case *map[string]string:
+		m := make(map[string]string, len(values))
+		for k := range values {
+			m[k] = values.Get(k)
+		}
+		*x = m
+	case *any:
+		m := make(map[string]any, len(values))
+		for k, vs := range values {
+			if len(vs) == 1 {
+				m[k] = vs[0]
+
+				continue
+			}
+
+			s := make([]any, 0, len(vs))
+			for _, v := range vs {
+				s = append(s, v)
+			}
+			m[k] = s
+		}
+		*x = m
+	default:
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (q *Query) Formats() []string {
+	return []string{"query", "form"}
+}
+
+// Description returns a short human-readable description of the format.
+func (q *Query) Description() string {
+	return "URL encoded query string"
+}
+
+// ContentType returns the MIME type of the format.
+func (q *Query) ContentType() string {
+	return "application/x-www-form-urlencoded"
+}
+
+// queryPairs returns the key/value pairs of v in the order they should be
+// rendered. If v cannot be rendered as a query string, ok is false.
+func queryPairs(v any) (pairs [][2]string, ok bool) {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, false
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			kv := reflect.ValueOf(k).Convert(rv.Type().Key())
+			values, ok := queryValues(rv.MapIndex(kv))
+			if !ok {
+				return nil, false
+			}
+			for _, s := range values {
+				pairs = append(pairs, [2]string{k, s})
+			}
+		}
+	case reflect.Struct:
+		for _, f := range structFields(rv.Type(), "query") {
+			fv := f.value(rv)
+			if f.hasOption("omitempty") && (!fv.IsValid() || fv.IsZero()) {
+				continue
+			}
+
+			values, ok := queryValues(fv)
+			if !ok {
+				return nil, false
+			}
+			for _, s := range values {
+				pairs = append(pairs, [2]string{f.name, s})
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	return pairs, true
+}
+
+// queryValues returns the string values of rv, which is a single value, or a
+// slice or array of values. Nested structs and maps are not supported.
+func queryValues(rv reflect.Value) (values []string, ok bool) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return nil, true
+	}
+
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) &&
+		rv.Type().Elem().Kind() != reflect.Uint8 {
+		values = make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			ev := indirect(rv.Index(i))
+			if !ev.IsValid() {
+				continue
+			}
+
+			s, ok := queryValue(ev)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+
+		return values, true
+	}
+
+	s, ok := queryValue(rv)
+	if !ok {
+		return nil, false
+	}
+
+	return []string{s}, true
+}
+
+func queryValue(rv reflect.Value) (string, bool) {
+	switch x := rv.Interface().(type) {
+	case time.Time:
+		return x.Format(time.RFC3339Nano), true
+	case fmt.Stringer:
+		return x.String(), true
+	case []byte:
+		return string(x), true
+	}
+	if s, ok := addrStringer(rv); ok {
+		return s.String(), true
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return "", false
+	default:
+		return fmt.Sprint(rv.Interface()), true
+	}
+}
@@ -0,0 +1,165 @@
+package render
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queryTestSearch struct {
+	Query   string    `query:"q"`
+	Page    int       `query:"page,omitempty"`
+	Tags    []string  `query:"tag"`
+	Exact   bool      `query:"exact"`
+	Since   time.Time `query:"since,omitempty"`
+	Limit   *int      `query:"limit"`
+	Sort    *mockStringer
+	Ignored string `query:"-"`
+}
+
+func TestQuery_Render(t *testing.T) {
+	limit := 10
+
+	tests := []struct {
+		name      string
+		writeErr  error
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "struct",
+			value: &queryTestSearch{
+				Query:   "hello world",
+				Tags:    []string{"a&b", "c"},
+				Exact:   true,
+				Since:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+				Limit:   &limit,
+				Sort:    &mockStringer{value: "name"},
+				Ignored: "x",
+			},
+			want: "q=hello+world&tag=a%26b&tag=c&exact=true" +
+				"&since=2024-03-01T12%3A00%3A00Z&limit=10&Sort=name",
+		},
+		{
+			name:  "struct with empty values",
+			value: queryTestSearch{Page: 2},
+			want:  "q=&page=2&exact=false",
+		},
+		{
+			name: "url.Values",
+			value: url.Values{
+				"b": {"2", "3"},
+				"a": {"1"},
+			},
+			want: "a=1&b=2&b=3",
+		},
+		{
+			name: "map with any values",
+			value: map[string]any{
+				"z":   1.5,
+				"a":   []int{1, 2},
+				"nil": nil,
+				"s":   "x/y",
+			},
+			want: "a=1&a=2&s=x%2Fy&z=1.5",
+		},
+		{
+			name:  "empty map",
+			value: map[string]string{},
+			want:  "",
+		},
+		{
+			name:      "nested struct",
+			value:     map[string]any{"a": struct{ B int }{}},
+			wantErr:   "render: cannot render: map[string]interface {}",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "nested slice",
+			value:     struct{ A [][]int }{A: [][]int{{1}}},
+			wantErr:   "render: cannot render: struct { A [][]int }",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "map without string keys",
+			value:     map[int]string{1: "a"},
+			wantErr:   "render: cannot render: map[int]string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "nil",
+			value:     nil,
+			wantErr:   "render: cannot render: <nil>",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "write error",
+			writeErr:  assert.AnError,
+			value:     url.Values{"a": {"1"}},
+			wantErr:   "render: failed: " + assert.AnError.Error(),
+			wantErrIs: []error{Err, ErrFailed, assert.AnError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockWriter{WriteErr: tt.writeErr}
+			h := &Query{}
+
+			err := h.Render(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQuery_Formats(t *testing.T) {
+	h := &Query{}
+
+	assert.Equal(t, []string{"query", "form"}, h.Formats())
+}
+
+func TestQuery_Parse(t *testing.T) {
+	h := &Query{}
+	src := "a=1&b=x+y&b=z\n"
+
+	var values url.Values
+	require.NoError(t, h.Parse(strings.NewReader(src), &values))
+	assert.Equal(t, url.Values{"a": {"1"}, "b": {"x y", "z"}}, values)
+
+	var m map[string][]string
+	require.NoError(t, h.Parse(strings.NewReader(src), &m))
+	assert.Equal(t, map[string][]string{"a": {"1"}, "b": {"x y", "z"}}, m)
+
+	var first map[string]string
+	require.NoError(t, h.Parse(strings.NewReader(src), &first))
+	assert.Equal(t, map[string]string{"a": "1", "b": "x y"}, first)
+
+	var v any
+	require.NoError(t, h.Parse(strings.NewReader(src), &v))
+	assert.Equal(t, map[string]any{"a": "1", "b": []any{"x y", "z"}}, v)
+
+	err := h.Parse(strings.NewReader("a=%zz"), &values)
+	assert.ErrorIs(t, err, ErrFailed)
+
+	var s string
+	err = h.Parse(strings.NewReader(src), &s)
+	assert.EqualError(t, err, "render: cannot render: *string")
+	assert.ErrorIs(t, err, ErrCannotRender)
+}
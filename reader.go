@@ -0,0 +1,35 @@
+package render
+
+import (
+	"io"
+	"math"
+)
+
+// readFromStart returns a io.Reader which reads r from its start, if possible.
+//
+// io.ReadSeeker values are seeked to their start before being read. If seeking
+// fails, as is the case with pipes, the value is read from its current
+// position instead. io.ReaderAt values are read from offset zero, up to their
+// size if they implement a Size() int64 method. Other io.Reader values are
+// returned as is, and nil is returned for any other values.
+//
+// This allows the same value to be rendered multiple times.
+func readFromStart(r any) io.Reader {
+	switch x := r.(type) {
+	case io.ReadSeeker:
+		_, _ = x.Seek(0, io.SeekStart)
+
+		return x
+	case io.ReaderAt:
+		size := int64(math.MaxInt64)
+		if s, ok := x.(interface{ Size() int64 }); ok {
+			size = s.Size()
+		}
+
+		return io.NewSectionReader(x, 0, size)
+	case io.Reader:
+		return x
+	}
+
+	return nil
+}
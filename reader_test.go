@@ -0,0 +1,140 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReaderAt only implements io.ReaderAt.
+type mockReaderAt struct {
+	value string
+	err   error
+}
+
+var _ io.ReaderAt = (*mockReaderAt)(nil)
+
+func (m *mockReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	return strings.NewReader(m.value).ReadAt(p, off)
+}
+
+// mockPipe is a io.ReadSeeker which fails to seek, like a pipe.
+type mockPipe struct {
+	*strings.Reader
+}
+
+var _ io.ReadSeeker = (*mockPipe)(nil)
+
+func (*mockPipe) Seek(int64, int) (int64, error) {
+	return 0, errors.New("illegal seek")
+}
+
+// partiallyRead returns a io.Reader which has had n bytes read from it.
+func partiallyRead(r io.Reader, n int) io.Reader {
+	_, _ = r.Read(make([]byte, n))
+
+	return r
+}
+
+func Test_readFromStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantNil bool
+	}{
+		{
+			name:  "io.ReadSeeker",
+			value: partiallyRead(strings.NewReader("hello world"), 6),
+			want:  "hello world",
+		},
+		{
+			name: "io.ReadSeeker which cannot seek",
+			value: &mockPipe{
+				Reader: partiallyRead(
+					strings.NewReader("hello world"), 6,
+				).(*strings.Reader),
+			},
+			want: "world",
+		},
+		{
+			name:  "io.ReaderAt",
+			value: &mockReaderAt{value: "hello world"},
+			want:  "hello world",
+		},
+		{
+			name:  "io.Reader",
+			value: partiallyRead(&mockReader{value: "hello world"}, 6),
+			want:  "world",
+		},
+		{
+			name:    "other",
+			value:   "hello world",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := readFromStart(tt.value)
+
+			if tt.wantNil {
+				assert.Nil(t, r)
+
+				return
+			}
+
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRenderTwice(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler Handler
+		value   any
+	}{
+		{
+			name:    "text with io.ReadSeeker",
+			handler: &Text{},
+			value:   bytes.NewReader([]byte("hello world")),
+		},
+		{
+			name:    "text with io.ReaderAt",
+			handler: &Text{},
+			value:   &mockReaderAt{value: "hello world"},
+		},
+		{
+			name:    "binary with io.ReadSeeker",
+			handler: &Binary{},
+			value:   bytes.NewReader([]byte("hello world")),
+		},
+		{
+			name:    "binary with io.ReaderAt",
+			handler: &Binary{},
+			value:   &mockReaderAt{value: "hello world"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+
+				err := tt.handler.Render(&buf, tt.value)
+
+				assert.NoError(t, err)
+				assert.Equal(t, "hello world", buf.String())
+			}
+		})
+	}
+}
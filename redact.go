@@ -0,0 +1,262 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Redacted is the default replacement for redacted values.
+const Redacted = "[REDACTED]"
+
+// maxDepth limits how deeply nested values are inspected when transforming
+// values before rendering, protecting against cyclic data structures.
+const maxDepth = 64
+
+// Redactor replaces sensitive values, like passwords and tokens, before they
+// are rendered. It is set on a Renderer with the Redactor field, and applies
+// to all formats.
+//
+// Struct fields tagged with `render:"redact"` are always redacted. Struct
+// fields and string map keys matching one of Fields are also redacted.
+//
+// Redacted values of string and interface types are replaced with the
+// Replacement string, while values of all other types are replaced with their
+// zero value. Values are copied as needed, the original value is never
+// modified.
+type Redactor struct {
+	// Fields is a list of names to redact. They are matched case-insensitively
+	// against struct field names, the names given in their json struct tag,
+	// and string map keys.
+	Fields []string
+
+	// Replacement is the string redacted values are replaced with. If empty,
+	// Redacted is used.
+	Replacement string
+}
+
+// redact returns v with all redacted values replaced. If nothing is redacted,
+// v is returned as is.
+func (rd *Redactor) redact(v any) any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+
+	nv, changed := rd.value(rv, 0)
+	if !changed {
+		return v
+	}
+
+	return nv.Interface()
+}
+
+// value returns rv with redacted values replaced, and reports if anything was
+// replaced. The returned value always has the same type as rv.
+func (rd *Redactor) value(
+	rv reflect.Value,
+	depth int,
+) (reflect.Value, bool) {
+	if depth > maxDepth {
+		return rv, false
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return rv, false
+		}
+
+		ev, changed := rd.value(rv.Elem(), depth+1)
+		if !changed {
+			return rv, false
+		}
+
+		if rv.Kind() == reflect.Interface {
+			nv := reflect.New(rv.Type()).Elem()
+			nv.Set(ev)
+
+			return nv, true
+		}
+
+		p := reflect.New(ev.Type())
+		p.Elem().Set(ev)
+
+		return p, true
+	case reflect.Struct:
+		return rd.structValue(rv, depth)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return rv, false
+		}
+
+		var c reflect.Value
+		for i := 0; i < rv.Len(); i++ {
+			ev, changed := rd.value(rv.Index(i), depth+1)
+			if !changed {
+				continue
+			}
+			if !c.IsValid() {
+				c = copyValue(rv)
+			}
+			c.Index(i).Set(ev)
+		}
+		if !c.IsValid() {
+			return rv, false
+		}
+
+		return c, true
+	case reflect.Map:
+		return rd.mapValue(rv, depth)
+	}
+
+	return rv, false
+}
+
+// structValue returns a copy of the struct rv with redacted fields replaced,
+// and reports if anything was replaced.
+func (rd *Redactor) structValue(
+	rv reflect.Value,
+	depth int,
+) (reflect.Value, bool) {
+	t := rv.Type()
+
+	var c reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		var fv reflect.Value
+		if rd.redactField(f) {
+			fv = rd.replacement(f.Type)
+		} else {
+			var changed bool
+			if fv, changed = rd.value(rv.Field(i), depth+1); !changed {
+				continue
+			}
+		}
+
+		if !c.IsValid() {
+			c = copyValue(rv)
+		}
+		c.Field(i).Set(fv)
+	}
+	if !c.IsValid() {
+		return rv, false
+	}
+
+	return c, true
+}
+
+// mapValue returns a copy of the map rv with redacted entries replaced, and
+// reports if anything was replaced.
+func (rd *Redactor) mapValue(
+	rv reflect.Value,
+	depth int,
+) (reflect.Value, bool) {
+	if rv.IsNil() {
+		return rv, false
+	}
+
+	var c reflect.Value
+	iter := rv.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+
+		var ev reflect.Value
+		if k.Kind() == reflect.String && rd.matches(k.String()) {
+			ev = rd.replacement(rv.Type().Elem())
+		} else {
+			var changed bool
+			if ev, changed = rd.value(iter.Value(), depth+1); !changed {
+				continue
+			}
+		}
+
+		if !c.IsValid() {
+			c = copyValue(rv)
+		}
+		c.SetMapIndex(k, ev)
+	}
+	if !c.IsValid() {
+		return rv, false
+	}
+
+	return c, true
+}
+
+// redactField reports if the struct field f should be redacted.
+func (rd *Redactor) redactField(f reflect.StructField) bool {
+	for _, opt := range strings.Split(f.Tag.Get("render"), ",") {
+		if strings.TrimSpace(opt) == "redact" {
+			return true
+		}
+	}
+
+	if rd.matches(f.Name) {
+		return true
+	}
+
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+	return name != "" && rd.matches(name)
+}
+
+// matches reports if name matches one of the Fields to redact.
+func (rd *Redactor) matches(name string) bool {
+	for _, f := range rd.Fields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replacement returns the value which redacted values of type t are replaced
+// with.
+func (rd *Redactor) replacement(t reflect.Type) reflect.Value {
+	s := rd.Replacement
+	if s == "" {
+		s = Redacted
+	}
+
+	sv := reflect.ValueOf(s)
+	switch {
+	case t.Kind() == reflect.String:
+		return sv.Convert(t)
+	case sv.Type().AssignableTo(t):
+		nv := reflect.New(t).Elem()
+		nv.Set(sv)
+
+		return nv
+	default:
+		return reflect.Zero(t)
+	}
+}
+
+// copyValue returns an addressable shallow copy of the struct, array, slice,
+// or map rv.
+func copyValue(rv reflect.Value) reflect.Value {
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Slice:
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+
+		return c
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		return c
+	default:
+		c := reflect.New(rv.Type()).Elem()
+		c.Set(rv)
+
+		return c
+	}
+}
@@ -0,0 +1,196 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type redactTestCredentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	APIKey   string `json:"api_key" render:"redact"`
+	PIN      int    `json:"pin" render:"redact"`
+	Extra    any    `json:"extra,omitempty"`
+}
+
+type redactTestAccount struct {
+	Name  string                  `json:"name"`
+	Creds *redactTestCredentials  `json:"creds"`
+	Other []redactTestCredentials `json:"other,omitempty"`
+}
+
+func TestRedactor_redact(t *testing.T) {
+	tests := []struct {
+		name     string
+		redactor *Redactor
+		value    any
+		want     any
+	}{
+		{
+			name:     "nil value",
+			redactor: &Redactor{},
+			value:    nil,
+			want:     nil,
+		},
+		{
+			name:     "tagged fields",
+			redactor: &Redactor{},
+			value: redactTestCredentials{
+				User: "john", Password: "secret", APIKey: "key", PIN: 1234,
+			},
+			want: redactTestCredentials{
+				User: "john", Password: "secret", APIKey: Redacted,
+			},
+		},
+		{
+			name:     "named fields",
+			redactor: &Redactor{Fields: []string{"PASSWORD", "extra"}},
+			value: &redactTestCredentials{
+				User: "john", Password: "secret", Extra: 42,
+			},
+			want: &redactTestCredentials{
+				User: "john", Password: Redacted, APIKey: Redacted,
+				Extra: Redacted,
+			},
+		},
+		{
+			name: "custom replacement",
+			redactor: &Redactor{
+				Fields:      []string{"password"},
+				Replacement: "***",
+			},
+			value: redactTestCredentials{Password: "secret"},
+			want:  redactTestCredentials{Password: "***", APIKey: "***"},
+		},
+		{
+			name:     "nested values",
+			redactor: &Redactor{Fields: []string{"password"}},
+			value: redactTestAccount{
+				Name:  "acme",
+				Creds: &redactTestCredentials{Password: "secret"},
+				Other: []redactTestCredentials{{User: "x", Password: "y"}},
+			},
+			want: redactTestAccount{
+				Name: "acme",
+				Creds: &redactTestCredentials{
+					Password: Redacted, APIKey: Redacted,
+				},
+				Other: []redactTestCredentials{{
+					User: "x", Password: Redacted, APIKey: Redacted,
+				}},
+			},
+		},
+		{
+			name:     "map keys",
+			redactor: &Redactor{Fields: []string{"token"}},
+			value: map[string]any{
+				"Token": "abc",
+				"list":  []any{map[string]string{"token": "def", "id": "1"}},
+			},
+			want: map[string]any{
+				"Token": Redacted,
+				"list":  []any{map[string]string{"token": Redacted, "id": "1"}},
+			},
+		},
+		{
+			name:     "map with non-string values",
+			redactor: &Redactor{Fields: []string{"token"}},
+			value:    map[string]int{"token": 1, "id": 2},
+			want:     map[string]int{"token": 0, "id": 2},
+		},
+		{
+			name:     "array",
+			redactor: &Redactor{Fields: []string{"token"}},
+			value:    [1]map[string]string{{"token": "abc"}},
+			want:     [1]map[string]string{{"token": Redacted}},
+		},
+		{
+			name:     "nothing to redact",
+			redactor: &Redactor{Fields: []string{"password"}},
+			value:    map[string]any{"user": "john", "nil": nil},
+			want:     map[string]any{"user": "john", "nil": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.redactor.redact(tt.value)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRedactor_redact_doesNotModifyValue(t *testing.T) {
+	creds := &redactTestCredentials{Password: "secret", APIKey: "key"}
+	m := map[string]any{"creds": creds, "token": "abc"}
+	rd := &Redactor{Fields: []string{"password", "token"}}
+
+	got := rd.redact(m)
+
+	assert.Equal(t, map[string]any{
+		"creds": &redactTestCredentials{Password: Redacted, APIKey: Redacted},
+		"token": Redacted,
+	}, got)
+	assert.Equal(t, "secret", creds.Password)
+	assert.Equal(t, "key", creds.APIKey)
+	assert.Equal(t, "abc", m["token"])
+}
+
+func TestRedactor_redact_cyclic(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+
+	got := (&Redactor{Fields: []string{"token"}}).redact(n)
+
+	assert.Same(t, n, got)
+}
+
+func TestRenderer_Render_redactor(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+		"xml":  &XML{},
+	})
+	r.Redactor = &Redactor{Fields: []string{"password"}}
+	value := redactTestCredentials{User: "john", Password: "secret"}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "json",
+			want: `{"user":"john","password":"[REDACTED]",` +
+				`"api_key":"[REDACTED]","pin":0}` + "\n",
+		},
+		{
+			format: "yaml",
+			want: "user: john\npassword: '[REDACTED]'\n" +
+				"apikey: '[REDACTED]'\npin: 0\nextra: null\n",
+		},
+		{
+			format: "xml",
+			want: "<redactTestCredentials><User>john</User>" +
+				"<Password>[REDACTED]</Password>" +
+				"<APIKey>[REDACTED]</APIKey><PIN>0</PIN>" +
+				"</redactTestCredentials>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := r.Render(&buf, tt.format, false, value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	assert.Same(t, r.Redactor, r.NewWith("json").Redactor)
+}
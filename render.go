@@ -20,6 +20,8 @@ package render
 import (
 	"fmt"
 	"io"
+	"reflect"
+	"sync"
 )
 
 var (
@@ -37,11 +39,29 @@ var (
 	// level NewWith function to create new renderers with a sub-set of
 	// formats.
 	Base = New(map[string]Handler{
-		"binary": &Binary{},
-		"json":   &JSON{},
-		"text":   &Text{},
-		"xml":    &XML{},
-		"yaml":   &YAML{},
+		"arrow":      &Arrow{},
+		"auto":       &Auto{},
+		"binary":     &Binary{},
+		"cloudevent": &CloudEvent{},
+		"csv":        &CSV{},
+		"dump":       &Dump{},
+		"flat":       &Flat{},
+		"hal":        &HAL{},
+		"ics":        &ICal{},
+		"json":       &JSON{},
+		"ndjson":     &NDJSON{},
+		"jsonapi":    &JSONAPI{},
+		"junit":      &JUnit{},
+		"problem":    &Problem{},
+		"query":      &Query{},
+		"table":      &Table{},
+		"text":       &Text{},
+		"tsv":        &CSV{Delimiter: '\t'},
+		"vcf":        &VCard{},
+		"wide":       &Table{Wide: true},
+		"xlsx":       &XLSX{},
+		"xml":        &XML{},
+		"yaml":       &YAML{},
 	})
 
 	// Default is the default renderer that is used by package level Render,
@@ -49,6 +69,47 @@ var (
 	Default = Base.NewWith("json", "text", "yaml")
 )
 
+// globalsMu guards the Base and Default variables.
+var globalsMu sync.RWMutex
+
+// SetDefault replaces the Default renderer used by package level functions.
+// Unlike assigning to Default directly, it is safe to call concurrently with
+// package level functions. The given Renderer must not be nil, and should not
+// be modified after it is set.
+func SetDefault(r *Renderer) {
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+
+	Default = r
+}
+
+// SetBase replaces the Base renderer used by package level functions like
+// NewWith and Configure. Unlike assigning to Base directly, it is safe to call
+// concurrently with package level functions. The given Renderer must not be
+// nil, and should not be modified after it is set.
+func SetBase(r *Renderer) {
+	globalsMu.Lock()
+	defer globalsMu.Unlock()
+
+	Base = r
+}
+
+// defaultRenderer returns the current Default renderer.
+func defaultRenderer() *Renderer {
+	globalsMu.RLock()
+	defer globalsMu.RUnlock()
+
+	return Default
+}
+
+// baseRenderer returns the current Base renderer.
+func baseRenderer() *Renderer {
+	globalsMu.RLock()
+	defer globalsMu.RUnlock()
+
+	return Base
+}
+
 // Render renders the given value to the given writer using the given format. If
 // pretty is true, the value will be rendered "pretty" if the target format
 // supports it, otherwise it will be rendered in a compact way.
@@ -59,24 +120,125 @@ var (
 // If you need to support a custom set of formats, use the New function to
 // create a new Renderer with the formats you need. If you need new custom
 // renderers, manually create a new Renderer.
-func Render(w io.Writer, format string, pretty bool, v any) error {
-	return Default.Render(w, format, pretty, v)
+func Render(
+	w io.Writer,
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) error {
+	return defaultRenderer().Render(w, format, pretty, v, opts...)
 }
 
 // Compact is a convenience function that calls the Default renderer's Compact
 // method. It is the same as calling Render with pretty set to false.
-func Compact(w io.Writer, format string, v any) error {
-	return Default.Compact(w, format, v)
+func Compact(w io.Writer, format string, v any, opts ...Option) error {
+	return defaultRenderer().Compact(w, format, v, opts...)
 }
 
 // Pretty is a convenience function that calls the Default renderer's Pretty
 // method. It is the same as calling Render with pretty set to true.
-func Pretty(w io.Writer, format string, v any) error {
-	return Default.Pretty(w, format, v)
+func Pretty(w io.Writer, format string, v any, opts ...Option) error {
+	return defaultRenderer().Pretty(w, format, v, opts...)
+}
+
+// RenderStream is a convenience function that calls the Default renderer's
+// RenderStream method.
+func RenderStream(
+	w io.Writer,
+	format string,
+	seq func(yield func(any) bool),
+	opts ...Option,
+) error {
+	return defaultRenderer().RenderStream(w, format, seq, opts...)
+}
+
+// RenderCount is a convenience function that calls the Default renderer's
+// RenderCount method, returning the number of bytes written to w.
+func RenderCount(
+	w io.Writer,
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) (int64, error) {
+	return defaultRenderer().RenderCount(w, format, pretty, v, opts...)
+}
+
+// Bytes is a convenience function that calls the Default renderer's Bytes
+// method, returning the rendered output as a byte slice.
+func Bytes(format string, pretty bool, v any, opts ...Option) ([]byte, error) {
+	return defaultRenderer().Bytes(format, pretty, v, opts...)
+}
+
+// String is a convenience function that calls the Default renderer's String
+// method, returning the rendered output as a string.
+func String(format string, pretty bool, v any, opts ...Option) (string, error) {
+	return defaultRenderer().String(format, pretty, v, opts...)
+}
+
+// Supports returns true if the given format is supported by the Default
+// renderer.
+func Supports(format string) bool {
+	return defaultRenderer().Supports(format)
+}
+
+// FormatFromPath is a convenience function that calls the Default renderer's
+// FormatFromPath method.
+func FormatFromPath(path string) (string, bool) {
+	return defaultRenderer().FormatFromPath(path)
+}
+
+// RenderAll is a convenience function that calls the Default renderer's
+// RenderAll method.
+func RenderAll(v any, formats ...string) (map[string][]byte, error) {
+	return defaultRenderer().RenderAll(v, formats...)
+}
+
+// Formats returns all formats supported by the Default renderer. See
+// Renderer.Formats for details.
+func Formats() []Format {
+	return defaultRenderer().Formats()
 }
 
 // NewWith creates a new Renderer with the given formats. Only formats on the
 // BaseRender will be supported.
 func NewWith(formats ...string) *Renderer {
-	return Base.NewWith(formats...)
+	return baseRenderer().NewWith(formats...)
+}
+
+// Without creates a new Renderer with all formats of the Base renderer, except
+// the given formats and their aliases.
+func Without(formats ...string) *Renderer {
+	return baseRenderer().Without(formats...)
+}
+
+// Configure calls fn once for each Handler of type H in the Base renderer,
+// allowing the configuration of built-in handlers to be tuned without building
+// a custom set of handlers. As the Default renderer, and renderers created with
+// NewWith, share Handler instances with Base, changes apply to them too.
+//
+// Configure is not safe for concurrent use with rendering, and should be called
+// before first use, typically from an init function or early in main:
+//
+//	render.Configure(func(h *render.JSON) { h.Indent = "\t" })
+//	render.Configure(func(h *render.YAML) { h.Indent = 4 })
+func Configure[H Handler](fn func(h H)) {
+	seen := map[any]struct{}{}
+
+	for _, handler := range baseRenderer().Handlers {
+		h, ok := handler.(H)
+		if !ok {
+			continue
+		}
+
+		if reflect.TypeOf(h).Comparable() {
+			if _, ok := seen[h]; ok {
+				continue
+			}
+			seen[h] = struct{}{}
+		}
+
+		fn(h)
+	}
 }
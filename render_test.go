@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -92,6 +93,36 @@ func (mph *mockPrettyHandler) Formats() []string {
 	return mph.formats
 }
 
+type mockPanicHandler struct {
+	value any
+}
+
+var (
+	_ Handler        = (*mockPanicHandler)(nil)
+	_ StreamHandler  = (*mockPanicHandler)(nil)
+	_ EncoderHandler = (*mockPanicHandler)(nil)
+)
+
+func (mph *mockPanicHandler) Render(io.Writer, any) error {
+	panic(mph.value)
+}
+
+func (mph *mockPanicHandler) RenderStream(
+	io.Writer,
+	bool,
+	func(yield func(any) bool),
+) error {
+	panic(mph.value)
+}
+
+func (mph *mockPanicHandler) NewEncoder(io.Writer, bool) ValueEncoder {
+	return mph
+}
+
+func (mph *mockPanicHandler) Encode(any) error {
+	panic(mph.value)
+}
+
 type mockFormatsHandler struct {
 	output  string
 	formats []string
@@ -117,6 +148,17 @@ func (mph *mockFormatsHandler) Formats() []string {
 	return mph.formats
 }
 
+type mockDescribedHandler struct {
+	mockFormatsHandler
+	description string
+}
+
+var _ DescribedHandler = (*mockDescribedHandler)(nil)
+
+func (mdh *mockDescribedHandler) Description() string {
+	return mdh.description
+}
+
 type renderFormatTestCase struct {
 	name        string
 	writeErr    error
@@ -137,13 +179,17 @@ var binaryFormattestCases = []renderFormatTestCase{
 		name:    "with binary marshaler",
 		formats: []string{"binary", "bin"},
 		value:   &mockBinaryMarshaler{data: []byte("test string")},
-		want:    "test string",
+		wantPretty: "00000000  74 65 73 74 20 73 74 72  69 6e 67" +
+			"                 |test string|\n",
+		wantCompact: "test string",
 	},
 	{
 		name:    "capitalized format",
 		formats: []string{"BINARY", "BIN"},
 		value:   &mockBinaryMarshaler{data: []byte("test string")},
-		want:    "test string",
+		wantPretty: "00000000  74 65 73 74 20 73 74 72  69 6e 67" +
+			"                 |test string|\n",
+		wantCompact: "test string",
 	},
 	{
 		name:      "without binary marshaler",
@@ -173,7 +219,7 @@ var binaryFormattestCases = []renderFormatTestCase{
 	{
 		name:      "with invalid type",
 		formats:   []string{"binary", "bin"},
-		value:     make(chan int),
+		value:     make(chan<- int),
 		wantErr:   "render: unsupported format: {{format}}",
 		wantErrIs: []error{Err, ErrUnsupportedFormat},
 	},
@@ -216,11 +262,36 @@ var jsonFormatTestCases = []renderFormatTestCase{
 		wantErr:   "render: failed: write error!!1",
 		wantErrIs: []error{Err, ErrFailed},
 	},
+	{
+		name:    "with *sync.Map",
+		formats: []string{"json"},
+		valueFunc: func() any {
+			return newSyncMap(map[any]any{"age": 30})
+		},
+		wantPretty:  "{\n  \"age\": 30\n}\n",
+		wantCompact: "{\"age\":30}\n",
+	},
+	{
+		name:        "with map[any]any",
+		formats:     []string{"json"},
+		value:       map[any]any{"age": 30, 1: "one"},
+		wantPretty:  "{\n  \"1\": \"one\",\n  \"age\": 30\n}\n",
+		wantCompact: "{\"1\":\"one\",\"age\":30}\n",
+	},
+	{
+		name:    "with *list.List",
+		formats: []string{"json"},
+		valueFunc: func() any {
+			return newList("foo", 30)
+		},
+		wantPretty:  "[\n  \"foo\",\n  30\n]\n",
+		wantCompact: "[\"foo\",30]\n",
+	},
 	{
 		name:      "with invalid type",
 		formats:   []string{"json"},
-		value:     make(chan int),
-		wantErr:   "render: failed: json: unsupported type: chan int",
+		value:     make(chan<- int),
+		wantErr:   "render: failed: json: unsupported type: chan<- int",
 		wantErrIs: []error{Err, ErrFailed},
 	},
 }
@@ -460,8 +531,8 @@ var xmlFormatTestCases = []renderFormatTestCase{
 	{
 		name:      "xml format with invalid value",
 		formats:   []string{"xml"},
-		value:     make(chan int),
-		wantErr:   "render: failed: xml: unsupported type: chan int",
+		value:     make(chan<- int),
+		wantErr:   "render: failed: xml: unsupported type: chan<- int",
 		wantErrIs: []error{Err, ErrFailed},
 	},
 }
@@ -515,8 +586,9 @@ var yamlFormatTestCases = []renderFormatTestCase{
 	{
 		name:      "yaml format with invalid type",
 		formats:   []string{"yaml", "yml"},
-		value:     make(chan int),
-		wantPanic: "cannot marshal type: chan int",
+		value:     make(chan<- int),
+		wantErr:   "render: failed: cannot marshal type: chan<- int",
+		wantErrIs: []error{Err, ErrFailed},
 	},
 }
 
@@ -704,6 +776,120 @@ func TestCompact(t *testing.T) {
 	}
 }
 
+func TestString(t *testing.T) {
+	tests := []renderFormatTestCase{}
+	tests = append(tests, jsonFormatTestCases...)
+	tests = append(tests, textFormatTestCases...)
+	tests = append(tests, yamlFormatTestCases...)
+
+	for _, tt := range tests {
+		if tt.writeErr != nil {
+			continue
+		}
+
+		for _, pretty := range []bool{false, true} {
+			for _, format := range tt.formats {
+				name := format + " format " + tt.name
+				if pretty {
+					name = "pretty " + name
+				}
+
+				t.Run(name, func(t *testing.T) {
+					value := func() any {
+						if tt.valueFunc != nil {
+							return tt.valueFunc()
+						}
+
+						return tt.value
+					}
+
+					var got string
+					var gotBytes []byte
+					var err, bytesErr error
+					var panicRes any
+					func() {
+						defer func() {
+							if r := recover(); r != nil {
+								panicRes = r
+							}
+						}()
+						got, err = String(format, pretty, value())
+						gotBytes, bytesErr = Bytes(format, pretty, value())
+					}()
+
+					want := tt.want
+					if pretty && tt.wantPretty != "" {
+						want = tt.wantPretty
+					} else if tt.wantCompact != "" {
+						want = tt.wantCompact
+					}
+
+					if tt.wantPanic != "" {
+						assert.Equal(t, tt.wantPanic, panicRes)
+					}
+
+					if tt.wantErr != "" {
+						wantErr := strings.ReplaceAll(
+							tt.wantErr, "{{format}}", format,
+						)
+						assert.EqualError(t, err, wantErr)
+						assert.EqualError(t, bytesErr, wantErr)
+					}
+					for _, e := range tt.wantErrIs {
+						assert.ErrorIs(t, err, e)
+						assert.ErrorIs(t, bytesErr, e)
+					}
+
+					if tt.wantPanic == "" &&
+						tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+						assert.NoError(t, err)
+						assert.NoError(t, bytesErr)
+						assert.Equal(t, want, got)
+						assert.Equal(t, want, string(gotBytes))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRenderCount(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := RenderCount(&buf, "json", false, map[string]int{"a": 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	assert.Equal(t, "{\"a\":1}\n", buf.String())
+}
+
+func TestFormatFromPath(t *testing.T) {
+	got, ok := FormatFromPath("report.yml")
+	assert.True(t, ok)
+	assert.Equal(t, "yml", got)
+
+	got, ok = FormatFromPath("report.xml")
+	assert.False(t, ok)
+	assert.Equal(t, "", got)
+}
+
+func TestRenderAll(t *testing.T) {
+	got, err := RenderAll([]int{1}, "json", "yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"json": []byte("[1]\n"),
+		"yaml": []byte("- 1\n"),
+	}, got)
+}
+
+func TestSupports(t *testing.T) {
+	assert.True(t, Supports("json"))
+	assert.True(t, Supports("YAML"))
+	assert.True(t, Supports("txt"))
+	assert.False(t, Supports("xml"))
+}
+
 func TestNewWith(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -786,3 +972,96 @@ func TestNewWith(t *testing.T) {
 		})
 	}
 }
+
+func TestWithout(t *testing.T) {
+	r := Without("json", "yaml")
+
+	assert.False(t, r.Supports("json"))
+	assert.False(t, r.Supports("yml"))
+	assert.True(t, r.Supports("xml"))
+	assert.True(t, Base.Supports("json"))
+}
+
+func TestConfigure(t *testing.T) {
+	origBase := Base
+	origDefault := Default
+	t.Cleanup(func() {
+		Base = origBase
+		Default = origDefault
+	})
+
+	Base = New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+	})
+	Default = Base.NewWith("json", "yaml")
+
+	calls := 0
+	Configure(func(h *JSON) {
+		calls++
+		h.Indent = "\t"
+	})
+	Configure(func(h *YAML) {
+		h.Indent = 4
+	})
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, &JSON{Indent: "\t"}, Base.Handlers["json"])
+	assert.Equal(t, &YAML{Indent: 4}, Base.Handlers["yaml"])
+	assert.Equal(t, &YAML{Indent: 4}, Base.Handlers["yml"])
+
+	var buf bytes.Buffer
+	err := Pretty(&buf, "json", map[string]int{"age": 30})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n\t\"age\": 30\n}\n", buf.String())
+}
+
+func TestSetDefault(t *testing.T) {
+	orig := Default
+	t.Cleanup(func() { SetDefault(orig) })
+
+	r := New(map[string]Handler{"mock": &mockHandler{output: "mock output"}})
+	SetDefault(r)
+
+	assert.Same(t, r, Default)
+
+	var buf bytes.Buffer
+	err := Render(&buf, "mock", false, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "mock output", buf.String())
+
+	err = Render(&buf, "json", false, "foo")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestSetDefault_concurrent(t *testing.T) {
+	orig := Default
+	t.Cleanup(func() { SetDefault(orig) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefault(Base.NewWith("json"))
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := String("json", false, 1)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetBase(t *testing.T) {
+	orig := Base
+	t.Cleanup(func() { SetBase(orig) })
+
+	r := New(map[string]Handler{"mock": &mockHandler{output: "mock output"}})
+	SetBase(r)
+
+	assert.Same(t, r, Base)
+	assert.True(t, NewWith("mock").Supports("mock"))
+	assert.False(t, NewWith("json").Supports("json"))
+}
@@ -1,9 +1,12 @@
 package render
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"reflect"
 	"strings"
 )
 
@@ -18,6 +21,69 @@ type Renderer struct {
 	// Handlers is a map of format names to Handler. When Render is called,
 	// the format is used to look up the Handler to use.
 	Handlers map[string]Handler
+
+	// DeprecatedFormats is a map of deprecated format names to the format
+	// which replaces them, lowercased unless CaseSensitive is set. Use
+	// Deprecate to add entries.
+	//
+	// When a deprecated format is rendered and it has no Handler of its own,
+	// the Handler of the replacement format is used instead.
+	DeprecatedFormats map[string]string
+
+	// OnDeprecated is an optional callback which is called whenever a
+	// deprecated format is rendered. It is intended for printing a warning to
+	// users, asking them to use the replacement format instead.
+	OnDeprecated func(format, replacement string)
+
+	// Linters is a list of Linter functions which inspect rendered output
+	// before it is written. When one or more linters are set, output is
+	// rendered to a buffer first, and only written to the io.Writer if all
+	// linters accept it.
+	Linters []Linter
+
+	// PostProcessors is a list of PostProcessor functions which transform
+	// rendered output before it is written. When one or more post-processors
+	// are set, output is rendered to a buffer first. Post-processors run in
+	// order, before any Linters.
+	PostProcessors []PostProcessor
+
+	// Header is an optional comment header written at the top of rendered
+	// output, for formats with a Handler that implements CommentHandler.
+	Header *Header
+
+	// Buffered renders output to a buffer first, and only writes it to the
+	// io.Writer if rendering succeeds. This ensures no partial output is
+	// written when a value fails to render part way through.
+	Buffered bool
+
+	// RecoverPanics recovers panics in Handlers, returning them as ErrFailed
+	// errors which include the format and the panic value. This prevents
+	// misbehaving Handlers from crashing the program.
+	RecoverPanics bool
+
+	// Redactor optionally replaces sensitive values, like passwords and
+	// tokens, before they are rendered in any format.
+	Redactor *Redactor
+
+	// NoAliases disables registering Handlers under the additional formats
+	// returned by the FormatsHandler interface when they are added, so only
+	// the exact formats given to Add, and aliases given to AddAlias, are
+	// supported. It must be set before Handlers are added, so New cannot be
+	// used to create a Renderer with it enabled.
+	NoAliases bool
+
+	// CaseSensitive disables lowercasing of format strings, both when
+	// Handlers are added, and when formats are looked up. It must be set
+	// before Handlers are added, so New cannot be used to create a Renderer
+	// with it enabled.
+	CaseSensitive bool
+
+	// types holds Handlers registered with RegisterType, keyed by type.
+	types map[reflect.Type]Handler
+
+	// views holds views registered with RegisterView, keyed by type and then
+	// by lowercased view name.
+	views map[reflect.Type]map[string]*View
 }
 
 // New returns a new Renderer that delegates rendering to the specified
@@ -40,23 +106,201 @@ func New(handlers map[string]Handler) *Renderer {
 // by Formats().
 func (r *Renderer) Add(format string, handler Handler) {
 	if format != "" {
-		r.Handlers[strings.ToLower(format)] = handler
+		r.Handlers[r.key(format)] = handler
 	}
 
-	if x, ok := handler.(FormatsHandler); ok {
+	if x, ok := handler.(FormatsHandler); ok && !r.NoAliases {
 		for _, f := range x.Formats() {
 			if f != "" && f != format {
-				r.Handlers[strings.ToLower(f)] = handler
+				r.Handlers[r.key(f)] = handler
 			}
 		}
 	}
 }
 
+// key returns the key used for format in the Handlers and DeprecatedFormats
+// maps, which is the lowercased format unless CaseSensitive is set.
+func (r *Renderer) key(format string) string {
+	if r.CaseSensitive {
+		return format
+	}
+
+	return strings.ToLower(format)
+}
+
+// AddAlias adds alias as an additional format string for the Handler of the
+// given format, like "j" for "json". The alias replaces any Handler already
+// registered under it.
+//
+// If the format is not supported, a ErrUnsupportedFormat error is returned.
+func (r *Renderer) AddAlias(alias, format string) error {
+	if alias == "" {
+		return fmt.Errorf("%w: empty alias for format: %s", Err, format)
+	}
+
+	handler, err := r.lookup(format)
+	if err != nil {
+		return err
+	}
+
+	r.Handlers[r.key(alias)] = handler
+
+	return nil
+}
+
+// Remove removes the Handler for the given format from the Renderer, along with
+// all other formats which use the same Handler, like aliases added based on the
+// FormatsHandler interface.
+func (r *Renderer) Remove(format string) {
+	f := r.key(format)
+
+	handler, ok := r.Handlers[f]
+	if !ok {
+		return
+	}
+
+	for k, h := range r.Handlers {
+		if k == f || sameHandler(h, handler) {
+			delete(r.Handlers, k)
+		}
+	}
+}
+
+// sameHandler returns true if a and b are the same Handler. Handlers of
+// non-comparable types are never considered the same.
+func sameHandler(a, b Handler) bool {
+	t := reflect.TypeOf(a)
+	if t != reflect.TypeOf(b) || !t.Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+// Deprecate marks format as deprecated in favor of replacement. Deprecated
+// formats can still be rendered, but cause the OnDeprecated callback to be
+// called. If format has no Handler, the Handler for replacement is used.
+func (r *Renderer) Deprecate(format, replacement string) {
+	if r.DeprecatedFormats == nil {
+		r.DeprecatedFormats = map[string]string{}
+	}
+
+	r.DeprecatedFormats[r.key(format)] = replacement
+}
+
+// handler returns the Handler for the given format, resolving deprecated
+// formats to their replacement if needed.
+func (r *Renderer) handler(format string) (Handler, error) {
+	plain := r.plainFormat(format)
+	f := r.key(plain)
+	if replacement, ok := r.DeprecatedFormats[f]; ok && r.OnDeprecated != nil {
+		r.OnDeprecated(plain, replacement)
+	}
+
+	return r.lookup(format)
+}
+
+// lookup returns the Handler for the given format like handler, but without
+// calling the OnDeprecated callback for deprecated formats. Parameters, like
+// ";indent=4", and modifiers, like "+pretty", are ignored.
+func (r *Renderer) lookup(format string) (Handler, error) {
+	handler, ok := r.lookupFormat(r.plainFormat(format))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+
+	return handler, nil
+}
+
+// lookupFormat returns the Handler for the given format, which must not have
+// any parameters or modifiers, and reports if there is one.
+func (r *Renderer) lookupFormat(format string) (Handler, bool) {
+	f := r.key(format)
+
+	if replacement, ok := r.DeprecatedFormats[f]; ok {
+		if _, ok := r.Handlers[f]; !ok {
+			f = r.key(replacement)
+		}
+	}
+
+	handler, ok := r.Handlers[f]
+
+	return handler, ok
+}
+
+// plainFormat returns format without any parameters or modifiers.
+func (r *Renderer) plainFormat(format string) string {
+	format, _ = formatParams(format)
+	format, _ = r.modifiers(format, false)
+
+	return format
+}
+
+// Supports returns true if the given format can be rendered by the Renderer.
+// Format names are case-insensitive unless CaseSensitive is set, and deprecated
+// formats are supported if they or their replacement have a Handler.
+// Parameters and modifiers, like "json;indent=4" and "json+pretty", are
+// accepted as with Render. The OnDeprecated callback is not called.
+func (r *Renderer) Supports(format string) bool {
+	_, err := r.lookup(format)
+
+	return err == nil
+}
+
+// FormatFromPath returns the format matching the file extension of path, and
+// true if the Renderer supports it. For example "out/config.YML" returns "yml"
+// if the "yml" format is supported.
+func (r *Renderer) FormatFromPath(path string) (string, bool) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if ext == "" || !r.Supports(ext) {
+		return "", false
+	}
+
+	return ext, true
+}
+
 // Render renders a value to the given io.Writer using the specified format.
 //
 // If pretty is true, it will attempt to render the value with pretty
 // formatting if the underlying Handler supports pretty formatting.
 //
+// Values of type *sync.Map, *list.List, and map[any]any are converted to plain
+// maps and slices before being passed to the Handler, as most formats cannot
+// render them directly.
+//
+// If a Redactor is set, sensitive values are redacted before the value is
+// passed to the Handler.
+//
+// If a Header is set and the Handler implements CommentHandler, the header is
+// written as comments before the rendered value.
+//
+// If Buffered is true, the output is rendered to a buffer first, and only
+// written to w if rendering succeeds.
+//
+// If any PostProcessors are set, the output is rendered to a buffer first, and
+// transformed by each post-processor in order before it is written.
+//
+// If any Linters are set, the output is rendered to a buffer first, and only
+// written to w if all linters accept it. Otherwise a ErrLint error is returned.
+//
+// Options given override the configuration of the Handler for this call only,
+// if it implements OptionsHandler.
+//
+// If a Handler is registered with RegisterType for the type of v, it is used
+// instead of the Handler of the format.
+//
+// Channels which can be received from are rendered with RenderStream, with
+// each element rendered as it is received, until the channel is closed.
+//
+// The format may have "+pretty" or "+compact" modifiers appended, like
+// "json+pretty", which override the pretty argument. Formats registered with
+// a "+" in their name, like "hal+json", take precedence over modifiers.
+//
+// The format may also have parameters appended, separated by semicolons, like
+// "yaml;indent=4" or "json;prefix=//;indent=\t". The "pretty", "prefix", and
+// "indent" parameters are applied like WithPretty, WithPrefix, and WithIndent,
+// and all others like WithParam. They are applied after any given options.
+//
 // If the format is not supported or the value cannot be rendered to the format,
 // a ErrUnsupportedFormat error is returned.
 func (r *Renderer) Render(
@@ -64,20 +308,203 @@ func (r *Renderer) Render(
 	format string,
 	pretty bool,
 	v any,
+	opts ...Option,
 ) error {
-	handler, ok := r.Handlers[strings.ToLower(format)]
-	if !ok {
-		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	if seq, ok := chanSeq(v); ok {
+		format, params := formatParams(format)
+		opts = append(opts[:len(opts):len(opts)], params...)
+		if format, pretty = r.modifiers(format, pretty); pretty {
+			opts = append([]Option{WithPretty()}, opts...)
+		}
+
+		return r.RenderStream(w, format, seq, opts...)
+	}
+
+	format, pretty, o := r.configure(format, pretty, opts)
+
+	handler, err := r.handler(format)
+	if err != nil {
+		return err
+	}
+
+	if h := r.typeHandler(v); h != nil {
+		handler = h
+	}
+	handler = withOptions(handler, o)
+
+	v, err = o.transform(r.prepare(v))
+	if err != nil {
+		return err
+	}
+
+	return r.output(w, handler, format, pretty, v)
+}
+
+// output renders the prepared value v to w with handler, writing the Header
+// first if set, and applying PostProcessors and Linters.
+func (r *Renderer) output(
+	w io.Writer,
+	handler Handler,
+	format string,
+	pretty bool,
+	v any,
+) error {
+	var header string
+	if x, ok := handler.(CommentHandler); ok && r.Header != nil {
+		header = r.Header.comment(x.CommentPrefix())
+	}
+
+	if !r.Buffered && len(r.Linters) == 0 && len(r.PostProcessors) == 0 &&
+		header == "" {
+		return r.render(w, handler, format, pretty, v)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	err := r.render(&buf, handler, format, pretty, v)
+	if err != nil {
+		return err
+	}
+
+	output := buf.Bytes()
+	for _, process := range r.PostProcessors {
+		if output, err = process(format, output); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
 	}
 
+	for _, lint := range r.Linters {
+		if err = lint(format, output); err != nil {
+			return fmt.Errorf("%w: %w", ErrLint, err)
+		}
+	}
+
+	_, err = w.Write(output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// configure splits the parameters and modifiers off format, returning the
+// plain format, whether to render pretty, and the Options for opts and the
+// parameters. It is shared by all methods which render values, so they accept
+// the same formats.
+func (r *Renderer) configure(
+	format string,
+	pretty bool,
+	opts []Option,
+) (string, bool, *Options) {
+	format, params := formatParams(format)
+	if len(params) > 0 {
+		opts = append(opts[:len(opts):len(opts)], params...)
+	}
+	format, pretty = r.modifiers(format, pretty)
+
+	o := newOptions(opts)
+
+	return format, pretty || o.Pretty, o
+}
+
+// withOptions returns handler configured with o, if it implements
+// OptionsHandler.
+func withOptions(handler Handler, o *Options) Handler {
+	if x, ok := handler.(OptionsHandler); ok {
+		return x.WithOptions(o)
+	}
+
+	return handler
+}
+
+// prepare normalizes v, and redacts it if a Redactor is set, before it is
+// passed to a Handler.
+func (r *Renderer) prepare(v any) any {
+	v = normalize(v)
+	if r.Redactor != nil {
+		v = r.Redactor.redact(v)
+	}
+
+	return v
+}
+
+// modifiers strips "+pretty" and "+compact" modifiers from the end of format,
+// returning the plain format, and pretty as set by the last modifier. The
+// format is returned unchanged if the Renderer supports it as is.
+func (r *Renderer) modifiers(format string, pretty bool) (string, bool) {
+	if !strings.Contains(format, "+") {
+		return format, pretty
+	}
+	if _, ok := r.lookupFormat(format); ok {
+		return format, pretty
+	}
+
+	f := format
+	last := ""
+	for {
+		i := strings.LastIndex(f, "+")
+		if i < 0 {
+			break
+		}
+
+		mod := strings.ToLower(f[i+1:])
+		if mod != "pretty" && mod != "compact" {
+			break
+		}
+
+		if last == "" {
+			last = mod
+		}
+		f = f[:i]
+	}
+
+	if last == "" {
+		return format, pretty
+	}
+
+	return f, last == "pretty"
+}
+
+// render renders v to w with the given handler, ensuring returned errors are
+// wrapped with either ErrUnsupportedFormat or ErrFailed.
+func (r *Renderer) render(
+	w io.Writer,
+	handler Handler,
+	format string,
+	pretty bool,
+	v any,
+) (err error) {
+	defer r.recoverPanic(format, &err)
+
 	prettyHandler, ok := handler.(PrettyHandler)
-	var err error
 	if pretty && ok {
 		err = prettyHandler.RenderPretty(w, v)
 	} else {
 		err = handler.Render(w, v)
 	}
 
+	return renderError(format, err)
+}
+
+// recoverPanic recovers a panic if RecoverPanics is enabled, and sets err to a
+// ErrFailed error describing it. It must be called with defer.
+func (r *Renderer) recoverPanic(format string, err *error) {
+	if !r.RecoverPanics {
+		return
+	}
+
+	if p := recover(); p != nil {
+		if perr, ok := p.(error); ok {
+			*err = fmt.Errorf("%w: %s: panic: %w", ErrFailed, format, perr)
+		} else {
+			*err = fmt.Errorf("%w: %s: panic: %v", ErrFailed, format, p)
+		}
+	}
+}
+
+// renderError ensures err returned by a Handler is wrapped with either
+// ErrUnsupportedFormat or ErrFailed.
+func renderError(format string, err error) error {
 	if err != nil {
 		if errors.Is(err, ErrCannotRender) {
 			return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
@@ -95,13 +522,103 @@ func (r *Renderer) Render(
 }
 
 // Compact is a convenience method that calls Render with pretty set to false.
-func (r *Renderer) Compact(w io.Writer, format string, v any) error {
-	return r.Render(w, format, false, v)
+func (r *Renderer) Compact(
+	w io.Writer,
+	format string,
+	v any,
+	opts ...Option,
+) error {
+	return r.Render(w, format, false, v, opts...)
 }
 
 // Pretty is a convenience method that calls Render with pretty set to true.
-func (r *Renderer) Pretty(w io.Writer, format string, v any) error {
-	return r.Render(w, format, true, v)
+func (r *Renderer) Pretty(
+	w io.Writer,
+	format string,
+	v any,
+	opts ...Option,
+) error {
+	return r.Render(w, format, true, v, opts...)
+}
+
+// RenderCount is like Render, but also returns the number of bytes written to
+// w, including any bytes written before an error occurred.
+func (r *Renderer) RenderCount(
+	w io.Writer,
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) (int64, error) {
+	cw := &countWriter{w: w}
+	err := r.Render(cw, format, pretty, v, opts...)
+
+	return cw.n, err
+}
+
+// Bytes is a convenience method that calls Render with a buffer, and returns
+// the rendered output as a byte slice.
+func (r *Renderer) Bytes(
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	err := r.Render(&buf, format, pretty, v, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// String is a convenience method that calls Render with a buffer, and returns
+// the rendered output as a string.
+func (r *Renderer) String(
+	format string,
+	pretty bool,
+	v any,
+	opts ...Option,
+) (string, error) {
+	b, err := r.Bytes(format, pretty, v, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// RenderAll renders v to each of the given formats, returning the rendered
+// output of each keyed by format. If rendering to any of the formats fails, the
+// error is returned.
+//
+// Channels are received from until closed before rendering, so all formats
+// render the same elements.
+func (r *Renderer) RenderAll(
+	v any,
+	formats ...string,
+) (map[string][]byte, error) {
+	if seq, ok := chanSeq(v); ok {
+		values := []any{}
+		seq(func(v any) bool {
+			values = append(values, v)
+
+			return true
+		})
+		v = values
+	}
+
+	result := make(map[string][]byte, len(formats))
+	for _, format := range formats {
+		b, err := r.Bytes(format, false, v)
+		if err != nil {
+			return nil, err
+		}
+		result[format] = b
+	}
+
+	return result, nil
 }
 
 // NewWith creates a new Renderer with the formats given, if they have handlers
@@ -111,10 +628,62 @@ func (r *Renderer) NewWith(formats ...string) *Renderer {
 	handlers := make(map[string]Handler, len(formats))
 
 	for _, format := range formats {
-		if r, ok := r.Handlers[strings.ToLower(format)]; ok {
-			handlers[format] = r
+		if h, ok := r.Handlers[r.key(format)]; ok {
+			handlers[format] = h
+		}
+	}
+
+	nr := &Renderer{
+		Handlers:      make(map[string]Handler, len(handlers)),
+		NoAliases:     r.NoAliases,
+		CaseSensitive: r.CaseSensitive,
+	}
+	for format, handler := range handlers {
+		nr.Add(format, handler)
+	}
+	nr.OnDeprecated = r.OnDeprecated
+	nr.Linters = append([]Linter(nil), r.Linters...)
+	nr.PostProcessors = append([]PostProcessor(nil), r.PostProcessors...)
+	nr.Header = r.Header
+	nr.Buffered = r.Buffered
+	nr.RecoverPanics = r.RecoverPanics
+	nr.Redactor = r.Redactor
+
+	for format, replacement := range r.DeprecatedFormats {
+		nr.Deprecate(format, replacement)
+	}
+
+	for t, h := range r.types {
+		if nr.types == nil {
+			nr.types = make(map[reflect.Type]Handler, len(r.types))
+		}
+		nr.types[t] = h
+	}
+
+	for t, views := range r.views {
+		if nr.views == nil {
+			nr.views = make(map[reflect.Type]map[string]*View, len(r.views))
+		}
+		nr.views[t] = make(map[string]*View, len(views))
+		for name, view := range views {
+			nr.views[t][name] = view
 		}
 	}
 
-	return New(handlers)
+	return nr
+}
+
+// Without creates a new Renderer with all the formats of the current Renderer,
+// except the given formats and their aliases. It is the inverse of NewWith.
+func (r *Renderer) Without(formats ...string) *Renderer {
+	nr := r.NewWith()
+	for format, handler := range r.Handlers {
+		nr.Handlers[format] = handler
+	}
+
+	for _, format := range formats {
+		nr.Remove(format)
+	}
+
+	return nr
 }
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -157,6 +159,34 @@ func TestRenderer_Add(t *testing.T) {
 	}
 }
 
+func TestRenderer_AddAlias(t *testing.T) {
+	jsonHandler := &mockFormatsHandler{formats: []string{"json"}}
+	r := New(map[string]Handler{"json": jsonHandler})
+	r.Deprecate("js", "json")
+
+	err := r.AddAlias("J", "json")
+	require.NoError(t, err)
+	assert.Same(t, jsonHandler, r.Handlers["j"])
+
+	err = r.AddAlias("jj", "JS")
+	require.NoError(t, err)
+	assert.Same(t, jsonHandler, r.Handlers["jj"])
+
+	err = r.AddAlias("y", "yaml")
+	assert.EqualError(t, err, "render: unsupported format: yaml")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.NotContains(t, r.Handlers, "y")
+
+	err = r.AddAlias("", "json")
+	assert.EqualError(t, err, "render: empty alias for format: json")
+	assert.ErrorIs(t, err, Err)
+
+	formats := r.Formats()
+	require.Len(t, formats, 1)
+	assert.Equal(t, "json", formats[0].Name)
+	assert.Equal(t, []string{"j", "jj"}, formats[0].Aliases)
+}
+
 func TestRenderer_Render(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -228,6 +258,90 @@ func TestRenderer_Render(t *testing.T) {
 			value:  struct{}{},
 			want:   "plain output",
 		},
+		{
+			name: "pretty modifier",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock+pretty",
+			pretty: false,
+			value:  struct{}{},
+			want:   "pretty output",
+		},
+		{
+			name: "compact modifier",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock+compact",
+			pretty: true,
+			value:  struct{}{},
+			want:   "plain output",
+		},
+		{
+			name: "modifiers are case-insensitive",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "MOCK+Pretty",
+			pretty: false,
+			value:  struct{}{},
+			want:   "pretty output",
+		},
+		{
+			name: "last modifier wins",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock+pretty+compact",
+			pretty: true,
+			value:  struct{}{},
+			want:   "plain output",
+		},
+		{
+			name: "format with plus in name takes precedence",
+			handlers: map[string]Handler{
+				"mock":        &mockHandler{output: "mock output"},
+				"mock+pretty": &mockHandler{output: "plus output"},
+			},
+			format: "mock+pretty",
+			value:  struct{}{},
+			want:   "plus output",
+		},
+		{
+			name: "modifier on format with plus in name",
+			handlers: map[string]Handler{
+				"mock+json": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock+json+pretty",
+			value:  struct{}{},
+			want:   "pretty output",
+		},
+		{
+			name: "unknown modifier",
+			handlers: map[string]Handler{
+				"mock": &mockHandler{output: "mock output"},
+			},
+			format:    "mock+shiny",
+			value:     struct{}{},
+			wantErr:   "render: unsupported format: mock+shiny",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
 		{
 			name: "handler returns error",
 			handlers: map[string]Handler{
@@ -469,6 +583,88 @@ func TestRenderer_Pretty(t *testing.T) {
 	}
 }
 
+func TestRenderer_Bytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		handlers  map[string]Handler
+		format    string
+		pretty    bool
+		want      []byte
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "compact",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock",
+			want:   []byte("plain output"),
+		},
+		{
+			name: "pretty",
+			handlers: map[string]Handler{
+				"mock": &mockPrettyHandler{
+					output:       "plain output",
+					prettyOutput: "pretty output",
+				},
+			},
+			format: "mock",
+			pretty: true,
+			want:   []byte("pretty output"),
+		},
+		{
+			name: "handler returns error",
+			handlers: map[string]Handler{
+				"mock": &mockHandler{
+					output: "mock output",
+					err:    errors.New("mock error"),
+				},
+			},
+			format:    "mock",
+			wantErr:   "render: failed: mock error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "non-existing handler",
+			handlers:  map[string]Handler{},
+			format:    "unknown",
+			wantErr:   "render: unsupported format: unknown",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Renderer{Handlers: tt.handlers}
+
+			got, err := r.Bytes(tt.format, tt.pretty, struct{}{})
+			gotString, stringErr := r.String(tt.format, tt.pretty, struct{}{})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, stringErr, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+				assert.ErrorIs(t, stringErr, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.NoError(t, stringErr)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, string(tt.want), gotString)
+			} else {
+				assert.Nil(t, got)
+				assert.Empty(t, gotString)
+			}
+		})
+	}
+}
+
 func TestRenderer_RenderAllFormats(t *testing.T) {
 	tests := []renderFormatTestCase{}
 	tests = append(tests, binaryFormattestCases...)
@@ -661,3 +857,693 @@ func TestRenderer_PrettyAllFormats(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderer_Deprecate(t *testing.T) {
+	type call struct {
+		format      string
+		replacement string
+	}
+
+	tests := []struct {
+		name         string
+		handlers     map[string]Handler
+		deprecations map[string]string
+		format       string
+		want         string
+		wantCalls    []call
+		wantErr      string
+		wantErrIs    []error
+	}{
+		{
+			name: "deprecated format with own handler",
+			handlers: map[string]Handler{
+				"text": &mockHandler{output: "text output"},
+				"txt":  &mockHandler{output: "txt output"},
+			},
+			deprecations: map[string]string{"txt": "text"},
+			format:       "txt",
+			want:         "txt output",
+			wantCalls:    []call{{"txt", "text"}},
+		},
+		{
+			name: "deprecated format without own handler",
+			handlers: map[string]Handler{
+				"text": &mockHandler{output: "text output"},
+			},
+			deprecations: map[string]string{"TXT": "Text"},
+			format:       "Txt",
+			want:         "text output",
+			wantCalls:    []call{{"Txt", "Text"}},
+		},
+		{
+			name: "non-deprecated format",
+			handlers: map[string]Handler{
+				"text": &mockHandler{output: "text output"},
+			},
+			deprecations: map[string]string{"txt": "text"},
+			format:       "text",
+			want:         "text output",
+		},
+		{
+			name:         "replacement format without handler",
+			handlers:     map[string]Handler{},
+			deprecations: map[string]string{"txt": "text"},
+			format:       "txt",
+			wantCalls:    []call{{"txt", "text"}},
+			wantErr:      "render: unsupported format: txt",
+			wantErrIs:    []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []call
+			r := &Renderer{
+				Handlers: tt.handlers,
+				OnDeprecated: func(format, replacement string) {
+					calls = append(calls, call{format, replacement})
+				},
+			}
+			for format, replacement := range tt.deprecations {
+				r.Deprecate(format, replacement)
+			}
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, false, struct{}{})
+
+			assert.Equal(t, tt.wantCalls, calls)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderer_Deprecate_withoutCallback(t *testing.T) {
+	r := New(map[string]Handler{"text": &mockHandler{output: "text"}})
+	r.Deprecate("txt", "text")
+
+	var buf bytes.Buffer
+	err := r.Render(&buf, "txt", false, struct{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text", buf.String())
+	assert.Equal(t, map[string]string{"txt": "text"}, r.DeprecatedFormats)
+}
+
+func TestRenderer_NewWith_keepsDeprecations(t *testing.T) {
+	var got []string
+	r := New(map[string]Handler{"text": &mockHandler{output: "text"}})
+	r.Deprecate("txt", "text")
+	r.OnDeprecated = func(format, _ string) { got = append(got, format) }
+
+	nr := r.NewWith("text")
+	var buf bytes.Buffer
+	err := nr.Render(&buf, "txt", false, struct{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text", buf.String())
+	assert.Equal(t, []string{"txt"}, got)
+}
+
+func TestRenderer_Supports(t *testing.T) {
+	var called bool
+	r := New(map[string]Handler{
+		"yaml": &mockFormatsHandler{formats: []string{"yaml", "yml"}},
+		"text": &mockHandler{output: "text"},
+	})
+	r.Deprecate("txt", "text")
+	r.Deprecate("old", "missing")
+	r.OnDeprecated = func(_, _ string) { called = true }
+
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{format: "yaml", want: true},
+		{format: "YML", want: true},
+		{format: "Text", want: true},
+		{format: "txt", want: true},
+		{format: "old", want: false},
+		{format: "json", want: false},
+		{format: "yaml+pretty", want: true},
+		{format: "YML+Compact", want: true},
+		{format: "txt+pretty+compact", want: true},
+		{format: "yaml+foo", want: false},
+		{format: "json+pretty", want: false},
+		{format: "yaml;indent=4", want: true},
+		{format: "YML+pretty;indent=4;foo", want: true},
+		{format: "json;indent=4", want: false},
+		{format: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got := r.Supports(tt.format)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.False(t, called, "OnDeprecated must not be called")
+}
+
+func TestRenderer_Remove(t *testing.T) {
+	jsonHandler := &mockHandler{output: "json"}
+	yamlHandler := &mockFormatsHandler{formats: []string{"yaml", "yml"}}
+	textHandler := &mockHandler{output: "text"}
+
+	tests := []struct {
+		name   string
+		format string
+		want   []string
+	}{
+		{
+			name:   "format",
+			format: "json",
+			want:   []string{"yaml", "yml", "text", "txt"},
+		},
+		{
+			name:   "format with aliases",
+			format: "yaml",
+			want:   []string{"json", "text", "txt"},
+		},
+		{
+			name:   "alias",
+			format: "YML",
+			want:   []string{"json", "text", "txt"},
+		},
+		{
+			name:   "format with shared handler",
+			format: "txt",
+			want:   []string{"json", "yaml", "yml"},
+		},
+		{
+			name:   "unknown format",
+			format: "xml",
+			want:   []string{"json", "yaml", "yml", "text", "txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(map[string]Handler{
+				"json": jsonHandler,
+				"yaml": yamlHandler,
+				"text": textHandler,
+			})
+			r.Handlers["txt"] = textHandler
+
+			r.Remove(tt.format)
+
+			got := []string{}
+			for f := range r.Handlers {
+				got = append(got, f)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderer_Without(t *testing.T) {
+	var got []string
+	r := New(map[string]Handler{
+		"json": &mockHandler{output: "json"},
+		"yaml": &mockFormatsHandler{formats: []string{"yaml", "yml"}},
+		"text": &mockHandler{output: "text"},
+	})
+	r.Deprecate("txt", "text")
+	r.OnDeprecated = func(format, _ string) { got = append(got, format) }
+
+	nr := r.Without("YML", "json", "unknown")
+
+	formats := []string{}
+	for f := range nr.Handlers {
+		formats = append(formats, f)
+	}
+	assert.ElementsMatch(t, []string{"text"}, formats)
+	assert.Len(t, r.Handlers, 4, "original renderer must not be modified")
+
+	var buf bytes.Buffer
+	err := nr.Render(&buf, "txt", false, struct{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text", buf.String())
+	assert.Equal(t, []string{"txt"}, got)
+}
+
+func TestRenderer_Render_buffered(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffered bool
+		handler  Handler
+		value    any
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "buffered success",
+			buffered: true,
+			handler:  &mockHandler{output: "foo"},
+			want:     "foo",
+		},
+		{
+			name:     "buffered failure",
+			buffered: true,
+			handler: &mockHandler{
+				output: "partial",
+				err:    errors.New("mock error"),
+			},
+			want:    "",
+			wantErr: "render: failed: mock error",
+		},
+		{
+			name: "unbuffered failure",
+			handler: &mockHandler{
+				output: "partial",
+				err:    errors.New("mock error"),
+			},
+			want:    "partial",
+			wantErr: "render: failed: mock error",
+		},
+		{
+			name:     "buffered channel",
+			buffered: true,
+			handler:  &JSON{},
+			value: func() chan any {
+				ch := make(chan any, 2)
+				ch <- 1
+				ch <- make(chan<- int)
+				close(ch)
+
+				return ch
+			}(),
+			want:    "",
+			wantErr: "render: failed: json: unsupported type: chan<- int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(map[string]Handler{"mock": tt.handler})
+			r.Buffered = tt.buffered
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, "mock", false, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_NewWith_keepsBuffered(t *testing.T) {
+	r := New(map[string]Handler{"text": &mockHandler{output: "text"}})
+	r.Buffered = true
+
+	assert.True(t, r.NewWith("text").Buffered)
+	assert.True(t, r.Without("text").Buffered)
+}
+
+func TestRenderer_RecoverPanics(t *testing.T) {
+	seq := func(yield func(any) bool) { yield(1) }
+
+	tests := []struct {
+		name      string
+		value     any
+		render    func(r *Renderer) error
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:  "Render",
+			value: "boom",
+			render: func(r *Renderer) error {
+				return r.Render(io.Discard, "mock", false, 1)
+			},
+			wantErr:   "render: failed: mock: panic: boom",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "Render with error panic",
+			value: io.ErrUnexpectedEOF,
+			render: func(r *Renderer) error {
+				return r.Render(io.Discard, "mock", true, 1)
+			},
+			wantErr:   "render: failed: mock: panic: unexpected EOF",
+			wantErrIs: []error{Err, ErrFailed, io.ErrUnexpectedEOF},
+		},
+		{
+			name:  "RenderStream",
+			value: 42,
+			render: func(r *Renderer) error {
+				return r.RenderStream(io.Discard, "mock", seq)
+			},
+			wantErr:   "render: failed: mock: panic: 42",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "Encoder",
+			value: "boom",
+			render: func(r *Renderer) error {
+				return r.NewEncoder(io.Discard, "mock").Encode(1)
+			},
+			wantErr:   "render: failed: mock: panic: boom",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(map[string]Handler{
+				"mock": &mockPanicHandler{value: tt.value},
+			})
+
+			assert.PanicsWithValue(t, tt.value, func() {
+				_ = tt.render(r)
+			})
+
+			r.RecoverPanics = true
+			err := tt.render(r)
+
+			assert.EqualError(t, err, tt.wantErr)
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+		})
+	}
+}
+
+func TestRenderer_NewWith_keepsRecoverPanics(t *testing.T) {
+	r := New(map[string]Handler{"text": &mockHandler{output: "text"}})
+	r.RecoverPanics = true
+
+	assert.True(t, r.NewWith("text").RecoverPanics)
+}
+
+func TestRenderer_NoAliases(t *testing.T) {
+	h := &mockFormatsHandler{formats: []string{"hackle", "hack"}}
+	r := &Renderer{Handlers: map[string]Handler{}, NoAliases: true}
+
+	r.Add("hackle", h)
+	require.NoError(t, r.AddAlias("hk", "hackle"))
+
+	assert.True(t, r.Supports("hackle"))
+	assert.True(t, r.Supports("HACKLE"))
+	assert.True(t, r.Supports("hk"))
+	assert.False(t, r.Supports("hack"))
+
+	nr := r.NewWith("hackle")
+	assert.True(t, nr.NoAliases)
+	assert.Equal(t, map[string]Handler{"hackle": h}, nr.Handlers)
+}
+
+func TestRenderer_CaseSensitive(t *testing.T) {
+	h := &mockFormatsHandler{formats: []string{"Hackle", "hack"}}
+	r := &Renderer{Handlers: map[string]Handler{}, CaseSensitive: true}
+
+	r.Add("Hackle", h)
+	r.Deprecate("OLD", "Hackle")
+
+	assert.True(t, r.Supports("Hackle"))
+	assert.True(t, r.Supports("hack"))
+	assert.True(t, r.Supports("OLD"))
+	assert.False(t, r.Supports("hackle"))
+	assert.False(t, r.Supports("HACK"))
+	assert.False(t, r.Supports("old"))
+
+	formats := r.Formats()
+	require.Len(t, formats, 1)
+	assert.Equal(t, "Hackle", formats[0].Name)
+	assert.Equal(t, []string{"hack"}, formats[0].Aliases)
+
+	nr := r.NewWith("Hackle", "hackle")
+	assert.True(t, nr.CaseSensitive)
+	assert.True(t, nr.Supports("Hackle"))
+	assert.False(t, nr.Supports("hackle"))
+
+	r.Remove("hackle")
+	assert.True(t, r.Supports("Hackle"))
+	r.Remove("Hackle")
+	assert.False(t, r.Supports("hack"))
+}
+
+func TestRenderer_RenderCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   Handler
+		format    string
+		pretty    bool
+		writer    io.Writer
+		want      int64
+		wantOut   string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "compact",
+			handler: &mockPrettyHandler{
+				output:       "plain output",
+				prettyOutput: "pretty output!",
+			},
+			format:  "mock",
+			want:    12,
+			wantOut: "plain output",
+		},
+		{
+			name: "pretty",
+			handler: &mockPrettyHandler{
+				output:       "plain output",
+				prettyOutput: "pretty output!",
+			},
+			format:  "mock",
+			pretty:  true,
+			want:    14,
+			wantOut: "pretty output!",
+		},
+		{
+			name: "partial output before error",
+			handler: &mockHandler{
+				output: "partial",
+				err:    errors.New("mock error"),
+			},
+			format:    "mock",
+			want:      7,
+			wantOut:   "partial",
+			wantErr:   "render: failed: mock error",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "unsupported format",
+			handler:   &mockHandler{output: "output"},
+			format:    "unknown",
+			want:      0,
+			wantErr:   "render: unsupported format: unknown",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:    "short writes",
+			handler: &Text{},
+			format:  "mock",
+			writer:  &mockShortWriter{max: 2, err: io.ErrShortWrite},
+			want:    5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(map[string]Handler{"mock": tt.handler})
+			var buf bytes.Buffer
+			w := tt.writer
+			if w == nil {
+				w = &buf
+			}
+
+			got, err := r.RenderCount(w, tt.format, tt.pretty, "hello")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			assert.Equal(t, tt.want, got)
+			if tt.writer == nil {
+				assert.Equal(t, tt.wantOut, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderer_FormatFromPath(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &mockHandler{},
+		"yaml": &mockFormatsHandler{formats: []string{"yaml", "yml"}},
+	})
+	r.Deprecate("js", "json")
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "out.json", want: "json", wantOK: true},
+		{path: "/tmp/config.yaml", want: "yaml", wantOK: true},
+		{path: "dir.d/config.YML", want: "yml", wantOK: true},
+		{path: "legacy.js", want: "js", wantOK: true},
+		{path: "archive.tar.json", want: "json", wantOK: true},
+		{path: "data.xml", want: "", wantOK: false},
+		{path: "json", want: "", wantOK: false},
+		{path: "config.", want: "", wantOK: false},
+		{path: "", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := r.FormatFromPath(tt.path)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestRenderer_RenderAll(t *testing.T) {
+	r := NewWith("json", "yaml", "text")
+
+	tests := []struct {
+		name      string
+		formats   []string
+		value     func() any
+		want      map[string][]byte
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:    "multiple formats",
+			formats: []string{"json", "yml"},
+			value:   func() any { return map[string]int{"a": 1} },
+			want: map[string][]byte{
+				"json": []byte(`{"a":1}` + "\n"),
+				"yml":  []byte("a: 1\n"),
+			},
+		},
+		{
+			name:    "no formats",
+			formats: nil,
+			value:   func() any { return 1 },
+			want:    map[string][]byte{},
+		},
+		{
+			name:    "reader",
+			formats: []string{"text", "txt"},
+			value:   func() any { return strings.NewReader("hello") },
+			want: map[string][]byte{
+				"text": []byte("hello"),
+				"txt":  []byte("hello"),
+			},
+		},
+		{
+			name:    "channel",
+			formats: []string{"json", "yaml"},
+			value: func() any {
+				ch := make(chan int, 2)
+				ch <- 1
+				ch <- 2
+				close(ch)
+
+				return ch
+			},
+			want: map[string][]byte{
+				"json": []byte("[1,2]\n"),
+				"yaml": []byte("- 1\n- 2\n"),
+			},
+		},
+		{
+			name:      "unsupported format",
+			formats:   []string{"json", "xml"},
+			value:     func() any { return 1 },
+			wantErr:   "render: unsupported format: xml",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "value not supported by format",
+			formats:   []string{"json", "text"},
+			value:     func() any { return map[string]int{"a": 1} },
+			wantErr:   "render: unsupported format: text",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.RenderAll(tt.value(), tt.formats...)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_sortsMapKeys(t *testing.T) {
+	value := map[string]any{}
+	nested := map[any]any{}
+	for _, k := range []string{"z", "b", "v", "a", "x", "c", "w", "d"} {
+		value[k] = 1
+		nested[k] = true
+	}
+	value["nested"] = nested
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "json",
+			want: `{"a":1,"b":1,"c":1,"d":1,"nested":{"a":true,"b":true,` +
+				`"c":true,"d":true,"v":true,"w":true,"x":true,"z":true},` +
+				`"v":1,"w":1,"x":1,"z":1}` + "\n",
+		},
+		{
+			format: "yaml",
+			want: "a: 1\nb: 1\nc: 1\nd: 1\nnested:\n  a: true\n  b: true\n" +
+				"  c: true\n  d: true\n  v: true\n  w: true\n  x: true\n" +
+				"  z: true\nv: 1\nw: 1\nx: 1\nz: 1\n",
+		},
+		{
+			format: "flat",
+			want: "a=1\nb=1\nc=1\nd=1\nnested.a=true\nnested.b=true\n" +
+				"nested.c=true\nnested.d=true\nnested.v=true\n" +
+				"nested.w=true\nnested.x=true\nnested.z=true\n" +
+				"v=1\nw=1\nx=1\nz=1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got, err := Base.String(tt.format, false, value)
+
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
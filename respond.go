@@ -0,0 +1,183 @@
+package render
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotAcceptable is returned by Respond when none of the formats supported
+// by the Renderer are acceptable to the client.
+var ErrNotAcceptable = fmt.Errorf("%w: not acceptable", Err)
+
+// Respond renders v as a HTTP response with the given status code, in the
+// format which best matches the Accept header of req. Only formats with a
+// Handler that implements ContentTypeHandler can be negotiated, and the
+// Content-Type header is set to the content type of the chosen format.
+//
+// If req has no Accept header, or accepts any media type, the first format by
+// name is used. If no format is acceptable, a 406 Not Acceptable response is
+// written, and a ErrNotAcceptable error is returned.
+//
+// The value is rendered to a buffer before anything is written, so if
+// rendering fails, a 500 Internal Server Error response is written instead,
+// and the error is returned.
+func (r *Renderer) Respond(
+	w http.ResponseWriter,
+	req *http.Request,
+	status int,
+	v any,
+) error {
+	accept := strings.Join(req.Header.Values("Accept"), ",")
+
+	format, ok := r.negotiate(accept)
+	if !ok {
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotAcceptable),
+			http.StatusNotAcceptable,
+		)
+
+		return fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+	}
+
+	b, err := r.Bytes(format.Name, false, v)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+
+		return err
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+
+	if req.Method == http.MethodHead {
+		return nil
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Respond is a convenience function that calls the Default renderer's Respond
+// method.
+func Respond(
+	w http.ResponseWriter,
+	req *http.Request,
+	status int,
+	v any,
+) error {
+	return defaultRenderer().Respond(w, req, status, v)
+}
+
+// negotiate returns the format which best matches the given Accept header
+// value, from formats of the Renderer which have a content type.
+func (r *Renderer) negotiate(accept string) (Format, bool) {
+	var formats []Format
+	for _, f := range r.Formats() {
+		if f.ContentType != "" {
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) == 0 {
+		return Format{}, false
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return formats[0], true
+	}
+
+	for _, ar := range parseAccept(accept) {
+		for _, f := range formats {
+			if ar.matches(f.ContentType) {
+				return f, true
+			}
+		}
+	}
+
+	return Format{}, false
+}
+
+// acceptRange is a single media range of a Accept header.
+type acceptRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// parseAccept parses the media ranges of a Accept header value, sorted by
+// preference. Ranges are sorted by quality, and then by how specific they
+// are. Invalid ranges, and ranges with a quality of zero are omitted.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, s := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(s))
+		if err != nil {
+			continue
+		}
+
+		typ, subtype, ok := strings.Cut(mt, "/")
+		if !ok || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(qs, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, acceptRange{typ: typ, subtype: subtype, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+
+	return ranges
+}
+
+func (ar acceptRange) specificity() int {
+	switch {
+	case ar.typ == "*":
+		return 0
+	case ar.subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matches returns true if the media range matches the given content type.
+func (ar acceptRange) matches(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	typ, subtype, _ := strings.Cut(mt, "/")
+
+	return (ar.typ == "*" || ar.typ == typ) &&
+		(ar.subtype == "*" || ar.subtype == subtype)
+}
@@ -0,0 +1,210 @@
+package render
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Respond(t *testing.T) {
+	r := NewWith("json", "text", "yaml", "xml")
+	value := map[string]int{"a": 1}
+
+	tests := []struct {
+		name            string
+		method          string
+		accept          []string
+		status          int
+		value           any
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+		wantErr         string
+		wantErrIs       []error
+	}{
+		{
+			name:            "no Accept header",
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"a":1}` + "\n",
+		},
+		{
+			name:            "any media type",
+			accept:          []string{"*/*"},
+			status:          http.StatusCreated,
+			value:           value,
+			wantStatus:      http.StatusCreated,
+			wantContentType: "application/json",
+			wantBody:        `{"a":1}` + "\n",
+		},
+		{
+			name:            "exact media type",
+			accept:          []string{"application/yaml"},
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantBody:        "a: 1\n",
+		},
+		{
+			name:            "media type with parameters",
+			accept:          []string{"Text/Plain; charset=utf-8"},
+			status:          http.StatusOK,
+			value:           "hello",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "hello",
+		},
+		{
+			name: "quality values",
+			accept: []string{
+				"application/json;q=0.5, application/yaml",
+			},
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantBody:        "a: 1\n",
+		},
+		{
+			name:            "more specific range preferred",
+			accept:          []string{"*/*, text/*"},
+			status:          http.StatusOK,
+			value:           "hello",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "hello",
+		},
+		{
+			name:            "multiple Accept headers",
+			accept:          []string{"text/html", "application/yaml"},
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantBody:        "a: 1\n",
+		},
+		{
+			name:            "head request",
+			method:          http.MethodHead,
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        "",
+		},
+		{
+			name:            "not acceptable",
+			accept:          []string{"text/html, application/json;q=0"},
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusNotAcceptable,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "Not Acceptable\n",
+			wantErr: "render: not acceptable: " +
+				"text/html, application/json;q=0",
+			wantErrIs: []error{Err, ErrNotAcceptable},
+		},
+		{
+			name:            "render failure",
+			accept:          []string{"text/plain"},
+			status:          http.StatusOK,
+			value:           value,
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "Internal Server Error\n",
+			wantErr:         "render: unsupported format: text",
+			wantErrIs:       []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			for _, a := range tt.accept {
+				req.Header.Add("Accept", a)
+			}
+			rec := httptest.NewRecorder()
+
+			err := r.Respond(rec, req, tt.status, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t,
+				tt.wantContentType, rec.Header().Get("Content-Type"),
+			)
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRenderer_Respond_noContentTypes(t *testing.T) {
+	r := New(map[string]Handler{"mock": &mockHandler{output: "mock"}})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	err := r.Respond(rec, req, http.StatusOK, 1)
+
+	assert.ErrorIs(t, err, ErrNotAcceptable)
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
+
+type respondTestWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (rtw *respondTestWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestRenderer_Respond_writeError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := &respondTestWriter{httptest.NewRecorder()}
+
+	err := NewWith("json").Respond(w, req, http.StatusOK, 1)
+
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestRespond(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/yaml")
+	rec := httptest.NewRecorder()
+
+	err := Respond(rec, req, http.StatusOK, []int{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	assert.Equal(t, "- 1\n- 2\n", rec.Body.String())
+}
+
+func Test_parseAccept(t *testing.T) {
+	got := parseAccept(
+		"*/*;q=0.1, text/*, application/json, invalid, */json, " +
+			"application/xml;q=0, text/html;q=x, application/yaml;q=0.8",
+	)
+
+	assert.Equal(t, []acceptRange{
+		{typ: "application", subtype: "json", q: 1},
+		{typ: "text", subtype: "*", q: 1},
+		{typ: "application", subtype: "yaml", q: 0.8},
+		{typ: "*", subtype: "*", q: 0.1},
+	}, got)
+}
@@ -0,0 +1,215 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithFields renders only the given fields of struct and map values, like
+// sparse fieldsets in APIs, or a --fields flag in CLI tools. It applies to the
+// top-level value, and to each element if it is a slice or array.
+//
+// Fields are matched case-insensitively against map keys, struct field names,
+// and the names given in their json struct tag. Selected struct fields keep
+// their struct tags, so each format renders them as it would without field
+// selection, like YAML with yaml tags, and XML with attributes.
+func WithFields(fields ...string) Option {
+	return func(o *Options) {
+		o.Fields = append(o.Fields, fields...)
+	}
+}
+
+// WithoutFields renders all but the given fields of struct and map values. It
+// is matched and applied like WithFields.
+func WithoutFields(fields ...string) Option {
+	return func(o *Options) {
+		o.OmitFields = append(o.OmitFields, fields...)
+	}
+}
+
+// fieldSelector selects which fields of struct and map values are rendered.
+type fieldSelector struct {
+	include []string
+	exclude []string
+
+	// projections holds the struct projections created by the selector,
+	// keyed by their type.
+	projections map[reflect.Type]*structProjection
+}
+
+// newFieldSelector returns a fieldSelector for the given options, or nil if
+// they do not select any fields.
+func newFieldSelector(o *Options) *fieldSelector {
+	if len(o.Fields) == 0 && len(o.OmitFields) == 0 {
+		return nil
+	}
+
+	return &fieldSelector{
+		include:     o.Fields,
+		exclude:     o.OmitFields,
+		projections: map[reflect.Type]*structProjection{},
+	}
+}
+
+// selected reports if any of the given names of a field is selected.
+func (fs *fieldSelector) selected(names ...string) bool {
+	match := func(list []string) bool {
+		for _, f := range list {
+			for _, name := range names {
+				if name != "" && strings.EqualFold(f, name) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	if len(fs.include) > 0 && !match(fs.include) {
+		return false
+	}
+
+	return !match(fs.exclude)
+}
+
+// apply returns v with only the selected fields, if v is a struct or map, or a
+// slice or array of them. Other values are returned as is.
+//
+// Structs are replaced with values of a struct type with only the selected
+// fields, and slices and arrays of structs with slices of them, so they are
+// rendered by each format as they would be without selecting fields.
+func (fs *fieldSelector) apply(v any) any {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return v
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Struct, reflect.Map:
+		if nv, ok := fs.value(rv); ok {
+			return rootValue(nv, fs.projection).Interface()
+		}
+	case reflect.Slice, reflect.Array:
+		if _, ok := v.([]byte); ok {
+			return v
+		}
+
+		et := rv.Type().Elem()
+		if sp := fs.structProjection(et); sp != nil {
+			return rootValue(fs.structs(rv, sp), fs.projection).Interface()
+		}
+
+		s := make([]any, 0, rv.Len())
+		changed := false
+		for i := 0; i < rv.Len(); i++ {
+			ev := rv.Index(i)
+			nv, ok := fs.value(indirect(ev))
+			if ok {
+				s = append(s, nv.Interface())
+			} else {
+				s = append(s, ev.Interface())
+			}
+			changed = changed || ok
+		}
+		if changed {
+			return rootValue(reflect.ValueOf(s), fs.projection).Interface()
+		}
+	}
+
+	return v
+}
+
+// projection returns the projection created by the selector with the type t,
+// or nil if there is none.
+func (fs *fieldSelector) projection(t reflect.Type) *structProjection {
+	return fs.projections[t]
+}
+
+// structProjection returns a projection of the struct type t, or of the
+// struct type t points to, with only the selected fields. It returns nil if t
+// is not a struct type, or it implements its own marshaling.
+func (fs *fieldSelector) structProjection(
+	t reflect.Type,
+) *structProjection {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || marshals(t) {
+		return nil
+	}
+
+	sp := newStructProjection(t,
+		func(f reflect.StructField) (reflect.StructField, bool) {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+			return f, fs.selected(f.Name, name)
+		},
+	)
+	fs.projections[sp.typ] = sp
+
+	return sp
+}
+
+// structs returns a slice of the projection sp, with the selected fields of
+// each struct in the slice or array rv. If the elements of rv are pointers,
+// the returned slice holds pointers, keeping nil elements as nil.
+func (fs *fieldSelector) structs(
+	rv reflect.Value,
+	sp *structProjection,
+) reflect.Value {
+	et := sp.typ
+	if rv.Type().Elem().Kind() == reflect.Pointer {
+		et = reflect.PointerTo(et)
+	}
+
+	s := reflect.MakeSlice(reflect.SliceOf(et), rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		ev := indirect(rv.Index(i))
+		if !ev.IsValid() {
+			continue
+		}
+
+		nv := sp.value(ev, nil)
+		if et.Kind() == reflect.Pointer {
+			p := reflect.New(sp.typ)
+			p.Elem().Set(nv)
+			nv = p
+		}
+		s.Index(i).Set(nv)
+	}
+
+	return s
+}
+
+// value returns the selected fields of the struct or map rv.
+func (fs *fieldSelector) value(rv reflect.Value) (reflect.Value, bool) {
+	if !rv.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		sp := fs.structProjection(rv.Type())
+		if sp == nil {
+			return reflect.Value{}, false
+		}
+
+		return sp.value(rv, nil), true
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+
+		m := reflect.MakeMap(rv.Type())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if fs.selected(iter.Key().String()) {
+				m.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+
+		return m, true
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,286 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type selectTestPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	License string
+	Private bool `json:"-"`
+}
+
+func TestRenderer_Render_withFields(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+	})
+	pkg := selectTestPackage{Name: "render", Version: "1.0.0", License: "MIT"}
+
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "struct with fields",
+			format: "json",
+			value:  pkg,
+			opts:   []Option{WithFields("VERSION", "name")},
+			want:   `{"name":"render","version":"1.0.0"}` + "\n",
+		},
+		{
+			name:   "struct pointer with fields by Go name",
+			format: "yaml",
+			value:  &pkg,
+			opts:   []Option{WithFields("License", "Private")},
+			want:   "license: MIT\nprivate: false\n",
+		},
+		{
+			name:   "struct without fields",
+			format: "json",
+			value:  pkg,
+			opts:   []Option{WithoutFields("license")},
+			want:   `{"name":"render","version":"1.0.0"}` + "\n",
+		},
+		{
+			name:   "fields and without fields",
+			format: "json",
+			value:  pkg,
+			opts: []Option{
+				WithFields("name", "version"),
+				WithoutFields("name"),
+			},
+			want: `{"version":"1.0.0"}` + "\n",
+		},
+		{
+			name:   "map with fields",
+			format: "json",
+			value:  map[string]any{"a": 1, "b": 2, "c": 3},
+			opts:   []Option{WithFields("a", "C")},
+			want:   `{"a":1,"c":3}` + "\n",
+		},
+		{
+			name:   "map without fields",
+			format: "yaml",
+			value:  map[string]int{"a": 1, "b": 2},
+			opts:   []Option{WithoutFields("a")},
+			want:   "b: 2\n",
+		},
+		{
+			name:   "slice of structs",
+			format: "json",
+			value:  []*selectTestPackage{&pkg, nil},
+			opts:   []Option{WithFields("name")},
+			want:   `[{"name":"render"},null]` + "\n",
+		},
+		{
+			name:   "slice of maps",
+			format: "json",
+			value:  []map[string]int{{"a": 1, "b": 2}},
+			opts:   []Option{WithFields("b")},
+			want:   `[{"b":2}]` + "\n",
+		},
+		{
+			name:   "other values are unchanged",
+			format: "json",
+			value:  []int{1, 2},
+			opts:   []Option{WithFields("a")},
+			want:   "[1,2]\n",
+		},
+		{
+			name:   "no field options",
+			format: "json",
+			value:  map[string]int{"a": 1},
+			opts:   []Option{WithIndent("\t")},
+			want:   `{"a":1}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := r.Render(&buf, tt.format, false, tt.value, tt.opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+type selectTestAuthor struct {
+	Name string
+}
+
+type selectTestRelease struct {
+	ID     int    `json:"id" yaml:"release_id" xml:"id,attr" table:"Release" csv:"release"`
+	Title  string `json:"title" yaml:"name"`
+	Notes  string `json:"notes"`
+	Author selectTestAuthor
+}
+
+func TestRenderer_Render_withFields_formats(t *testing.T) {
+	release := selectTestRelease{
+		ID:     1,
+		Title:  "a&b<c>",
+		Notes:  "hidden",
+		Author: selectTestAuthor{Name: "jim"},
+	}
+	opts := []Option{WithFields("id", "title", "author")}
+
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		want   string
+	}{
+		{
+			name:   "json",
+			format: "json",
+			value:  release,
+			want: `{"id":1,"title":"a\u0026b\u003cc\u003e",` +
+				`"Author":{"Name":"jim"}}` + "\n",
+		},
+		{
+			name:   "yaml uses yaml tags",
+			format: "yaml",
+			value:  release,
+			want:   "release_id: 1\nname: a&b<c>\nauthor:\n  name: jim\n",
+		},
+		{
+			name:   "xml keeps attributes and field element names",
+			format: "xml",
+			value:  &release,
+			want: `<selectTestRelease id="1"><Title>a&amp;b&lt;c&gt;</Title>` +
+				`<Author><Name>jim</Name></Author></selectTestRelease>`,
+		},
+		{
+			name:   "xml slice",
+			format: "xml",
+			value:  []selectTestRelease{release},
+			want: `<selectTestRelease id="1"><Title>a&amp;b&lt;c&gt;</Title>` +
+				`<Author><Name>jim</Name></Author></selectTestRelease>`,
+		},
+		{
+			name:   "table struct",
+			format: "table",
+			value:  release,
+			want:   "Release  Title   Author\n1        a&b<c>  {jim}\n",
+		},
+		{
+			name:   "table slice of pointers",
+			format: "table",
+			value:  []*selectTestRelease{&release, nil},
+			want:   "Release  Title   Author\n1        a&b<c>  {jim}\n",
+		},
+		{
+			name:   "csv slice",
+			format: "csv",
+			value:  []selectTestRelease{release},
+			want:   "release,Title,Author\n1,a&b<c>,{jim}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Base.String(tt.format, false, tt.value, opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderer_Render_withFields_xlsx(t *testing.T) {
+	value := []selectTestRelease{{ID: 1, Title: "a", Notes: "hidden"}}
+
+	b, err := Base.Bytes("xlsx", false, value, WithoutFields("notes"))
+	require.NoError(t, err)
+
+	sheet := readXLSX(t, b)["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, ">ID<")
+	assert.Contains(t, sheet, ">Title<")
+	assert.NotContains(t, sheet, "Notes")
+	assert.NotContains(t, sheet, "XMLName")
+	assert.Equal(t, 2, strings.Count(sheet, "<row "))
+}
+
+type selectTestTaggedXMLName struct {
+	XMLName xml.Name `xml:"person"`
+	Name    string   `xml:"name"`
+	Age     int      `xml:"age"`
+}
+
+type selectTestXMLName struct {
+	XMLName xml.Name
+	Name    string `xml:"name"`
+	Age     int    `xml:"age"`
+}
+
+func TestRenderer_Render_withFields_xmlName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		opts  []Option
+		want  string
+	}{
+		{
+			name:  "tagged XMLName with WithFields",
+			value: selectTestTaggedXMLName{Name: "jim", Age: 42},
+			opts:  []Option{WithFields("name")},
+			want:  "<person><name>jim</name></person>",
+		},
+		{
+			name:  "tagged XMLName slice with WithoutFields",
+			value: []selectTestTaggedXMLName{{Name: "jim", Age: 42}},
+			opts:  []Option{WithoutFields("age")},
+			want:  "<person><name>jim</name></person>",
+		},
+		{
+			name:  "untagged XMLName with WithFields",
+			value: selectTestXMLName{Name: "jim", Age: 42},
+			opts:  []Option{WithFields("name")},
+			want: "<selectTestXMLName><name>jim</name>" +
+				"</selectTestXMLName>",
+		},
+		{
+			name:  "untagged XMLName slice with WithoutFields",
+			value: []*selectTestXMLName{{Name: "jim", Age: 42}},
+			opts:  []Option{WithoutFields("age")},
+			want: "<selectTestXMLName><name>jim</name>" +
+				"</selectTestXMLName>",
+		},
+		{
+			name:  "untagged XMLName with WithKeyCase",
+			value: selectTestXMLName{Name: "jim", Age: 42},
+			opts:  []Option{WithKeyCase(PascalCase)},
+			want: "<selectTestXMLName><Name>jim</Name><Age>42</Age>" +
+				"</selectTestXMLName>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Base.String("xml", false, tt.value, tt.opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderer_Render_withFields_anonymousXML(t *testing.T) {
+	value := struct{ A, B int }{A: 1, B: 2}
+
+	_, err := Base.String("xml", false, value, WithFields("a"))
+
+	assert.EqualError(t, err,
+		"render: failed: xml: unsupported type: struct { A int }",
+	)
+}
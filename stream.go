@@ -0,0 +1,149 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// RenderStream renders a sequence of values to w using the specified format.
+// The seq function has the same signature as iter.Seq[any], and can be passed
+// one directly.
+//
+// If the Handler of the format implements StreamHandler, values are rendered
+// incrementally as seq yields them, for example as elements of a JSON array,
+// or as lines of NDJSON. Otherwise, or if Buffered, Linters, or PostProcessors
+// are set on the Renderer, all values are collected into a []any slice which
+// is rendered like Render renders a value.
+//
+// If w has a Flush method, like bufio.Writer or http.ResponseWriter, it is
+// called after each value is rendered by a StreamHandler, so output is
+// delivered as soon as each value is available.
+//
+// The format and options are handled as with Render, with WithPretty enabling
+// pretty rendering. Value transformations, like WithFields, WithKeyCase, and
+// WithQuery, are applied to each value yielded by seq.
+func (r *Renderer) RenderStream(
+	w io.Writer,
+	format string,
+	seq func(yield func(any) bool),
+	opts ...Option,
+) error {
+	handler, err := r.handler(format)
+	if err != nil {
+		return err
+	}
+	format, pretty, o := r.configure(format, false, opts)
+	handler = withOptions(handler, o)
+
+	// transformed yields each value of seq prepared and transformed, stopping
+	// at the first error.
+	transformed := func(yield func(any) bool) {
+		seq(func(v any) bool {
+			if v, err = o.transform(r.prepare(v)); err != nil {
+				return false
+			}
+
+			return yield(v)
+		})
+	}
+
+	x, ok := handler.(StreamHandler)
+	if !ok || r.Buffered || len(r.Linters) > 0 || len(r.PostProcessors) > 0 {
+		values := []any{}
+		transformed(func(v any) bool {
+			values = append(values, v)
+
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		return r.output(w, handler, format, pretty, values)
+	}
+
+	if c, ok := handler.(CommentHandler); ok && r.Header != nil {
+		_, err = io.WriteString(w, r.Header.comment(c.CommentPrefix()))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	}
+
+	renderErr := r.renderStream(w, x, format, pretty, transformed)
+	if err != nil {
+		return err
+	}
+	if renderErr == nil {
+		renderErr = flush(w)
+	}
+
+	return renderError(format, renderErr)
+}
+
+// renderStream renders seq to w with the given StreamHandler, flushing w after
+// each value. If flushing fails, the sequence is stopped and the flush error
+// is returned.
+func (r *Renderer) renderStream(
+	w io.Writer,
+	handler StreamHandler,
+	format string,
+	pretty bool,
+	seq func(yield func(any) bool),
+) (err error) {
+	defer r.recoverPanic(format, &err)
+
+	var flushErr error
+	err = handler.RenderStream(w, pretty, func(yield func(any) bool) {
+		seq(func(v any) bool {
+			if !yield(v) {
+				return false
+			}
+			flushErr = flush(w)
+
+			return flushErr == nil
+		})
+	})
+	if flushErr != nil {
+		return flushErr
+	}
+
+	return err
+}
+
+// flush flushes w if it has a Flush method.
+func flush(w io.Writer) error {
+	switch x := w.(type) {
+	case interface{ Flush() error }:
+		if err := x.Flush(); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	case interface{ Flush() }:
+		x.Flush()
+	}
+
+	return nil
+}
+
+// chanSeq returns a sequence yielding each value received from v, if v is a
+// channel which can be received from. The sequence ends when the channel is
+// closed. A nil channel yields no values.
+func chanSeq(v any) (func(yield func(any) bool), bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Chan || rv.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, false
+	}
+
+	return func(yield func(any) bool) {
+		if rv.IsNil() {
+			return
+		}
+
+		for {
+			x, ok := rv.Recv()
+			if !ok || !yield(x.Interface()) {
+				return
+			}
+		}
+	}, true
+}
@@ -0,0 +1,481 @@
+package render
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// streamTestSeq returns a sequence yielding the given values, recording the
+// number of values yielded in n.
+func streamTestSeq(n *int, values ...any) func(yield func(any) bool) {
+	return func(yield func(any) bool) {
+		for _, v := range values {
+			*n++
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func TestRenderer_RenderStream(t *testing.T) {
+	r := New(map[string]Handler{
+		"json":   &JSON{},
+		"ndjson": &NDJSON{},
+		"yaml":   &YAML{},
+		"text":   &Text{},
+	})
+	l := list.New()
+	l.PushBack("x")
+
+	tests := []struct {
+		name      string
+		format    string
+		opts      []Option
+		values    []any
+		want      string
+		wantN     int
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "json",
+			format: "json",
+			values: []any{map[string]int{"a": 1}, "foo", 2},
+			want:   `[{"a":1},"foo",2]` + "\n",
+			wantN:  3,
+		},
+		{
+			name:   "json pretty",
+			format: "json",
+			opts:   []Option{WithPretty()},
+			values: []any{map[string]int{"a": 1}, 2},
+			want:   "[\n  {\n    \"a\": 1\n  },\n  2\n]\n",
+			wantN:  2,
+		},
+		{
+			name:   "json pretty with WithIndent and WithPrefix",
+			format: "json",
+			opts:   []Option{WithPretty(), WithIndent("\t"), WithPrefix("//")},
+			values: []any{[]int{1}, 2},
+			want:   "[\n//\t[\n//\t\t1\n//\t],\n//\t2\n//]\n",
+			wantN:  2,
+		},
+		{
+			name:   "json pretty modifier",
+			format: "json+pretty",
+			values: []any{map[string]int{"a": 1}, 2},
+			want:   "[\n  {\n    \"a\": 1\n  },\n  2\n]\n",
+			wantN:  2,
+		},
+		{
+			name:   "json compact modifier",
+			format: "json+compact",
+			values: []any{[]int{1}, 2},
+			want:   "[[1],2]\n",
+			wantN:  2,
+		},
+		{
+			name:   "json with parameters",
+			format: "json+pretty;indent=1",
+			values: []any{[]int{1}, 2},
+			want:   "[\n [\n  1\n ],\n 2\n]\n",
+			wantN:  2,
+		},
+		{
+			name:   "json empty",
+			format: "json",
+			want:   "[]\n",
+		},
+		{
+			name:   "json normalizes values",
+			format: "json",
+			values: []any{l},
+			want:   `[["x"]]` + "\n",
+			wantN:  1,
+		},
+		{
+			name:   "ndjson",
+			format: "ndjson",
+			values: []any{map[string]int{"a": 1}, "foo"},
+			want:   "{\"a\":1}\n\"foo\"\n",
+			wantN:  2,
+		},
+		{
+			name:   "handler without stream support",
+			format: "yaml",
+			values: []any{"a", 1},
+			want:   "- a\n- 1\n",
+			wantN:  2,
+		},
+		{
+			name:   "handler without stream support with modifier",
+			format: "yaml+compact",
+			values: []any{"a", 1},
+			want:   "- a\n- 1\n",
+			wantN:  2,
+		},
+		{
+			name:   "handler without stream support with parameters",
+			format: "yaml;indent=8",
+			values: []any{map[string][]int{"a": {1}}},
+			want:   "- a:\n        - 1\n",
+			wantN:  1,
+		},
+		{
+			name:      "handler without stream support cannot render",
+			format:    "text",
+			values:    []any{"a"},
+			wantErr:   "render: unsupported format: text",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "unsupported format",
+			format:    "unknown",
+			values:    []any{1},
+			wantErr:   "render: unsupported format: unknown",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+		{
+			name:      "stops on error",
+			format:    "json",
+			values:    []any{1, make(chan int), 3},
+			wantErr:   "render: failed: json: unsupported type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
+			wantN:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n := 0
+
+			err := r.RenderStream(
+				&buf, tt.format, streamTestSeq(&n, tt.values...), tt.opts...,
+			)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+			if tt.wantN > 0 {
+				assert.Equal(t, tt.wantN, n)
+			}
+		})
+	}
+}
+
+func TestRenderer_RenderStream_writeError(t *testing.T) {
+	w := &mockWriter{WriteErr: errors.New("write error")}
+	n := 0
+
+	err := NewWith("json").RenderStream(w, "json", streamTestSeq(&n, 1, 2))
+
+	assert.EqualError(t, err, "render: failed: write error")
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.Equal(t, 1, n)
+}
+
+func TestRenderStream(t *testing.T) {
+	var buf bytes.Buffer
+	n := 0
+
+	err := RenderStream(&buf, "json", streamTestSeq(&n, 1, 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]\n", buf.String())
+}
+
+type streamTestFlusher struct {
+	bytes.Buffer
+	flushed []string
+	err     error
+
+	// once only returns err from the first call to Flush.
+	once bool
+}
+
+func (f *streamTestFlusher) Flush() error {
+	f.flushed = append(f.flushed, f.String())
+
+	err := f.err
+	if f.once {
+		f.err = nil
+	}
+
+	return err
+}
+
+func TestRenderer_RenderStream_flush(t *testing.T) {
+	r := NewWith("json")
+
+	t.Run("flushes after each value", func(t *testing.T) {
+		w := &streamTestFlusher{}
+		n := 0
+
+		err := r.RenderStream(w, "json", streamTestSeq(&n, 1, 2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"[1", "[1,2", "[1,2]\n"}, w.flushed)
+	})
+
+	t.Run("flush error", func(t *testing.T) {
+		w := &streamTestFlusher{err: errors.New("flush error")}
+		n := 0
+
+		err := r.RenderStream(w, "json", streamTestSeq(&n, 1, 2))
+
+		assert.EqualError(t, err, "render: failed: flush error")
+		assert.ErrorIs(t, err, ErrFailed)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("flush error only returned once", func(t *testing.T) {
+		w := &streamTestFlusher{err: errors.New("flush error"), once: true}
+		n := 0
+
+		err := r.RenderStream(w, "json", streamTestSeq(&n, 1, 2))
+
+		assert.EqualError(t, err, "render: failed: flush error")
+		assert.ErrorIs(t, err, ErrFailed)
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestRenderer_Render_channel(t *testing.T) {
+	r := NewWith("json", "yaml", "ndjson")
+
+	tests := []struct {
+		name   string
+		format string
+		pretty bool
+		value  func() any
+		want   string
+	}{
+		{
+			name:   "json",
+			format: "json",
+			value: func() any {
+				ch := make(chan map[string]int, 2)
+				ch <- map[string]int{"a": 1}
+				ch <- map[string]int{"b": 2}
+				close(ch)
+
+				return ch
+			},
+			want: `[{"a":1},{"b":2}]` + "\n",
+		},
+		{
+			name:   "json pretty",
+			format: "json",
+			pretty: true,
+			value: func() any {
+				ch := make(chan int, 2)
+				ch <- 1
+				ch <- 2
+				close(ch)
+
+				return (<-chan int)(ch)
+			},
+			want: "[\n  1,\n  2\n]\n",
+		},
+		{
+			name:   "ndjson",
+			format: "ndjson",
+			value: func() any {
+				ch := make(chan string, 2)
+				ch <- "foo"
+				ch <- "bar"
+				close(ch)
+
+				return ch
+			},
+			want: "\"foo\"\n\"bar\"\n",
+		},
+		{
+			name:   "handler without stream support",
+			format: "yaml",
+			value: func() any {
+				ch := make(chan int, 2)
+				ch <- 1
+				ch <- 2
+				close(ch)
+
+				return ch
+			},
+			want: "- 1\n- 2\n",
+		},
+		{
+			name:   "nil channel",
+			format: "json",
+			value: func() any {
+				var ch chan int
+
+				return ch
+			},
+			want: "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := r.Render(&buf, tt.format, tt.pretty, tt.value())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_channelIncremental(t *testing.T) {
+	ch := make(chan int)
+	w := &streamTestFlusher{}
+	done := make(chan error)
+
+	go func() {
+		done <- NewWith("ndjson").Render(w, "ndjson", false, ch)
+	}()
+
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"1\n", "1\n2\n", "1\n2\n"}, w.flushed)
+}
+
+func TestRenderer_RenderStream_transforms(t *testing.T) {
+	r := NewWith("json", "ndjson", "yaml")
+	values := []any{
+		map[string]any{"userName": "foo", "age": 1},
+		map[string]any{"userName": "bar", "age": 2},
+	}
+
+	tests := []struct {
+		name      string
+		format    string
+		opts      []Option
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "json with WithFields and WithKeyCase",
+			format: "json",
+			opts: []Option{
+				WithFields("userName"), WithKeyCase(SnakeCase),
+			},
+			want: `[{"user_name":"foo"},{"user_name":"bar"}]` + "\n",
+		},
+		{
+			name:   "ndjson with WithoutFields",
+			format: "ndjson",
+			opts:   []Option{WithoutFields("userName")},
+			want:   "{\"age\":1}\n{\"age\":2}\n",
+		},
+		{
+			name:   "ndjson with WithQuery",
+			format: "ndjson",
+			opts:   []Option{WithQuery(".userName")},
+			want:   "\"foo\"\n\"bar\"\n",
+		},
+		{
+			name:   "handler without stream support with WithQuery",
+			format: "yaml",
+			opts:   []Option{WithQuery(".age")},
+			want:   "- 1\n- 2\n",
+		},
+		{
+			name:   "invalid query",
+			format: "ndjson",
+			opts:   []Option{WithQuery("foo")},
+			wantErr: "render: failed: query: \"foo\": " +
+				"expected '.' at position 1",
+			wantErrIs: []error{Err, ErrFailed, ErrQuery},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n := 0
+
+			err := r.RenderStream(
+				&buf, tt.format, streamTestSeq(&n, values...), tt.opts...,
+			)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+// streamTestCommentHandler is a StreamHandler which supports comments.
+type streamTestCommentHandler struct {
+	NDJSON
+}
+
+func (*streamTestCommentHandler) CommentPrefix() string {
+	return "# "
+}
+
+func TestRenderer_RenderStream_header(t *testing.T) {
+	r := New(map[string]Handler{
+		"json":    &JSON{},
+		"comment": &streamTestCommentHandler{},
+	})
+	r.Header = &Header{Tool: "mytool", Version: "1.2.3"}
+
+	var buf bytes.Buffer
+	n := 0
+	err := r.RenderStream(&buf, "comment", streamTestSeq(&n, 1, 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "# Generated by mytool 1.2.3\n1\n2\n", buf.String())
+
+	buf.Reset()
+	err = r.RenderStream(&buf, "json", streamTestSeq(&n, 1, 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]\n", buf.String())
+}
+
+func TestRenderer_RenderStream_linters(t *testing.T) {
+	r := NewWith("json")
+	var linted []string
+	r.Linters = []Linter{func(_ string, output []byte) error {
+		linted = append(linted, string(output))
+
+		return nil
+	}}
+
+	var buf bytes.Buffer
+	n := 0
+	err := r.RenderStream(
+		&buf, "json;pretty", streamTestSeq(&n, []int{1}, 2),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  [\n    1\n  ],\n  2\n]\n", buf.String())
+	assert.Equal(t, []string{buf.String()}, linted)
+}
@@ -0,0 +1,558 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table is a Handler that renders structs, and slices or arrays of structs, as
+// a plain text table, with a header row followed by one row per struct.
+//
+// Each exported field is rendered as a column, with the header taken from the
+// "table" struct tag if present, or the field name otherwise. Fields tagged
+// with `table:"-"` are skipped. Columns are ordered with the "order" tag
+// option, like `table:"Name,order=1"`, placing fields with an order before all
+// other fields, which keep their declaration order.
+//
+// Cells are rendered with the String method if available, time.Time values as
+// RFC 3339, and all other values with fmt.Sprint. Nil values are rendered as
+// empty cells. The width of cells in a column can be limited with the
+// "max_width" tag option, like `table:"Description,max_width=40"`, which
+// overrides MaxWidth.
+//
+// Columns tagged with the "wide" option, like `table:"Node,wide"`, are only
+// rendered if Wide is set, like the "wide" output of kubectl.
+//
+// A footer row with aggregates of the values of columns, like their sum, is
+// added if any column has one, either set with Footer, or with the "footer"
+// tag option, like `table:"Cost,footer=sum"`, which supports the "count" and
+// "sum" aggregates.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type Table struct {
+	// Columns selects the columns to render, in order, by their header names.
+	// Names are matched case-insensitively, and may include wide columns. If
+	// empty, all columns are rendered.
+	Columns []string
+
+	// Wide includes columns tagged with the "wide" option. A Table handler
+	// with Wide set supports the "wide" format instead of "table".
+	Wide bool
+
+	// Style is the layout of the table. By default columns are separated by
+	// two spaces, without any borders.
+	Style TableStyle
+
+	// MaxWidth is the maximum number of characters of cells in all columns
+	// without a "max_width" tag option. Longer cells are truncated, ending
+	// with "…", or wrapped if Wrap is set. If zero, cells are not limited.
+	MaxWidth int
+
+	// Wrap wraps cells longer than their maximum width onto multiple lines,
+	// instead of truncating them. With TableStyleMarkdown, lines are joined
+	// with "<br>" tags.
+	Wrap bool
+
+	// Footer sets the aggregates of columns, keyed by their header names,
+	// which are matched case-insensitively. They override aggregates set with
+	// the "footer" tag option.
+	Footer map[string]TableAggregate
+}
+
+// TableAggregate returns the text of a footer cell, for the values of all rows
+// of a column. Nil pointers are given as nil, and all other pointers are
+// dereferenced.
+type TableAggregate func(values []any) string
+
+var tableAggregates = map[string]TableAggregate{
+	"count": TableCount,
+	"sum":   TableSum,
+}
+
+// TableCount is a TableAggregate which returns the number of rows.
+func TableCount(values []any) string {
+	return strconv.Itoa(len(values))
+}
+
+// TableSum is a TableAggregate which returns the sum of all numeric values,
+// formatted like the values themselves, like "1h30m0s" for time.Duration
+// values. Non-numeric values are ignored, and if there are none, the cell is
+// empty.
+func TableSum(values []any) string {
+	var (
+		typ    reflect.Type
+		ints   int64
+		uints  uint64
+		floats float64
+	)
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() { //nolint:exhaustive
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64:
+			ints += rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Uint64, reflect.Uintptr:
+			uints += rv.Uint()
+		case reflect.Float32, reflect.Float64:
+			floats += rv.Float()
+		default:
+			continue
+		}
+		if typ == nil {
+			typ = rv.Type()
+		}
+	}
+	if typ == nil {
+		return ""
+	}
+
+	sum := reflect.New(typ).Elem()
+	switch sum.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		sum.SetInt(ints)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		sum.SetUint(uints)
+	default:
+		sum.SetFloat(floats)
+	}
+
+	return tableCell(sum)
+}
+
+// TableStyle is a layout for rendering tables with the Table handler.
+type TableStyle int
+
+const (
+	// TableStyleBorderless separates columns with two spaces, without any
+	// borders, like kubectl output.
+	TableStyleBorderless TableStyle = iota
+
+	// TableStyleUnicode draws borders around all cells with box-drawing
+	// characters.
+	TableStyleUnicode
+
+	// TableStyleASCII draws borders around all cells with "+", "-", and "|"
+	// characters.
+	TableStyleASCII
+
+	// TableStyleMarkdown renders the table as a GitHub Flavored Markdown
+	// table. Pipe characters in cells are escaped.
+	TableStyleMarkdown
+)
+
+var tableStyleParams = map[string]TableStyle{
+	"borderless": TableStyleBorderless,
+	"unicode":    TableStyleUnicode,
+	"ascii":      TableStyleASCII,
+	"markdown":   TableStyleMarkdown,
+}
+
+// tableBorder describes the borders of a table style. Rules are given as the
+// left, fill, cross, and right characters, and are omitted if empty.
+type tableBorder struct {
+	top, separator, bottom string
+	vertical               string
+}
+
+var tableBorders = map[TableStyle]tableBorder{
+	TableStyleUnicode: {
+		top:       "┌─┬┐",
+		separator: "├─┼┤",
+		bottom:    "└─┴┘",
+		vertical:  "│",
+	},
+	TableStyleASCII: {
+		top:       "+-++",
+		separator: "+-++",
+		bottom:    "+-++",
+		vertical:  "|",
+	},
+	TableStyleMarkdown: {
+		separator: "|-||",
+		vertical:  "|",
+	},
+}
+
+var (
+	_ Handler            = (*Table)(nil)
+	_ FormatsHandler     = (*Table)(nil)
+	_ DescribedHandler   = (*Table)(nil)
+	_ ContentTypeHandler = (*Table)(nil)
+	_ OptionsHandler     = (*Table)(nil)
+)
+
+// Render writes v as a table to w.
+func (tb *Table) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	fields, err := tb.fields(t)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(fields))
+		for i, f := range fields {
+			cells[r][i] = tb.fit(f, tableCell(f.value(row)))
+		}
+	}
+
+	var footer []string
+	for i, f := range fields {
+		agg := tb.aggregate(f)
+		if agg == nil {
+			continue
+		}
+		if footer == nil {
+			footer = make([]string, len(fields))
+		}
+
+		values := make([]any, len(rows))
+		for r, row := range rows {
+			if fv := indirect(f.value(row)); fv.IsValid() {
+				values[r] = fv.Interface()
+			}
+		}
+		footer[i] = tb.fit(f, agg(values))
+	}
+
+	if tb.Style == TableStyleMarkdown {
+		esc := strings.NewReplacer("|", "\\|", "\n", "<br>")
+		for _, row := range append([][]string{header, footer}, cells...) {
+			for i := range row {
+				row[i] = esc.Replace(row[i])
+			}
+		}
+	}
+
+	out := tableLayout(header, cells, footer, tb.Style)
+	_, err = (&fullWriter{w: w}).Write(out)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// fields returns the fields of the struct type t rendered as columns, in
+// order.
+func (tb *Table) fields(t reflect.Type) ([]structField, error) {
+	fields := structFields(t, "table")
+	sort.SliceStable(fields, func(i, j int) bool {
+		oi, iok := tableOrder(fields[i])
+		oj, jok := tableOrder(fields[j])
+
+		return iok && (!jok || oi < oj)
+	})
+
+	if len(tb.Columns) == 0 {
+		if tb.Wide {
+			return fields, nil
+		}
+
+		narrow := make([]structField, 0, len(fields))
+		for _, f := range fields {
+			if !f.hasOption("wide") {
+				narrow = append(narrow, f)
+			}
+		}
+
+		return narrow, nil
+	}
+
+	selected := make([]structField, 0, len(tb.Columns))
+	for _, name := range tb.Columns {
+		i := tableFieldIndex(fields, name)
+		if i < 0 {
+			return nil, fmt.Errorf(
+				"%w: unknown table column: %q", ErrFailed, name,
+			)
+		}
+		selected = append(selected, fields[i])
+	}
+
+	return selected, nil
+}
+
+// fit returns cell truncated or wrapped to the maximum width of the field f.
+func (tb *Table) fit(f structField, cell string) string {
+	if limit := tb.maxWidth(f); limit > 0 {
+		return string(textFit([]byte(cell), limit, !tb.Wrap))
+	}
+
+	return cell
+}
+
+// aggregate returns the footer aggregate of the field f, or nil if it has
+// none.
+func (tb *Table) aggregate(f structField) TableAggregate {
+	for name, agg := range tb.Footer {
+		if strings.EqualFold(name, f.name) {
+			return agg
+		}
+	}
+
+	return tableAggregates[f.options["footer"]]
+}
+
+// maxWidth returns the maximum width of cells of the field f, or zero if they
+// are not limited.
+func (tb *Table) maxWidth(f structField) int {
+	if n, err := strconv.Atoi(f.options["max_width"]); err == nil {
+		return n
+	}
+
+	return tb.MaxWidth
+}
+
+// tableOrder returns the value of the "order" option of f, and true if it is
+// set to a valid integer.
+func tableOrder(f structField) (int, bool) {
+	n, err := strconv.Atoi(f.options["order"])
+
+	return n, err == nil
+}
+
+// tableFieldIndex returns the index of the field with the given name, matched
+// case-insensitively, or -1 if there is none.
+func tableFieldIndex(fields []structField, name string) int {
+	for i, f := range fields {
+		if strings.EqualFold(f.name, strings.TrimSpace(name)) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// tableCell returns the text of a cell for the given value. Line breaks are
+// replaced with spaces, so each row is a single line.
+func tableCell(rv reflect.Value) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(
+		fieldText(rv),
+	)
+}
+
+// tableLayout returns the text of a table with the given header, rows, and
+// footer in the given style, with columns left-aligned. Cells may span
+// multiple lines. If footer is nil, no footer row is written.
+func tableLayout(
+	header []string,
+	rows [][]string,
+	footer []string,
+	style TableStyle,
+) []byte {
+	all := append([][]string{header}, rows...)
+	if footer != nil {
+		all = append(all, footer)
+	}
+	widths := make([]int, len(header))
+	for _, row := range all {
+		for i, cell := range row {
+			for _, line := range strings.Split(cell, "\n") {
+				if n := utf8.RuneCountInString(line); n > widths[i] {
+					widths[i] = n
+				}
+			}
+		}
+	}
+
+	border, boxed := tableBorders[style]
+
+	var b strings.Builder
+	if boxed {
+		tableRule(&b, border.top, widths)
+	}
+	for r, row := range all {
+		for _, line := range tableLines(row) {
+			if !boxed {
+				var sb strings.Builder
+				for i, cell := range line {
+					if i > 0 {
+						sb.WriteString("  ")
+					}
+					sb.WriteString(tablePad(cell, widths[i]))
+				}
+				b.WriteString(strings.TrimRight(sb.String(), " ") + "\n")
+
+				continue
+			}
+
+			b.WriteString(border.vertical)
+			for i, cell := range line {
+				b.WriteString(" " + tablePad(cell, widths[i]) + " ")
+				b.WriteString(border.vertical)
+			}
+			b.WriteString("\n")
+		}
+
+		// Markdown has no footer rows, so the footer is a regular row.
+		if boxed && (r == 0 || (footer != nil && r == len(all)-2 &&
+			style != TableStyleMarkdown)) {
+			tableRule(&b, border.separator, widths)
+		}
+	}
+	if boxed {
+		tableRule(&b, border.bottom, widths)
+	}
+
+	return []byte(b.String())
+}
+
+// tableLines splits the cells of row into lines, returning the cells of each
+// line. Cells with fewer lines than others are padded with empty lines.
+func tableLines(row []string) [][]string {
+	split := make([][]string, len(row))
+	height := 1
+	for i, cell := range row {
+		split[i] = strings.Split(cell, "\n")
+		if len(split[i]) > height {
+			height = len(split[i])
+		}
+	}
+
+	lines := make([][]string, height)
+	for l := range lines {
+		lines[l] = make([]string, len(row))
+		for i, cellLines := range split {
+			if l < len(cellLines) {
+				lines[l][i] = cellLines[l]
+			}
+		}
+	}
+
+	return lines
+}
+
+// tableRule writes a horizontal rule for columns of the given widths to b,
+// using the left, fill, cross, and right characters of rule. Nothing is
+// written if rule is empty.
+func tableRule(b *strings.Builder, rule string, widths []int) {
+	if rule == "" {
+		return
+	}
+
+	rs := []rune(rule)
+	b.WriteRune(rs[0])
+	for i, w := range widths {
+		if i > 0 {
+			b.WriteRune(rs[2])
+		}
+		b.WriteString(strings.Repeat(string(rs[1]), w+2))
+	}
+	b.WriteRune(rs[3])
+	b.WriteString("\n")
+}
+
+// tablePad returns s padded with spaces to the given width.
+func tablePad(s string, width int) string {
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// WithOptions returns a copy of the Table handler with the "columns" parameter
+// applied to the Columns field, as a comma-separated list of column names,
+// like "table;columns=name,age". The "style" parameter sets the Style field to
+// TableStyleBorderless, TableStyleUnicode, TableStyleASCII, or
+// TableStyleMarkdown for the values "borderless", "unicode", "ascii", or
+// "markdown". The "max_width" parameter sets the MaxWidth field, if it is a
+// valid integer, and the "wrap" parameter sets the Wrap field, if it is a valid
+// boolean. The "footer" parameter adds "count" and "sum" aggregates to Footer,
+// as a comma-separated list of column and aggregate pairs, like
+// "table;footer=name:count,cost:sum". The "wide" parameter sets the Wide field,
+// if it is a valid boolean.
+func (tb *Table) WithOptions(opts *Options) Handler {
+	columns := opts.Params["columns"]
+	style, hasStyle := tableStyleParams[opts.Params["style"]]
+	maxWidth, err := strconv.Atoi(opts.Params["max_width"])
+	hasMaxWidth := err == nil && maxWidth >= 0
+	wrap, hasWrap := opts.boolParam("wrap")
+	footer := tableFooterParam(opts.Params["footer"])
+	wide, hasWide := opts.boolParam("wide")
+	if columns == "" && !hasStyle && !hasMaxWidth && !hasWrap &&
+		len(footer) == 0 && !hasWide {
+		return tb
+	}
+
+	c := *tb
+	if columns != "" {
+		c.Columns = strings.Split(columns, ",")
+	}
+	if hasStyle {
+		c.Style = style
+	}
+	if hasMaxWidth {
+		c.MaxWidth = maxWidth
+	}
+	if hasWrap {
+		c.Wrap = wrap
+	}
+	if hasWide {
+		c.Wide = wide
+	}
+	if len(footer) > 0 {
+		c.Footer = make(map[string]TableAggregate, len(tb.Footer)+len(footer))
+		for name, agg := range tb.Footer {
+			c.Footer[name] = agg
+		}
+		for name, agg := range footer {
+			c.Footer[name] = agg
+		}
+	}
+
+	return &c
+}
+
+// tableFooterParam returns the aggregates of the "footer" parameter value s,
+// skipping pairs with unknown aggregates.
+func tableFooterParam(s string) map[string]TableAggregate {
+	footer := map[string]TableAggregate{}
+	for _, pair := range strings.Split(s, ",") {
+		name, agg, _ := strings.Cut(pair, ":")
+		if fn, ok := tableAggregates[strings.TrimSpace(agg)]; ok {
+			footer[strings.TrimSpace(name)] = fn
+		}
+	}
+
+	return footer
+}
+
+// Formats returns a list of format strings that this Handler supports, which
+// is "wide" if Wide is set, and "table" otherwise.
+func (tb *Table) Formats() []string {
+	if tb.Wide {
+		return []string{"wide"}
+	}
+
+	return []string{"table"}
+}
+
+// Description returns a short human-readable description of the format.
+func (tb *Table) Description() string {
+	if tb.Wide {
+		return "Plain text table, with wide columns"
+	}
+
+	return "Plain text table"
+}
+
+// ContentType returns the MIME type of the format.
+func (tb *Table) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
@@ -0,0 +1,507 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableTestRow struct {
+	Name    string
+	Age     int
+	Joined  time.Time
+	Note    *string
+	Skipped string `table:"-"`
+	private string
+}
+
+type tableTestOrdered struct {
+	Description string `table:"Description"`
+	Name        string `table:"Name,order=1"`
+	ID          int    `table:"ID,order=0"`
+	Status      string
+}
+
+func TestTable_Render(t *testing.T) {
+	note := "hi\nthere"
+	joined := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		columns   []string
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "slice of structs",
+			value: []tableTestRow{
+				{Name: "Alice", Age: 30, Joined: joined, Note: &note},
+				{Name: "Bob", Age: 4, Skipped: "x", private: "y"},
+			},
+			want: "Name   Age  Joined                Note\n" +
+				"Alice  30   2024-03-25T12:00:00Z  hi there\n" +
+				"Bob    4\n",
+		},
+		{
+			name:  "single struct pointer",
+			value: &tableTestRow{Name: "Ä", Age: 1},
+			want: "Name  Age  Joined  Note\n" +
+				"Ä     1\n",
+		},
+		{
+			name:  "empty slice",
+			value: []tableTestRow{},
+			want:  "Name  Age  Joined  Note\n",
+		},
+		{
+			name: "ordered by tag",
+			value: []tableTestOrdered{
+				{Description: "desc", Name: "a", ID: 7, Status: "ok"},
+			},
+			want: "ID  Name  Description  Status\n" +
+				"7   a     desc         ok\n",
+		},
+		{
+			name:    "selected columns",
+			columns: []string{"status", "ID"},
+			value: []tableTestOrdered{
+				{Description: "desc", Name: "a", ID: 7, Status: "ok"},
+			},
+			want: "Status  ID\n" +
+				"ok      7\n",
+		},
+		{
+			name:      "unknown column",
+			columns:   []string{"Nope"},
+			value:     []tableTestOrdered{},
+			wantErr:   "render: failed: unknown table column: \"Nope\"",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "not struct based",
+			value:     map[string]int{"a": 1},
+			wantErr:   "render: cannot render: map[string]int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &Table{Columns: tt.columns}
+			var buf bytes.Buffer
+
+			err := tb.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestTable_Style(t *testing.T) {
+	value := []tableTestOrdered{
+		{ID: 1, Name: "Alice", Description: "a|b", Status: "ok"},
+		{ID: 22, Name: "Bö"},
+	}
+
+	tests := []struct {
+		name  string
+		style TableStyle
+		want  string
+	}{
+		{
+			name:  "borderless",
+			style: TableStyleBorderless,
+			want: "ID  Name   Description  Status\n" +
+				"1   Alice  a|b          ok\n" +
+				"22  Bö\n",
+		},
+		{
+			name:  "unicode",
+			style: TableStyleUnicode,
+			want: "┌────┬───────┬─────────────┬────────┐\n" +
+				"│ ID │ Name  │ Description │ Status │\n" +
+				"├────┼───────┼─────────────┼────────┤\n" +
+				"│ 1  │ Alice │ a|b         │ ok     │\n" +
+				"│ 22 │ Bö    │             │        │\n" +
+				"└────┴───────┴─────────────┴────────┘\n",
+		},
+		{
+			name:  "ascii",
+			style: TableStyleASCII,
+			want: "+----+-------+-------------+--------+\n" +
+				"| ID | Name  | Description | Status |\n" +
+				"+----+-------+-------------+--------+\n" +
+				"| 1  | Alice | a|b         | ok     |\n" +
+				"| 22 | Bö    |             |        |\n" +
+				"+----+-------+-------------+--------+\n",
+		},
+		{
+			name:  "markdown",
+			style: TableStyleMarkdown,
+			want: "| ID | Name  | Description | Status |\n" +
+				"|----|-------|-------------|--------|\n" +
+				"| 1  | Alice | a\\|b        | ok     |\n" +
+				"| 22 | Bö    |             |        |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &Table{Style: tt.style}
+			var buf bytes.Buffer
+
+			err := tb.Render(&buf, value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_tableStyleParam(t *testing.T) {
+	value := tableTestOrdered{ID: 1, Name: "a"}
+
+	got, err := Base.String("table;style=ascii;columns=id,name", false, value)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"+----+------+\n"+
+			"| ID | Name |\n"+
+			"+----+------+\n"+
+			"| 1  | a    |\n"+
+			"+----+------+\n",
+		got,
+	)
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	type task struct {
+		ID          int
+		Title       string `table:"Title,max_width=8"`
+		Description string
+	}
+	value := []task{
+		{ID: 1, Title: "Write the docs", Description: "all of them now"},
+		{ID: 2, Title: "Ship", Description: "soon"},
+	}
+
+	tests := []struct {
+		name     string
+		maxWidth int
+		wrap     bool
+		style    TableStyle
+		want     string
+	}{
+		{
+			name: "column tag only",
+			want: "ID  Title     Description\n" +
+				"1   Write t…  all of them now\n" +
+				"2   Ship      soon\n",
+		},
+		{
+			name:     "global max width",
+			maxWidth: 6,
+			want: "ID  Title     Description\n" +
+				"1   Write t…  all o…\n" +
+				"2   Ship      soon\n",
+		},
+		{
+			name:     "wrap",
+			maxWidth: 6,
+			wrap:     true,
+			want: "ID  Title     Description\n" +
+				"1   Write     all of\n" +
+				"    the docs  them\n" +
+				"              now\n" +
+				"2   Ship      soon\n",
+		},
+		{
+			name:     "wrap unicode",
+			maxWidth: 6,
+			wrap:     true,
+			style:    TableStyleUnicode,
+			want: "┌────┬──────────┬─────────────┐\n" +
+				"│ ID │ Title    │ Description │\n" +
+				"├────┼──────────┼─────────────┤\n" +
+				"│ 1  │ Write    │ all of      │\n" +
+				"│    │ the docs │ them        │\n" +
+				"│    │          │ now         │\n" +
+				"│ 2  │ Ship     │ soon        │\n" +
+				"└────┴──────────┴─────────────┘\n",
+		},
+		{
+			name:     "wrap markdown",
+			maxWidth: 6,
+			wrap:     true,
+			style:    TableStyleMarkdown,
+			want: "| ID | Title             | Description           |\n" +
+				"|----|-------------------|-----------------------|\n" +
+				"| 1  | Write<br>the docs | all of<br>them<br>now |\n" +
+				"| 2  | Ship              | soon                  |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &Table{
+				MaxWidth: tt.maxWidth,
+				Wrap:     tt.wrap,
+				Style:    tt.style,
+			}
+			var buf bytes.Buffer
+
+			err := tb.Render(&buf, value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_tableMaxWidthParams(t *testing.T) {
+	value := tableTestRow{Name: "Alexander", Age: 30}
+
+	got, err := Base.String(
+		"table;columns=name,age;max_width=5", false, value,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Name   Age\nAlex…  30\n", got)
+
+	got, err = Base.String(
+		"table;columns=name,age;max_width=5;wrap", false, value,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Name   Age\nAlexa  30\nnder\n", got)
+}
+
+type tableTestUsage struct {
+	Service string        `table:"Service,footer=count"`
+	Calls   int           `table:"Calls,footer=sum"`
+	Cost    float64       `table:"Cost"`
+	Time    time.Duration `table:"Time,footer=sum"`
+	Note    *string
+}
+
+func TestTable_Footer(t *testing.T) {
+	value := []tableTestUsage{
+		{Service: "api", Calls: 1200, Cost: 1.25, Time: time.Minute},
+		{Service: "db", Calls: 34, Cost: 0.5, Time: 30 * time.Second},
+	}
+
+	tests := []struct {
+		name   string
+		footer map[string]TableAggregate
+		style  TableStyle
+		value  any
+		want   string
+	}{
+		{
+			name:  "tag aggregates",
+			value: value,
+			want: "Service  Calls  Cost  Time   Note\n" +
+				"api      1200   1.25  1m0s\n" +
+				"db       34     0.5   30s\n" +
+				"2        1234         1m30s\n",
+		},
+		{
+			name: "custom aggregates",
+			footer: map[string]TableAggregate{
+				"cost":    TableSum,
+				"service": func([]any) string { return "Total" },
+				"note": func(values []any) string {
+					return fmt.Sprint(values)
+				},
+			},
+			value: value,
+			want: "Service  Calls  Cost  Time   Note\n" +
+				"api      1200   1.25  1m0s\n" +
+				"db       34     0.5   30s\n" +
+				"Total    1234   1.75  1m30s  [<nil> <nil>]\n",
+		},
+		{
+			name:  "no rows",
+			value: []tableTestUsage{},
+			want: "Service  Calls  Cost  Time  Note\n" +
+				"0\n",
+		},
+		{
+			name:  "unicode",
+			style: TableStyleUnicode,
+			value: value[:1],
+			want: "┌─────────┬───────┬──────┬──────┬──────┐\n" +
+				"│ Service │ Calls │ Cost │ Time │ Note │\n" +
+				"├─────────┼───────┼──────┼──────┼──────┤\n" +
+				"│ api     │ 1200  │ 1.25 │ 1m0s │      │\n" +
+				"├─────────┼───────┼──────┼──────┼──────┤\n" +
+				"│ 1       │ 1200  │      │ 1m0s │      │\n" +
+				"└─────────┴───────┴──────┴──────┴──────┘\n",
+		},
+		{
+			name:  "markdown",
+			style: TableStyleMarkdown,
+			value: value[:1],
+			want: "| Service | Calls | Cost | Time | Note |\n" +
+				"|---------|-------|------|------|------|\n" +
+				"| api     | 1200  | 1.25 | 1m0s |      |\n" +
+				"| 1       | 1200  |      | 1m0s |      |\n",
+		},
+		{
+			name:  "no aggregates",
+			value: []tableTestRow{{Name: "a", Age: 1}},
+			want:  "Name  Age  Joined  Note\na     1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &Table{Footer: tt.footer, Style: tt.style}
+			var buf bytes.Buffer
+
+			err := tb.Render(&buf, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestTableSum(t *testing.T) {
+	one := 1
+	tests := []struct {
+		name   string
+		values []any
+		want   string
+	}{
+		{name: "ints", values: []any{1, 2, 3}, want: "6"},
+		{name: "uints", values: []any{uint8(200), uint8(50)}, want: "250"},
+		{name: "floats", values: []any{0.25, 0.5}, want: "0.75"},
+		{name: "nil values", values: []any{nil, 2, nil}, want: "2"},
+		{name: "pointers", values: []any{&one}, want: ""},
+		{name: "non-numeric", values: []any{"a", true}, want: ""},
+		{name: "empty", values: nil, want: ""},
+		{
+			name:   "durations",
+			values: []any{time.Second, 2 * time.Minute},
+			want:   "2m1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TableSum(tt.values))
+		})
+	}
+}
+
+func TestTableCount(t *testing.T) {
+	assert.Equal(t, "0", TableCount(nil))
+	assert.Equal(t, "3", TableCount([]any{1, nil, "a"}))
+}
+
+func TestRenderer_Render_tableFooterParam(t *testing.T) {
+	value := []tableTestUsage{
+		{Service: "api", Cost: 1.25},
+		{Service: "db", Cost: 0.5},
+	}
+
+	got, err := Base.String(
+		"table;columns=service,cost;footer=cost:sum,service:nope",
+		false, value,
+	)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"Service  Cost\napi      1.25\ndb       0.5\n2        1.75\n", got,
+	)
+}
+
+type tableTestPod struct {
+	Name   string
+	Status string
+	IP     string `table:"IP,wide"`
+	Node   string `table:",wide"`
+}
+
+func TestTable_Wide(t *testing.T) {
+	value := []tableTestPod{
+		{Name: "web", Status: "Running", IP: "10.0.0.1", Node: "n1"},
+	}
+
+	tests := []struct {
+		name string
+		tb   *Table
+		want string
+	}{
+		{
+			name: "narrow",
+			tb:   &Table{},
+			want: "Name  Status\n" +
+				"web   Running\n",
+		},
+		{
+			name: "wide",
+			tb:   &Table{Wide: true},
+			want: "Name  Status   IP        Node\n" +
+				"web   Running  10.0.0.1  n1\n",
+		},
+		{
+			name: "narrow with wide column selected",
+			tb:   &Table{Columns: []string{"name", "node"}},
+			want: "Name  Node\n" +
+				"web   n1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.tb.Render(&buf, value)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_wideFormat(t *testing.T) {
+	value := tableTestPod{Name: "web", Status: "Running", Node: "n1"}
+
+	got, err := Base.String("wide", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "Name  Status   IP  Node\nweb   Running      n1\n", got)
+
+	got, err = Base.String("table;wide=true;columns=name,node", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "Name  Node\nweb   n1\n", got)
+}
+
+func TestTable_Render_writeError(t *testing.T) {
+	tb := &Table{}
+	w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+	err := tb.Render(w, tableTestRow{Name: "a"})
+
+	assert.EqualError(t, err, "render: failed: write error!!1")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestRenderer_Render_tableColumnsParam(t *testing.T) {
+	value := []tableTestOrdered{{Name: "a", ID: 1, Status: "ok"}}
+
+	got, err := Base.String("table;columns=name,status", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "Name  Status\na     ok\n", got)
+}
+
+func TestTable_Formats(t *testing.T) {
+	h := &Table{}
+
+	assert.Equal(t, []string{"table"}, h.Formats())
+	assert.Equal(t, []string{"wide"}, (&Table{Wide: true}).Formats())
+}
@@ -0,0 +1,50 @@
+"""Generates the Arrow IPC stream fixtures used by arrow_test.go with pyarrow,
+as an independent reference for the output of the Arrow handler.
+
+Usage: python3 testdata/arrow/generate.py
+"""
+
+import datetime
+import os
+
+import pyarrow as pa
+
+schema = pa.schema(
+    [
+        pa.field("name", pa.utf8()),
+        pa.field("Age", pa.int64()),
+        pa.field("Small", pa.int8()),
+        pa.field("Count", pa.uint16()),
+        pa.field("Score", pa.float32()),
+        pa.field("Ratio", pa.float64()),
+        pa.field("Active", pa.bool_()),
+        pa.field("Joined", pa.timestamp("us", tz="UTC")),
+        pa.field("Took", pa.duration("ns")),
+        pa.field("Note", pa.utf8()),
+    ]
+)
+
+joined = datetime.datetime(
+    2024, 3, 1, 12, 30, 0, 123456, tzinfo=datetime.timezone.utc
+)
+
+batch = pa.record_batch(
+    [
+        pa.array(["John", "Jane"], pa.utf8()),
+        pa.array([42, -1], pa.int64()),
+        pa.array([-3, 0], pa.int8()),
+        pa.array([7, 0], pa.uint16()),
+        pa.array([1.5, 0.0], pa.float32()),
+        pa.array([0.25, 0.0], pa.float64()),
+        pa.array([True, False], pa.bool_()),
+        pa.array([joined, None], pa.timestamp("us", tz="UTC")),
+        pa.array([90 * 10**9, 0], pa.duration("ns")),
+        pa.array(["hi", None], pa.utf8()),
+    ],
+    schema=schema,
+)
+
+path = os.path.join(os.path.dirname(__file__), "rows.arrows")
+with pa.OSFile(path, "wb") as sink:
+    with pa.ipc.new_stream(sink, schema) as writer:
+        writer.write_batch(batch)
@@ -1,8 +1,18 @@
 package render
 
 import (
+	"bytes"
+	"encoding"
 	"fmt"
 	"io"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
 )
 
 // Text is a Handler that writes the given value to the writer as text,
@@ -16,22 +26,150 @@ import (
 //   - int, int8, int16, int32, int64
 //   - uint, uint8, uint16, uint32, uint64
 //   - float32, float64
+//   - complex64, complex128
 //   - bool
+//   - time.Time (formatted as RFC 3339 with nanoseconds)
+//   - time.Duration (like "1h2m3s")
+//   - ByteSize
+//   - *big.Int
+//   - *big.Float (with the smallest number of digits needed to represent it)
+//   - io.ReadSeeker (read from the start)
+//   - io.ReaderAt (read from the start)
 //   - io.Reader
 //   - io.WriterTo
 //   - fmt.Stringer
 //   - error
+//   - encoding.TextAppender
+//   - encoding.TextMarshaler
+//   - fmt.Formatter (formatted with the "%v" verb)
+//   - pointers to any of the above (nil pointers are rendered as Nil)
 //
-// If the value is of any other type, a ErrCannotRender error will be returned.
-type Text struct{}
+// If the value is of any other type, a ErrCannotRender error will be returned,
+// unless Fallback is set.
+//
+// If Template is set, it is executed with the value instead, regardless of its
+// type.
+//
+// When pretty rendering, ByteSize, time.Duration, and integer values are
+// humanized for terminal users, like "1.2 GiB", "3h12m", and "1.4M". All other
+// values are rendered the same as when not pretty rendering.
+//
+// If Width is set, long lines are wrapped or truncated to fit within it.
+type Text struct {
+	// Template is executed with the value as its data to render it, instead
+	// of rendering based on the value's type, if not nil.
+	Template *template.Template
+
+	// Fallback renders values of any other type with fmt.Sprintf("%+v", v),
+	// instead of returning a ErrCannotRender error.
+	Fallback bool
+
+	// Nil is written for nil pointers to supported types, like a nil *string.
+	// If empty, nothing is written.
+	Nil string
+
+	// Width is the maximum number of characters per line. Longer lines are
+	// wrapped at spaces, or split where they have none. If negative, the
+	// width of the terminal is read from the COLUMNS environment variable. If
+	// zero, or COLUMNS is not set, lines are not limited.
+	Width int
+
+	// Truncate cuts lines which are longer than Width short, ending them with
+	// "…", instead of wrapping them.
+	Truncate bool
+}
 
 var (
-	_ Handler        = (*Text)(nil)
-	_ FormatsHandler = (*Text)(nil)
+	_ Handler            = (*Text)(nil)
+	_ PrettyHandler      = (*Text)(nil)
+	_ FormatsHandler     = (*Text)(nil)
+	_ DescribedHandler   = (*Text)(nil)
+	_ ContentTypeHandler = (*Text)(nil)
+	_ CommentHandler     = (*Text)(nil)
+	_ ProbeHandler       = (*Text)(nil)
+	_ ParseHandler       = (*Text)(nil)
+	_ OptionsHandler     = (*Text)(nil)
 )
 
-// Render writes the given value to the writer as text.
+// Render writes the given value to the writer as text. Partial writes to w are
+// retried until all output has been written.
 func (t *Text) Render(w io.Writer, v any) error {
+	return t.render(w, v, false)
+}
+
+// RenderPretty writes the given value to the writer as text, humanizing byte
+// sizes, durations, and large integers. If Template is set, it is used as is.
+func (t *Text) RenderPretty(w io.Writer, v any) error {
+	return t.render(w, v, true)
+}
+
+// render writes v to w as text, humanized if pretty is true, and fitted to the
+// configured Width.
+func (t *Text) render(w io.Writer, v any, pretty bool) error {
+	width := t.width()
+	if width <= 0 {
+		return t.write(w, v, pretty)
+	}
+
+	var buf bytes.Buffer
+	if err := t.write(&buf, v, pretty); err != nil {
+		return err
+	}
+
+	_, err := (&fullWriter{w: w}).Write(textFit(buf.Bytes(), width, t.Truncate))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// width returns the maximum line width, or zero if lines are not limited.
+func (t *Text) width() int {
+	if t.Width >= 0 {
+		return t.Width
+	}
+
+	n, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+// write writes v to w as text, humanized if pretty is true.
+func (t *Text) write(w io.Writer, v any, pretty bool) error {
+	w = &fullWriter{w: w}
+
+	if t.Template != nil {
+		err := t.Template.Execute(w, v)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+
+		return nil
+	}
+
+	if rv, ok := textPointer(v); ok {
+		if rv.IsNil() {
+			_, err := w.Write([]byte(t.Nil))
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrFailed, err)
+			}
+
+			return nil
+		}
+
+		return t.write(w, rv.Elem().Interface(), pretty)
+	}
+
+	if pretty {
+		if s, ok := humanize(v); ok {
+			v = s
+		}
+	}
+
 	var err error
 	switch x := v.(type) {
 	case []byte:
@@ -42,28 +180,219 @@ func (t *Text) Render(w io.Writer, v any) error {
 		_, err = w.Write([]byte(x))
 	case int, int8, int16, int32, int64,
 		uint, uint8, uint16, uint32, uint64,
-		float32, float64, bool:
+		float32, float64, complex64, complex128, bool:
 		_, err = fmt.Fprintf(w, "%v", x)
-	case io.Reader:
-		_, err = io.Copy(w, x)
+	case time.Time:
+		_, err = w.Write([]byte(x.Format(time.RFC3339Nano)))
+	case time.Duration:
+		_, err = w.Write([]byte(x.String()))
+	case *big.Int:
+		_, err = w.Write([]byte(x.String()))
+	case *big.Float:
+		if x == nil {
+			_, err = w.Write([]byte("<nil>"))
+		} else {
+			_, err = w.Write([]byte(x.Text('g', -1)))
+		}
+	case io.ReadSeeker, io.ReaderAt, io.Reader:
+		_, err = io.Copy(w, readFromStart(x))
 	case io.WriterTo:
 		_, err = x.WriteTo(w)
 	case fmt.Stringer:
 		_, err = w.Write([]byte(x.String()))
 	case error:
 		_, err = w.Write([]byte(x.Error()))
+	case textAppender:
+		err = writeAppended(w, x.AppendText)
+	case encoding.TextMarshaler:
+		var b []byte
+		if b, err = x.MarshalText(); err == nil {
+			_, err = w.Write(b)
+		}
+	case fmt.Formatter:
+		_, err = fmt.Fprintf(w, "%v", x)
+	default:
+		if !t.Fallback {
+			return fmt.Errorf("%w: %T", ErrCannotRender, v)
+		}
+		_, err = fmt.Fprintf(w, "%+v", v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Parse reads all text from r into v, which must be a *string, *[]byte, *any,
+// or io.Writer. With *any, v is set to a string.
+func (t *Text) Parse(r io.Reader, v any) error {
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, r)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+
+		return nil
+	}
+
+	switch v.(type) {
+	case *string, *[]byte, *any:
 	default:
 		return fmt.Errorf("%w: %T", ErrCannotRender, v)
 	}
 
+	b, err := io.ReadAll(r)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
 
+	switch x := v.(type) {
+	case *string:
+		*x = string(b)
+	case *[]byte:
+		*x = b
+	case *any:
+		*x = string(b)
+	}
+
 	return nil
 }
 
+// textFit returns b with each line longer than width wrapped, or truncated if
+// truncate is true.
+func textFit(b []byte, width int, truncate bool) []byte {
+	lines := strings.Split(string(b), "\n")
+
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch {
+		case utf8.RuneCountInString(line) <= width:
+			out = append(out, line)
+		case truncate:
+			out = append(out, string([]rune(line)[:width-1])+"…")
+		default:
+			out = append(out, textWrap(line, width)...)
+		}
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
+
+// textWrap splits line into lines no longer than width, breaking at the last
+// space which fits, or within words longer than width.
+func textWrap(line string, width int) []string {
+	var lines []string
+	rs := []rune(line)
+	for len(rs) > width {
+		i := width
+		for i > 0 && rs[i] != ' ' {
+			i--
+		}
+
+		if i == 0 {
+			lines = append(lines, string(rs[:width]))
+			rs = rs[width:]
+		} else {
+			lines = append(lines, strings.TrimRight(string(rs[:i]), " "))
+			rs = rs[i+1:]
+		}
+	}
+
+	return append(lines, string(rs))
+}
+
+// Probe returns nil if v is of a type supported by Render, without reading or
+// writing anything. With Fallback or Template set, all types are supported.
+func (t *Text) Probe(v any) error {
+	if t.Fallback || t.Template != nil || textSupported(v) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %T", ErrCannotRender, v)
+}
+
+// textSupported reports if v is of a type supported by the type switch of
+// Text, or is a pointer to one, which may be nil.
+func textSupported(v any) bool {
+	if _, ok := textPointer(v); ok {
+		return true
+	}
+
+	switch v.(type) {
+	case []byte, []rune, string,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64, complex64, complex128, bool,
+		time.Time, time.Duration, *big.Int, *big.Float,
+		io.Reader, io.ReaderAt, io.WriterTo, fmt.Stringer, error,
+		textAppender, encoding.TextMarshaler, fmt.Formatter:
+		return true
+	}
+
+	return false
+}
+
+// textPointer returns v as a reflect.Value, and true if it is a pointer, which
+// may be nil, to a value supported by Text. Pointers which only support text
+// rendering through methods with pointer receivers are not included.
+func textPointer(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return rv, false
+	}
+
+	return rv, textSupported(reflect.Zero(rv.Type().Elem()).Interface())
+}
+
+// WithOptions returns a copy of the Text handler with the "fallback" and
+// "truncate" parameters applied to the Fallback and Truncate fields, if they
+// are valid booleans. The "width" parameter sets the Width field, if it is a
+// valid integer, and the "nil" parameter sets the Nil field.
+func (t *Text) WithOptions(opts *Options) Handler {
+	fallback, hasFallback := opts.boolParam("fallback")
+	truncate, hasTruncate := opts.boolParam("truncate")
+	width, err := strconv.Atoi(opts.Params["width"])
+	hasWidth := err == nil
+	nilText, hasNil := opts.Params["nil"]
+	if !hasFallback && !hasTruncate && !hasWidth && !hasNil {
+		return t
+	}
+
+	c := *t
+	if hasFallback {
+		c.Fallback = fallback
+	}
+	if hasTruncate {
+		c.Truncate = truncate
+	}
+	if hasWidth {
+		c.Width = width
+	}
+	if hasNil {
+		c.Nil = nilText
+	}
+
+	return &c
+}
+
 // Formats returns a list of format strings that this Handler supports.
 func (t *Text) Formats() []string {
 	return []string{"text", "txt", "plain"}
 }
+
+// Description returns a short human-readable description of the format.
+func (t *Text) Description() string {
+	return "Plain text"
+}
+
+// ContentType returns the MIME type of the format.
+func (t *Text) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// CommentPrefix returns the string used to start comment lines in text output.
+func (t *Text) CommentPrefix() string {
+	return "# "
+}
@@ -1,12 +1,20 @@
 package render
 
 import (
+	"bytes"
+	"encoding"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"net/netip"
+	"strings"
 	"testing"
+	"text/template"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockStringer struct {
@@ -19,6 +27,45 @@ func (ms *mockStringer) String() string {
 	return ms.value
 }
 
+type mockTextMarshaler struct {
+	value string
+	err   error
+}
+
+var _ encoding.TextMarshaler = (*mockTextMarshaler)(nil)
+
+func (m *mockTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte(m.value), m.err
+}
+
+type mockTextAppender struct {
+	value string
+	err   error
+}
+
+var (
+	_ textAppender           = (*mockTextAppender)(nil)
+	_ encoding.TextMarshaler = (*mockTextAppender)(nil)
+)
+
+func (m *mockTextAppender) AppendText(b []byte) ([]byte, error) {
+	return append(b, m.value...), m.err
+}
+
+func (m *mockTextAppender) MarshalText() ([]byte, error) {
+	return nil, errors.New("MarshalText called")
+}
+
+type mockFormatter struct {
+	value string
+}
+
+var _ fmt.Formatter = (*mockFormatter)(nil)
+
+func (m *mockFormatter) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "%c:%s", verb, m.value)
+}
+
 type mockWriterTo struct {
 	value string
 	err   error
@@ -140,8 +187,44 @@ func TestText_Render(t *testing.T) {
 		{name: "uint64", value: uint64(51), want: "51"},
 		{name: "float32", value: float32(3.14), want: "3.14"},
 		{name: "float64", value: float64(3.14159), want: "3.14159"},
+		{name: "complex64", value: complex64(1 + 2i), want: "(1+2i)"},
+		{
+			name:  "complex128",
+			value: complex(3.5, -0.25),
+			want:  "(3.5-0.25i)",
+		},
 		{name: "bool true", value: true, want: "true"},
 		{name: "bool false", value: false, want: "false"},
+		{
+			name: "time.Time",
+			value: time.Date(
+				2024, 3, 25, 10, 4, 5, 120000000,
+				time.FixedZone("CET", 3600),
+			),
+			want: "2024-03-25T10:04:05.12+01:00",
+		},
+		{
+			name:  "time.Duration",
+			value: 90*time.Minute + 500*time.Millisecond,
+			want:  "1h30m0.5s",
+		},
+		{
+			name:  "*big.Int",
+			value: new(big.Int).Lsh(big.NewInt(1), 100),
+			want:  "1267650600228229401496703205376",
+		},
+		{name: "nil *big.Int", value: (*big.Int)(nil), want: "<nil>"},
+		{
+			name:  "*big.Float",
+			value: big.NewFloat(1234567.8125),
+			want:  "1.2345678125e+06",
+		},
+		{
+			name:  "*big.Float integer",
+			value: big.NewFloat(42),
+			want:  "42",
+		},
+		{name: "nil *big.Float", value: (*big.Float)(nil), want: "<nil>"},
 		{
 			name:  "implements fmt.Stringer",
 			value: &mockStringer{value: "test string"},
@@ -182,11 +265,66 @@ func TestText_Render(t *testing.T) {
 			wantErr:   "render: failed: Read error!!1",
 			wantErrIs: []error{Err, ErrFailed},
 		},
+		{
+			name:  "implements io.ReadSeeker",
+			value: partiallyRead(strings.NewReader("seeker string"), 7),
+			want:  "seeker string",
+		},
+		{
+			name:  "implements io.ReaderAt",
+			value: &mockReaderAt{value: "reader at string"},
+			want:  "reader at string",
+		},
+		{
+			name:      "io.ReaderAt error",
+			value:     &mockReaderAt{err: errors.New("ReadAt error!!1")},
+			wantErr:   "render: failed: ReadAt error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
 		{
 			name:  "error",
 			value: errors.New("this is an error"),
 			want:  "this is an error",
 		},
+		{
+			name:  "implements encoding.TextMarshaler",
+			value: &mockTextMarshaler{value: "text marshaler"},
+			want:  "text marshaler",
+		},
+		{
+			name:      "encoding.TextMarshaler error",
+			value:     &mockTextMarshaler{err: errors.New("Marshal error!!1")},
+			wantErr:   "render: failed: Marshal error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "implements encoding.TextAppender",
+			value: &mockTextAppender{value: "text appender"},
+			want:  "text appender",
+		},
+		{
+			name:      "encoding.TextAppender error",
+			value:     &mockTextAppender{err: errors.New("Append error!!1")},
+			wantErr:   "render: failed: Append error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "implements fmt.Formatter",
+			value: &mockFormatter{value: "formatted"},
+			want:  "v:formatted",
+		},
+		{
+			name:      "error writing to writer with fmt.Formatter",
+			writeErr:  errors.New("write error!!1"),
+			value:     &mockFormatter{value: "formatted"},
+			wantErr:   "render: failed: write error!!1",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:  "netip.Addr",
+			value: netip.MustParseAddr("192.0.2.1"),
+			want:  "192.0.2.1",
+		},
 		{
 			name:      "does not implement any supported type/interface",
 			value:     struct{}{},
@@ -222,3 +360,434 @@ func TestText_Formats(t *testing.T) {
 
 	assert.Equal(t, []string{"text", "txt", "plain"}, h.Formats())
 }
+
+func TestText_CommentPrefix(t *testing.T) {
+	h := &Text{}
+
+	assert.Equal(t, "# ", h.CommentPrefix())
+}
+
+func TestText_Probe(t *testing.T) {
+	h := &Text{}
+
+	for _, v := range []any{
+		[]byte("a"), []rune("a"), "a", 1, uint8(1), 1.5, 1i, true,
+		time.Time{}, time.Second, big.NewInt(1), big.NewFloat(1),
+		strings.NewReader("a"), &mockStringer{value: "a"},
+		errors.New("a"), &mockTextMarshaler{value: "a"},
+		&mockFormatter{value: "a"}, &mockTextAppender{value: "a"},
+	} {
+		assert.NoErrorf(t, h.Probe(v), "%T", v)
+	}
+
+	err := h.Probe(struct{}{})
+	assert.EqualError(t, err, "render: cannot render: struct {}")
+	assert.ErrorIs(t, err, ErrCannotRender)
+}
+
+func TestText_Fallback(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "nil", value: nil, want: "<nil>"},
+		{name: "struct", value: point{X: 1, Y: 2}, want: "{X:1 Y:2}"},
+		{name: "pointer", value: &point{X: 1, Y: 2}, want: "&{X:1 Y:2}"},
+		{name: "slice", value: []int{1, 2}, want: "[1 2]"},
+		{
+			name:  "map",
+			value: map[string]int{"b": 2, "a": 1},
+			want:  "map[a:1 b:2]",
+		},
+		{name: "supported type", value: "plain", want: "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Text{Fallback: true}
+			var buf bytes.Buffer
+
+			err := h.Render(&buf, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+			assert.NoError(t, h.Probe(tt.value))
+		})
+	}
+
+	t.Run("write error", func(t *testing.T) {
+		h := &Text{Fallback: true}
+		w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+		err := h.Render(w, struct{}{})
+		assert.EqualError(t, err, "render: failed: write error!!1")
+		assert.ErrorIs(t, err, ErrFailed)
+	})
+}
+
+func TestRenderer_Render_textFallbackParam(t *testing.T) {
+	value := struct{ A int }{A: 1}
+
+	_, err := Base.String("text", false, value)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	got, err := Base.String("text;fallback", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "{A:1}", got)
+}
+
+func TestText_Template(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		writeErr error
+		value    any
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "struct",
+			template: "{{.Name}} is {{.Age}}",
+			value:    user{Name: "Alice", Age: 30},
+			want:     "Alice is 30",
+		},
+		{
+			name:     "overrides type switch",
+			template: "<{{.}}>",
+			value:    "plain",
+			want:     "<plain>",
+		},
+		{
+			name:     "range",
+			template: "{{range .}}- {{.}}\n{{end}}",
+			value:    []string{"a", "b"},
+			want:     "- a\n- b\n",
+		},
+		{
+			name:     "execution error",
+			template: "{{.Missing}}",
+			value:    user{Name: "Alice"},
+			wantErr: "render: failed: template: text:1:2: executing " +
+				"\"text\" at <.Missing>: can't evaluate field Missing " +
+				"in type render.user",
+		},
+		{
+			name:     "write error",
+			template: "{{.Name}}",
+			writeErr: errors.New("write error!!1"),
+			value:    user{Name: "Alice"},
+			wantErr:  "render: failed: write error!!1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Text{
+				Template: template.Must(
+					template.New("text").Parse(tt.template),
+				),
+			}
+			w := &mockWriter{WriteErr: tt.writeErr}
+
+			err := h.Render(w, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrFailed)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, w.String())
+			}
+			assert.NoError(t, h.Probe(tt.value))
+		})
+	}
+}
+
+func TestText_RenderPretty(t *testing.T) {
+	tests := []struct {
+		name     string
+		writeErr error
+		value    any
+		want     string
+		wantErr  string
+	}{
+		{name: "byte size", value: ByteSize(1288490189), want: "1.2 GiB"},
+		{
+			name:  "duration",
+			value: 3*time.Hour + 12*time.Minute + 5*time.Second,
+			want:  "3h12m",
+		},
+		{name: "integer", value: 1400000, want: "1.4M"},
+		{name: "string", value: "1400000", want: "1400000"},
+		{
+			name:     "write error",
+			writeErr: errors.New("write error!!1"),
+			value:    ByteSize(1024),
+			wantErr:  "render: failed: write error!!1",
+		},
+		{
+			name:    "unsupported type",
+			value:   struct{}{},
+			wantErr: "render: cannot render: struct {}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Text{}
+			w := &mockWriter{WriteErr: tt.writeErr}
+
+			err := h.RenderPretty(w, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, w.String())
+			}
+		})
+	}
+
+	t.Run("compact rendering stays raw", func(t *testing.T) {
+		got, err := Base.String("text", false, ByteSize(1288490189))
+		require.NoError(t, err)
+		assert.Equal(t, "1288490189", got)
+
+		got, err = Base.String("text", true, ByteSize(1288490189))
+		require.NoError(t, err)
+		assert.Equal(t, "1.2 GiB", got)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		h := &Text{Template: template.Must(template.New("").Parse("{{.}}"))}
+		var buf bytes.Buffer
+
+		err := h.RenderPretty(&buf, 1400000)
+		require.NoError(t, err)
+		assert.Equal(t, "1400000", buf.String())
+	})
+}
+
+func TestText_Width(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		truncate bool
+		columns  string
+		pretty   bool
+		value    any
+		want     string
+	}{
+		{
+			name:  "disabled",
+			value: "the quick brown fox jumps over the lazy dog",
+			want:  "the quick brown fox jumps over the lazy dog",
+		},
+		{
+			name:  "wraps at spaces",
+			width: 15,
+			value: "the quick brown fox jumps over the lazy dog",
+			want:  "the quick brown\nfox jumps over\nthe lazy dog",
+		},
+		{
+			name:  "keeps short lines",
+			width: 10,
+			value: "short\n\nlines\n",
+			want:  "short\n\nlines\n",
+		},
+		{
+			name:  "splits long words",
+			width: 4,
+			value: "abcdefghij kl",
+			want:  "abcd\nefgh\nij\nkl",
+		},
+		{
+			name:  "counts runes",
+			width: 5,
+			value: "ääääää ö",
+			want:  "äääää\nä ö",
+		},
+		{
+			name:     "truncates",
+			width:    10,
+			truncate: true,
+			value:    "the quick brown fox\njumps\nover the lazy dog",
+			want:     "the quick…\njumps\nover the …",
+		},
+		{
+			name:    "width from COLUMNS",
+			width:   -1,
+			columns: "9",
+			value:   "the quick brown fox",
+			want:    "the quick\nbrown fox",
+		},
+		{
+			name:    "invalid COLUMNS",
+			width:   -1,
+			columns: "wide",
+			value:   "the quick brown fox",
+			want:    "the quick brown fox",
+		},
+		{
+			name:   "pretty",
+			width:  3,
+			pretty: true,
+			value:  ByteSize(1024),
+			want:   "1\nKiB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+			h := &Text{Width: tt.width, Truncate: tt.truncate}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		h := &Text{Width: 10}
+
+		err := h.Render(&bytes.Buffer{}, struct{}{})
+		assert.ErrorIs(t, err, ErrCannotRender)
+
+		w := &mockWriter{WriteErr: errors.New("write error!!1")}
+		err = h.Render(w, "text")
+		assert.EqualError(t, err, "render: failed: write error!!1")
+	})
+}
+
+func TestRenderer_Render_textWidthParams(t *testing.T) {
+	got, err := Base.String("text;width=9", false, "the quick brown fox")
+	require.NoError(t, err)
+	assert.Equal(t, "the quick\nbrown fox", got)
+
+	got, err = Base.String("text;width=9;truncate", false, "the quick brown")
+	require.NoError(t, err)
+	assert.Equal(t, "the quic…", got)
+}
+
+func TestText_pointers(t *testing.T) {
+	str := "hello"
+	num := 42
+	dur := 90 * time.Minute
+	strPtr := &str
+
+	tests := []struct {
+		name    string
+		nil     string
+		pretty  bool
+		value   any
+		want    string
+		wantErr string
+	}{
+		{name: "*string", value: &str, want: "hello"},
+		{name: "*int", value: &num, want: "42"},
+		{name: "**string", value: &strPtr, want: "hello"},
+		{name: "*time.Duration", value: &dur, want: "1h30m0s"},
+		{
+			name:   "*time.Duration pretty",
+			pretty: true,
+			value:  &dur,
+			want:   "1h30m",
+		},
+		{name: "nil *string", value: (*string)(nil), want: ""},
+		{
+			name:  "nil *int with placeholder",
+			nil:   "-",
+			value: (*int)(nil),
+			want:  "-",
+		},
+		{
+			name:  "nil *time.Duration with placeholder",
+			nil:   "-",
+			value: (*time.Duration)(nil),
+			want:  "-",
+		},
+		{
+			name:  "nil **string with placeholder",
+			nil:   "<none>",
+			value: (**string)(nil),
+			want:  "<none>",
+		},
+		{
+			name:    "pointer to unsupported type",
+			value:   &struct{}{},
+			wantErr: "render: cannot render: *struct {}",
+		},
+		{
+			name:    "nil pointer to unsupported type",
+			value:   (*struct{})(nil),
+			wantErr: "render: cannot render: *struct {}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Text{Nil: tt.nil}
+			var buf bytes.Buffer
+
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrCannotRender)
+				assert.ErrorIs(t, h.Probe(tt.value), ErrCannotRender)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+				assert.NoError(t, h.Probe(tt.value))
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_textNilParam(t *testing.T) {
+	got, err := Base.String("text;nil=n/a", false, (*string)(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "n/a", got)
+}
+
+func TestText_Parse(t *testing.T) {
+	h := &Text{}
+
+	var s string
+	require.NoError(t, h.Parse(strings.NewReader("hello"), &s))
+	assert.Equal(t, "hello", s)
+
+	var b []byte
+	require.NoError(t, h.Parse(strings.NewReader("hello"), &b))
+	assert.Equal(t, []byte("hello"), b)
+
+	var v any
+	require.NoError(t, h.Parse(strings.NewReader("hello"), &v))
+	assert.Equal(t, "hello", v)
+
+	var buf strings.Builder
+	require.NoError(t, h.Parse(strings.NewReader("hello"), &buf))
+	assert.Equal(t, "hello", buf.String())
+
+	var n int
+	err := h.Parse(strings.NewReader("1"), &n)
+	assert.EqualError(t, err, "render: cannot render: *int")
+	assert.ErrorIs(t, err, ErrCannotRender)
+}
@@ -0,0 +1,401 @@
+package render
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	anyType           = reflect.TypeOf((*any)(nil)).Elem()
+	xmlNameType       = reflect.TypeOf(xml.Name{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	yamlMarshalerType = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+	xmlMarshalerType  = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// valueTransformer converts values into generic maps, slices, and views, while
+// transforming leaf values. Structs are
+// converted to views which retain their field order, named by their json
+// struct tag if set. Fields tagged with "omitempty" are omitted if empty.
+//
+// Values which implement json.Marshaler, yaml.Marshaler, xml.Marshaler, or
+// encoding.TextMarshaler are kept as is.
+type valueTransformer struct {
+	// leaf optionally replaces values. It is called for every value, and its
+	// result is used if it returns true.
+	leaf func(rv reflect.Value) (any, bool)
+}
+
+// apply returns the transformed value of v.
+func (vt *valueTransformer) apply(v any) any {
+	return vt.value(reflect.ValueOf(v), 0)
+}
+
+// value returns the transformed value of rv.
+func (vt *valueTransformer) value(rv reflect.Value, depth int) any {
+	if !rv.IsValid() {
+		return nil
+	}
+
+	switch x := rv.Interface().(type) {
+	case *viewValue:
+		return vt.view(x, depth)
+	case viewList:
+		l := make(viewList, 0, len(x))
+		for _, vv := range x {
+			l = append(l, vt.view(vv, depth+1))
+		}
+
+		return l
+	}
+
+	if depth > maxDepth || marshals(rv.Type()) {
+		return rv.Interface()
+	}
+
+	if vt.leaf != nil {
+		if v, ok := vt.leaf(rv); ok {
+			return v
+		}
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return rv.Interface()
+		}
+
+		return vt.value(rv.Elem(), depth+1)
+	case reflect.Struct:
+		fields := structFields(rv.Type(), "json")
+		vv := &viewValue{
+			name:   rv.Type().Name(),
+			labels: make([]string, 0, len(fields)),
+			values: make([]any, 0, len(fields)),
+		}
+		for _, f := range fields {
+			fv := f.value(rv)
+			if !fv.IsValid() || (f.hasOption("omitempty") && isEmpty(fv)) {
+				continue
+			}
+			vv.labels = append(vv.labels, f.name)
+			vv.values = append(vv.values, vt.value(fv, depth+1))
+		}
+
+		return vv
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return rv.Interface()
+		}
+		if rv.IsNil() {
+			return map[string]any(nil)
+		}
+
+		m := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			m[k] = vt.value(iter.Value(), depth+1)
+		}
+
+		return m
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface()
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return []any(nil)
+		}
+
+		s := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			s = append(s, vt.value(rv.Index(i), depth+1))
+		}
+
+		return s
+	}
+
+	return rv.Interface()
+}
+
+// view returns a copy of vv with transformed values.
+func (vt *valueTransformer) view(vv *viewValue, depth int) *viewValue {
+	nv := &viewValue{
+		name:   vv.name,
+		labels: vv.labels,
+		values: make([]any, 0, len(vv.values)),
+	}
+	for _, v := range vv.values {
+		nv.values = append(nv.values, vt.value(reflect.ValueOf(v), depth+1))
+	}
+
+	return nv
+}
+
+// isEmpty reports if rv is empty as defined by the "omitempty" option of
+// encoding/json.
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return rv.IsZero()
+	}
+
+	return false
+}
+
+// marshals reports if values of type t, or pointers to them, implement their
+// own marshaling for any of the common formats.
+func marshals(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+
+	for _, mt := range []reflect.Type{
+		jsonMarshalerType, yamlMarshalerType,
+		xmlMarshalerType, textMarshalerType,
+	} {
+		if t.Implements(mt) || reflect.PointerTo(t).Implements(mt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structProjection is an unnamed struct type derived from a named or unnamed
+// source struct type, with a subset of its exported fields, which may have
+// different types and struct tags. Fields promoted from embedded structs are
+// included as regular fields. Unlike a view, values of the projection are
+// plain structs, so every format renders them with its own struct tags.
+type structProjection struct {
+	typ reflect.Type
+
+	// index holds the index sequence within the source type of each field of
+	// typ.
+	index [][]int
+
+	// root is typ with a XMLName field which names the XML element after the
+	// source type, as the projection is unnamed. The XMLName field is added
+	// as the first field, or replaces an untagged XMLName field of typ. It is
+	// used for top-level values, while nested values are named after their
+	// field. It is nil if the source type is unnamed, or has a XMLName field
+	// with an element name in its tag.
+	root reflect.Type
+
+	// rootOffset is the index of the first field of typ within root.
+	rootOffset int
+}
+
+// newStructProjection returns a projection of the struct type t. The field
+// function is called with each exported field of t, except XMLName fields
+// which are always kept as is. It returns the field as it should be in the
+// projection, and false if it should be omitted.
+func newStructProjection(
+	t reflect.Type,
+	field func(f reflect.StructField) (reflect.StructField, bool),
+) *structProjection {
+	sp := &structProjection{}
+
+	var fields []reflect.StructField
+	xmlNameField := -1
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && isStructType(f.Type)) {
+			continue
+		}
+
+		index := f.Index
+		if f.Name == "XMLName" {
+			xmlNameField = len(fields)
+		}
+		if !isXMLName(f) {
+			var ok bool
+			if f, ok = field(f); !ok {
+				continue
+			}
+		}
+
+		sp.index = append(sp.index, index)
+		fields = append(fields, reflect.StructField{
+			Name: f.Name,
+			Type: f.Type,
+			Tag:  f.Tag,
+		})
+	}
+	sp.typ = reflect.StructOf(fields)
+
+	name, _, _ := strings.Cut(t.Name(), "[")
+	switch {
+	case name == "":
+	case xmlNameField == -1:
+		sp.rootOffset = 1
+		sp.root = reflect.StructOf(append([]reflect.StructField{{
+			Name: "XMLName",
+			Type: xmlNameType,
+			Tag:  reflect.StructTag(`xml:"` + name + `" json:"-" yaml:"-"`),
+		}}, fields...))
+	default:
+		f := fields[xmlNameField]
+		tag, hasTag := f.Tag.Lookup("xml")
+		if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+			break
+		}
+
+		rootFields := append([]reflect.StructField{}, fields...)
+		if hasTag {
+			rootFields[xmlNameField].Tag = reflect.StructTag(strings.Replace(
+				string(f.Tag), `xml:"`+tag+`"`, `xml:"`+name+tag+`"`, 1,
+			))
+		} else {
+			rootFields[xmlNameField].Tag = reflect.StructTag(
+				strings.TrimSpace(`xml:"` + name + `" ` + string(f.Tag)),
+			)
+		}
+		sp.root = reflect.StructOf(rootFields)
+	}
+
+	return sp
+}
+
+// value returns a value of the projection with the fields of the struct rv,
+// which must be of the source type. If convert is not nil, it is called with
+// the index and value of each field, except XMLName fields, and returns the
+// value to set. Fields promoted through nil embedded pointers are left empty.
+func (sp *structProjection) value(
+	rv reflect.Value,
+	convert func(i int, fv reflect.Value) reflect.Value,
+) reflect.Value {
+	nv := reflect.New(sp.typ).Elem()
+	for i, index := range sp.index {
+		fv, err := rv.FieldByIndexErr(index)
+		if err != nil || !fv.CanInterface() {
+			continue
+		}
+		if convert != nil && fv.Type() != xmlNameType {
+			fv = convert(i, fv)
+		}
+		nv.Field(i).Set(fv)
+	}
+
+	return nv
+}
+
+// rootType returns the type t with projections replaced by their root type,
+// if t is a projection, a pointer to one, or a slice or array of them. The
+// projection function returns the projection of a type, or nil if it is not
+// one.
+func rootType(
+	t reflect.Type,
+	projection func(t reflect.Type) *structProjection,
+) reflect.Type {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		if sp := projection(t); sp != nil && sp.root != nil {
+			return sp.root
+		}
+	case reflect.Pointer:
+		if et := rootType(t.Elem(), projection); et != t.Elem() {
+			return reflect.PointerTo(et)
+		}
+	case reflect.Slice, reflect.Array:
+		et := rootType(t.Elem(), projection)
+		switch {
+		case et == t.Elem():
+		case t.Kind() == reflect.Array:
+			return reflect.ArrayOf(t.Len(), et)
+		default:
+			return reflect.SliceOf(et)
+		}
+	}
+
+	return t
+}
+
+// rootValue returns rv converted to its rootType, so top-level projections,
+// and the elements of top-level slices and arrays, are rendered as XML
+// elements named after their source type. Elements of slices and arrays of
+// interfaces are converted too.
+func rootValue(
+	rv reflect.Value,
+	projection func(t reflect.Type) *structProjection,
+) reflect.Value {
+	return rootValueDepth(rv, projection, 0)
+}
+
+func rootValueDepth(
+	rv reflect.Value,
+	projection func(t reflect.Type) *structProjection,
+	depth int,
+) reflect.Value {
+	if !rv.IsValid() {
+		return rv
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		sp := projection(rv.Type())
+		if sp == nil || sp.root == nil {
+			return rv
+		}
+
+		nv := reflect.New(sp.root).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			nv.Field(i + sp.rootOffset).Set(rv.Field(i))
+		}
+
+		return nv
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return reflect.Zero(rootType(rv.Type(), projection))
+		}
+
+		ev := rootValueDepth(rv.Elem(), projection, depth)
+		if ev.Type() == rv.Type().Elem() {
+			return rv
+		}
+
+		p := reflect.New(ev.Type())
+		p.Elem().Set(ev)
+
+		return p
+	case reflect.Slice, reflect.Array:
+		if depth > 0 || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+			return rv
+		}
+
+		t := rootType(rv.Type(), projection)
+		if t == rv.Type() && t.Elem().Kind() != reflect.Interface {
+			return rv
+		}
+
+		var s reflect.Value
+		if rv.Kind() == reflect.Slice {
+			s = reflect.MakeSlice(t, rv.Len(), rv.Len())
+		} else {
+			s = reflect.New(t).Elem()
+		}
+		for i := 0; i < rv.Len(); i++ {
+			ev := rv.Index(i)
+			if ev.Kind() == reflect.Interface && !ev.IsNil() {
+				ev = ev.Elem()
+			}
+			s.Index(i).Set(rootValueDepth(ev, projection, depth+1))
+		}
+
+		return s
+	}
+
+	return rv
+}
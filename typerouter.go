@@ -0,0 +1,135 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// TypeRoute maps a type to the Handler used to render values of that type
+// within a TypeRouter.
+type TypeRoute struct {
+	// Type is the type of values the route applies to. If Type is an interface
+	// type, the route applies to all values which implement it.
+	Type reflect.Type
+
+	// Handler is the Handler used to render matching values.
+	Handler Handler
+}
+
+// Route returns a TypeRoute for type T, which may be an interface type.
+//
+//	render.Route[[]Event](&render.JSON{})
+//	render.Route[fmt.Stringer](&render.Text{})
+func Route[T any](h Handler) TypeRoute {
+	return TypeRoute{Type: reflect.TypeOf((*T)(nil)).Elem(), Handler: h}
+}
+
+// TypeRouter is a Handler that delegates rendering to child handlers based on
+// the type of the value being rendered. This enables rich per-type
+// presentation of values under a single format, for example rendering lists
+// as tables, and single items as key/value pairs in the "text" format.
+//
+// Routes are matched in the following order:
+//
+//  1. A route with the exact type of the value.
+//  2. If the value is a pointer, a route with the exact type pointed to.
+//  3. The first route with an interface type implemented by the value.
+//
+// If no route matches, the Default handler is used. If Default is nil, a
+// ErrCannotRender error is returned.
+type TypeRouter struct {
+	// Routes is the list of type routes.
+	Routes []TypeRoute
+
+	// Default is the Handler used for values which match no route.
+	Default Handler
+}
+
+var (
+	_ Handler        = (*TypeRouter)(nil)
+	_ PrettyHandler  = (*TypeRouter)(nil)
+	_ OptionsHandler = (*TypeRouter)(nil)
+)
+
+// Render renders v with the Handler of the route matching the type of v.
+func (tr *TypeRouter) Render(w io.Writer, v any) error {
+	h := tr.route(v)
+	if h == nil {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	return h.Render(w, v)
+}
+
+// RenderPretty renders v with the Handler of the route matching the type of v.
+// If the handler implements PrettyHandler, the RenderPretty method is used
+// instead of Render.
+func (tr *TypeRouter) RenderPretty(w io.Writer, v any) error {
+	h := tr.route(v)
+	if h == nil {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	if x, ok := h.(PrettyHandler); ok {
+		return x.RenderPretty(w, v)
+	}
+
+	return h.Render(w, v)
+}
+
+// WithOptions returns a copy of the TypeRouter, with the options applied to
+// all route and default handlers which implement OptionsHandler.
+func (tr *TypeRouter) WithOptions(opts *Options) Handler {
+	withOptions := func(h Handler) Handler {
+		if x, ok := h.(OptionsHandler); ok {
+			return x.WithOptions(opts)
+		}
+
+		return h
+	}
+
+	c := &TypeRouter{
+		Routes:  make([]TypeRoute, 0, len(tr.Routes)),
+		Default: withOptions(tr.Default),
+	}
+	for _, r := range tr.Routes {
+		c.Routes = append(c.Routes, TypeRoute{
+			Type:    r.Type,
+			Handler: withOptions(r.Handler),
+		})
+	}
+
+	return c
+}
+
+// route returns the Handler to use for v.
+func (tr *TypeRouter) route(v any) Handler {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return tr.Default
+	}
+
+	for _, r := range tr.Routes {
+		if r.Type == t {
+			return r.Handler
+		}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		for _, r := range tr.Routes {
+			if r.Type == t.Elem() {
+				return r.Handler
+			}
+		}
+	}
+
+	for _, r := range tr.Routes {
+		if r.Type != nil && r.Type.Kind() == reflect.Interface &&
+			t.Implements(r.Type) {
+			return r.Handler
+		}
+	}
+
+	return tr.Default
+}
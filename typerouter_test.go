@@ -0,0 +1,164 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typeRouterTestItem struct {
+	Name string
+}
+
+func TestRoute(t *testing.T) {
+	h := &mockHandler{}
+
+	got := Route[fmt.Stringer](h)
+
+	assert.Equal(t, "Stringer", got.Type.Name())
+	assert.Equal(t, "fmt", got.Type.PkgPath())
+	assert.Same(t, h, got.Handler)
+}
+
+func TestTypeRouter(t *testing.T) {
+	router := &TypeRouter{
+		Routes: []TypeRoute{
+			Route[[]typeRouterTestItem](&mockHandler{output: "list"}),
+			Route[typeRouterTestItem](&mockPrettyHandler{
+				output:       "item",
+				prettyOutput: "pretty item",
+			}),
+			Route[fmt.Stringer](&mockHandler{output: "stringer"}),
+			Route[error](&mockHandler{output: "error"}),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		router     *TypeRouter
+		value      any
+		want       string
+		wantPretty string
+		wantErr    string
+		wantErrIs  []error
+	}{
+		{
+			name:       "exact slice type",
+			router:     router,
+			value:      []typeRouterTestItem{},
+			want:       "list",
+			wantPretty: "list",
+		},
+		{
+			name:       "exact struct type",
+			router:     router,
+			value:      typeRouterTestItem{},
+			want:       "item",
+			wantPretty: "pretty item",
+		},
+		{
+			name:       "pointer to routed type",
+			router:     router,
+			value:      &typeRouterTestItem{},
+			want:       "item",
+			wantPretty: "pretty item",
+		},
+		{
+			name:       "first matching interface",
+			router:     router,
+			value:      &mockStringer{},
+			want:       "stringer",
+			wantPretty: "stringer",
+		},
+		{
+			name:       "second matching interface",
+			router:     router,
+			value:      errors.New("oops"),
+			want:       "error",
+			wantPretty: "error",
+		},
+		{
+			name:      "no matching route without default",
+			router:    router,
+			value:     42,
+			wantErr:   "render: cannot render: int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "nil without default",
+			router:    router,
+			value:     nil,
+			wantErr:   "render: cannot render: <nil>",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name: "no matching route with default",
+			router: &TypeRouter{
+				Routes:  router.Routes,
+				Default: &mockHandler{output: "default"},
+			},
+			value:      42,
+			want:       "default",
+			wantPretty: "default",
+		},
+	}
+	for _, tt := range tests {
+		for _, pretty := range []bool{false, true} {
+			name := tt.name
+			if pretty {
+				name = "pretty " + name
+			}
+
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+				var err error
+
+				want := tt.want
+				if pretty {
+					want = tt.wantPretty
+					err = tt.router.RenderPretty(&buf, tt.value)
+				} else {
+					err = tt.router.Render(&buf, tt.value)
+				}
+
+				if tt.wantErr != "" {
+					assert.EqualError(t, err, tt.wantErr)
+				}
+				for _, e := range tt.wantErrIs {
+					assert.ErrorIs(t, err, e)
+				}
+
+				if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+					assert.NoError(t, err)
+					assert.Equal(t, want, buf.String())
+				}
+			})
+		}
+	}
+}
+
+func TestTypeRouter_WithOptions(t *testing.T) {
+	plain := &mockHandler{}
+	tr := &TypeRouter{
+		Routes: []TypeRoute{
+			Route[string](&JSON{}),
+			Route[int](plain),
+		},
+		Default: &XML{},
+	}
+
+	got := tr.WithOptions(&Options{Indent: "\t"})
+
+	assert.Equal(t, &TypeRouter{
+		Routes: []TypeRoute{
+			Route[string](&JSON{Indent: "\t"}),
+			Route[int](plain),
+		},
+		Default: &XML{Indent: "\t"},
+	}, got)
+	assert.Equal(t, &JSON{}, tr.Routes[0].Handler)
+	assert.Equal(t, &XML{}, tr.Default)
+}
@@ -0,0 +1,52 @@
+package render
+
+import "reflect"
+
+// RegisterType registers h as the Handler used to render values of type T, and
+// pointers to T, with the given Renderer. It is used in place of the Handler
+// of the format given to Render, for every supported format. This allows
+// customizing how specific domain types are rendered, without having to wrap
+// every format Handler.
+//
+// Options given to Render are applied to h if it implements OptionsHandler,
+// and pretty rendering is used if it implements PrettyHandler.
+//
+// Registering a Handler for a type which already has one replaces it. A nil
+// Handler removes the registration.
+func RegisterType[T any](r *Renderer, h Handler) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	if h == nil {
+		delete(r.types, t)
+
+		return
+	}
+
+	if r.types == nil {
+		r.types = map[reflect.Type]Handler{}
+	}
+	r.types[t] = h
+}
+
+// typeHandler returns the Handler registered with RegisterType for the type of
+// v, or the type pointed to by v. It returns nil if there is none.
+func (r *Renderer) typeHandler(v any) Handler {
+	if len(r.types) == 0 {
+		return nil
+	}
+
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil
+	}
+
+	if h, ok := r.types[t]; ok {
+		return h
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return r.types[t.Elem()]
+	}
+
+	return nil
+}
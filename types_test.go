@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typesTestMoney struct {
+	Cents int
+}
+
+func TestRegisterType(t *testing.T) {
+	r := New(map[string]Handler{
+		"json": &JSON{},
+		"yaml": &YAML{},
+	})
+	RegisterType[typesTestMoney](r, &mockPrettyHandler{
+		output:       "money",
+		prettyOutput: "pretty money",
+	})
+
+	tests := []struct {
+		name    string
+		format  string
+		pretty  bool
+		value   any
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "registered type",
+			format: "json",
+			value:  typesTestMoney{Cents: 100},
+			want:   "money",
+		},
+		{
+			name:   "pointer to registered type",
+			format: "yaml",
+			value:  &typesTestMoney{Cents: 100},
+			want:   "money",
+		},
+		{
+			name:   "pretty",
+			format: "json",
+			pretty: true,
+			value:  typesTestMoney{Cents: 100},
+			want:   "pretty money",
+		},
+		{
+			name:   "other type",
+			format: "json",
+			value:  map[string]int{"cents": 100},
+			want:   "{\"cents\":100}\n",
+		},
+		{
+			name:   "slice of registered type",
+			format: "json",
+			value:  []typesTestMoney{{Cents: 100}},
+			want:   "[{\"Cents\":100}]\n",
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			value:   typesTestMoney{Cents: 100},
+			wantErr: "render: unsupported format: xml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := r.Render(&buf, tt.format, tt.pretty, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRegisterType_remove(t *testing.T) {
+	r := New(map[string]Handler{"json": &JSON{}})
+	RegisterType[typesTestMoney](r, &mockHandler{output: "money"})
+
+	nr := r.NewWith("json")
+	RegisterType[typesTestMoney](r, nil)
+
+	got, err := r.String("json", false, typesTestMoney{Cents: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"Cents\":1}\n", got)
+
+	got, err = nr.String("json", false, typesTestMoney{Cents: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "money", got)
+}
@@ -0,0 +1,184 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VCard is a Handler that renders contact-shaped structs, and slices or arrays
+// of them, as vCard 4.0 (RFC 6350) documents.
+//
+// Only exported fields with a "vcard" struct tag are rendered, with the tag
+// specifying the vCard property name, for example `vcard:"email"`. Property
+// parameters can be given as tag options, for example
+// `vcard:"tel,type=cell"`.
+//
+// Field values are formatted based on their type:
+//
+//   - []string values of the structured N, ADR, and ORG properties are joined
+//     with semicolons, values of CATEGORIES and NICKNAME are joined with
+//     commas, and all other properties are repeated once per value.
+//   - time.Time values of BDAY and ANNIVERSARY properties are written as
+//     dates, and all others as UTC timestamps. Zero times are skipped.
+//   - Strings, fmt.Stringer values, numbers and bools are written as text.
+//
+// Empty strings and nil values are skipped. Every contact must have a FN
+// property, otherwise a ErrFailed error is returned.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type VCard struct{}
+
+var (
+	_ Handler            = (*VCard)(nil)
+	_ FormatsHandler     = (*VCard)(nil)
+	_ DescribedHandler   = (*VCard)(nil)
+	_ ContentTypeHandler = (*VCard)(nil)
+)
+
+// Render writes v as a vCard document to w.
+func (vc *VCard) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	var fields []structField
+	for _, f := range structFields(t, "vcard") {
+		if _, ok := t.FieldByIndex(f.index).Tag.Lookup("vcard"); ok {
+			fields = append(fields, f)
+		}
+	}
+
+	var buf strings.Builder
+	for n, row := range rows {
+		hasFN := false
+
+		writeContentLine(&buf, "BEGIN", "VCARD")
+		writeContentLine(&buf, "VERSION", "4.0")
+		for _, f := range fields {
+			prop := strings.ToUpper(f.name)
+			name := prop + vcardParams(f)
+
+			for _, value := range vcardValues(prop, f.value(row)) {
+				if prop == "FN" {
+					hasFN = true
+				}
+				writeContentLine(&buf, name, value)
+			}
+		}
+		writeContentLine(&buf, "END", "VCARD")
+
+		if !hasFN {
+			return fmt.Errorf("%w: vcard: contact %d has no FN", ErrFailed, n+1)
+		}
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (vc *VCard) Formats() []string {
+	return []string{"vcf", "vcard"}
+}
+
+// Description returns a short human-readable description of the format.
+func (vc *VCard) Description() string {
+	return "vCard 4.0 contacts"
+}
+
+// ContentType returns the MIME type of the format.
+func (vc *VCard) ContentType() string {
+	return "text/vcard; charset=utf-8"
+}
+
+// vcardParams returns the property parameters from the struct tag options of
+// the given field, in the form ";KEY=value", sorted by key.
+func vcardParams(f structField) string {
+	keys := make([]string, 0, len(f.options))
+	for k := range f.options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(";" + strings.ToUpper(k))
+		if v := f.options[k]; v != "" {
+			b.WriteString("=" + v)
+		}
+	}
+
+	return b.String()
+}
+
+// vcardValues returns the escaped values of a property for the given field
+// value. Each returned value is written as a separate content line.
+func vcardValues(prop string, rv reflect.Value) []string {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return nil
+	}
+
+	var value string
+	switch x := rv.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return nil
+		}
+		if prop == "BDAY" || prop == "ANNIVERSARY" {
+			return []string{x.Format("20060102")}
+		}
+
+		return []string{x.UTC().Format("20060102T150405Z")}
+	case []string:
+		if len(x) == 0 {
+			return nil
+		}
+
+		values := make([]string, 0, len(x))
+		for _, s := range x {
+			values = append(values, escapeText(s))
+		}
+
+		switch prop {
+		case "N", "ADR", "ORG":
+			return []string{strings.Join(values, ";")}
+		case "CATEGORIES", "NICKNAME":
+			return []string{strings.Join(values, ",")}
+		default:
+			return values
+		}
+	case fmt.Stringer:
+		value = x.String()
+	case bool:
+		value = strconv.FormatBool(x)
+	default:
+		switch rv.Kind() { //nolint:exhaustive
+		case reflect.String:
+			value = rv.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64, reflect.Float32,
+			reflect.Float64:
+			value = fmt.Sprint(x)
+		default:
+			return nil
+		}
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	return []string{escapeText(value)}
+}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type vcardTestContact struct {
+	Name      string    `vcard:"fn"`
+	Parts     []string  `vcard:"n"`
+	Email     string    `vcard:"email,type=work"`
+	Phones    []string  `vcard:"tel,type=cell,pref=1"`
+	Nicknames []string  `vcard:"nickname"`
+	Birthday  time.Time `vcard:"bday"`
+	Updated   time.Time `vcard:"rev"`
+	Note      *string   `vcard:"note"`
+	Internal  string
+}
+
+func TestVCard_Render(t *testing.T) {
+	note := "Likes; commas, and\nnewlines"
+
+	tests := []struct {
+		name      string
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name: "slice of contacts",
+			value: []*vcardTestContact{
+				{
+					Name:      "John Doe",
+					Parts:     []string{"Doe", "John", "", "Mr.", ""},
+					Email:     "john@example.com",
+					Phones:    []string{"+1 555 0100", "+1 555 0101"},
+					Nicknames: []string{"Johnny", "JD"},
+					Birthday:  time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+					Updated: time.Date(
+						2024, 3, 25, 12, 0, 0, 0, time.UTC,
+					),
+					Note:     &note,
+					Internal: "not rendered",
+				},
+				{Name: "Jane Doe"},
+			},
+			want: crlf(
+				"BEGIN:VCARD",
+				"VERSION:4.0",
+				"FN:John Doe",
+				"N:Doe;John;;Mr.;",
+				"EMAIL;TYPE=work:john@example.com",
+				"TEL;PREF=1;TYPE=cell:+1 555 0100",
+				"TEL;PREF=1;TYPE=cell:+1 555 0101",
+				"NICKNAME:Johnny,JD",
+				"BDAY:19900517",
+				"REV:20240325T120000Z",
+				`NOTE:Likes\; commas\, and\nnewlines`,
+				"END:VCARD",
+				"BEGIN:VCARD",
+				"VERSION:4.0",
+				"FN:Jane Doe",
+				"END:VCARD",
+			),
+		},
+		{
+			name:  "single contact",
+			value: vcardTestContact{Name: "John Doe"},
+			want: crlf(
+				"BEGIN:VCARD",
+				"VERSION:4.0",
+				"FN:John Doe",
+				"END:VCARD",
+			),
+		},
+		{
+			name:      "missing FN",
+			value:     []vcardTestContact{{Email: "john@example.com"}},
+			wantErr:   "render: failed: vcard: contact 1 has no FN",
+			wantErrIs: []error{Err, ErrFailed},
+		},
+		{
+			name:      "not struct based",
+			value:     map[string]string{"fn": "John Doe"},
+			wantErr:   "render: cannot render: map[string]string",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &VCard{}
+			w := &mockWriter{}
+
+			err := vc.Render(w, tt.value)
+			got := w.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVCard_Render_writeError(t *testing.T) {
+	vc := &VCard{}
+	w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+	err := vc.Render(w, vcardTestContact{Name: "John Doe"})
+
+	assert.EqualError(t, err, "render: failed: write error!!1")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestVCard_Formats(t *testing.T) {
+	h := &VCard{}
+
+	assert.Equal(t, []string{"vcf", "vcard"}, h.Formats())
+}
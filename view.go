@@ -0,0 +1,329 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownView is returned when a view is requested which has not been
+// registered for the type of the value being rendered.
+var ErrUnknownView = fmt.Errorf("%w: unknown view", Err)
+
+// View is a named projection of a type. It selects which fields of the type are
+// rendered, what they are labeled as, and in which order they appear. This
+// allows decoupling the structs used for data, from how they are presented.
+//
+// Views are registered for a specific type with RegisterView, and selected by
+// name at render time with Renderer.RenderView.
+type View struct {
+	// Name is the name used to select the view at render time.
+	Name string
+
+	// Fields is the list of fields included in the view, in the order they
+	// will be rendered.
+	Fields []ViewField
+}
+
+// ViewField describes a single field within a View.
+type ViewField struct {
+	// Field is the name of the struct field, or map key, to read the value
+	// from. Nested fields can be selected using dot notation, for example
+	// "Author.Name".
+	Field string
+
+	// Label is the key used for the field in the rendered output. If empty,
+	// Field is used instead.
+	Label string
+}
+
+func (vf ViewField) label() string {
+	if vf.Label != "" {
+		return vf.Label
+	}
+
+	return vf.Field
+}
+
+// RegisterView registers one or more views for type T on the given Renderer.
+// Views are also used for slices and arrays of T, and pointers to T.
+//
+// Registering a view with the same name as an existing view for T replaces the
+// existing view.
+func RegisterView[T any](r *Renderer, views ...*View) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	if r.views == nil {
+		r.views = map[reflect.Type]map[string]*View{}
+	}
+	if r.views[t] == nil {
+		r.views[t] = map[string]*View{}
+	}
+
+	for _, view := range views {
+		r.views[t][strings.ToLower(view.Name)] = view
+	}
+}
+
+// RenderView renders a projection of v, as defined by the named view, to the
+// given io.Writer using the specified format.
+//
+// The view must have been registered with RegisterView for the type of v, or
+// for the element type if v is a slice or array. Otherwise a ErrUnknownView
+// error is returned.
+//
+// If a Redactor is set, v is redacted before the view is applied, so redacted
+// fields are never included in the view.
+func (r *Renderer) RenderView(
+	w io.Writer,
+	format string,
+	view string,
+	pretty bool,
+	v any,
+) error {
+	if r.Redactor != nil {
+		v = r.Redactor.redact(v)
+	}
+
+	pv, err := r.project(view, v)
+	if err != nil {
+		return err
+	}
+
+	return r.Render(w, format, pretty, pv)
+}
+
+// project returns a viewValue or viewList for v based on the named view.
+func (r *Renderer) project(name string, v any) (any, error) {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("%w: %s: %T", ErrUnknownView, name, v)
+	}
+
+	if view := r.lookupView(rv.Type(), name); view != nil {
+		return newViewValue(view, rv)
+	}
+
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: %s: %T", ErrUnknownView, name, v)
+	}
+
+	et := rv.Type().Elem()
+	for et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+
+	view := r.lookupView(et, name)
+	if view == nil {
+		return nil, fmt.Errorf("%w: %s: %T", ErrUnknownView, name, v)
+	}
+
+	list := make(viewList, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		vv, err := newViewValue(view, indirect(rv.Index(i)))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, vv)
+	}
+
+	return list, nil
+}
+
+func (r *Renderer) lookupView(t reflect.Type, name string) *View {
+	if r.views == nil {
+		return nil
+	}
+
+	return r.views[t][strings.ToLower(name)]
+}
+
+// indirect dereferences pointers and interfaces until it reaches a non-pointer
+// value, or a nil pointer.
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+
+	return rv
+}
+
+// viewValue is the projection of a single value by a View. It retains the
+// field order of the view when rendered to JSON, YAML, XML, and text.
+type viewValue struct {
+	name   string
+	labels []string
+	values []any
+}
+
+var (
+	_ json.Marshaler = (*viewValue)(nil)
+	_ yaml.Marshaler = (*viewValue)(nil)
+	_ xml.Marshaler  = (*viewValue)(nil)
+	_ fmt.Stringer   = (*viewValue)(nil)
+)
+
+func newViewValue(view *View, rv reflect.Value) (*viewValue, error) {
+	vv := &viewValue{
+		name:   view.Name,
+		labels: make([]string, 0, len(view.Fields)),
+		values: make([]any, 0, len(view.Fields)),
+	}
+
+	for _, f := range view.Fields {
+		fv, err := viewFieldValue(rv, f.Field)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: view %s: %w", ErrFailed, view.Name, err,
+			)
+		}
+
+		vv.labels = append(vv.labels, f.label())
+		vv.values = append(vv.values, fv)
+	}
+
+	return vv, nil
+}
+
+// viewFieldValue returns the value of the dot separated field path within rv.
+// A nil pointer anywhere along the path results in a nil value.
+func viewFieldValue(rv reflect.Value, path string) (any, error) {
+	for _, name := range strings.Split(path, ".") {
+		rv = indirect(rv)
+		if !rv.IsValid() {
+			return nil, nil
+		}
+
+		switch rv.Kind() { //nolint:exhaustive
+		case reflect.Struct:
+			sf, ok := rv.Type().FieldByName(name)
+			if !ok || !sf.IsExported() {
+				return nil, fmt.Errorf(
+					"no field %q in type %s", name, rv.Type(),
+				)
+			}
+			rv = rv.FieldByIndex(sf.Index)
+		case reflect.Map:
+			if rv.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf(
+					"cannot select %q from type %s", name, rv.Type(),
+				)
+			}
+			rv = rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !rv.IsValid() {
+				return nil, nil
+			}
+		default:
+			return nil, fmt.Errorf(
+				"cannot select %q from type %s", name, rv.Type(),
+			)
+		}
+	}
+
+	return rv.Interface(), nil
+}
+
+// MarshalJSON renders the view as a JSON object with fields in view order.
+// HTML characters are not escaped, as the JSON encoder calling MarshalJSON
+// escapes them itself unless escaping is disabled, like with the NoEscapeHTML
+// field of the JSON handler.
+func (vv *viewValue) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, label := range vv.labels {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := enc.Encode(label); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+		buf.WriteByte(':')
+
+		if err := enc.Encode(vv.values[i]); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML renders the view as a YAML mapping with fields in view order.
+func (vv *viewValue) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for i, label := range vv.labels {
+		key := &yaml.Node{}
+		if err := key.Encode(label); err != nil {
+			return nil, err
+		}
+
+		value := &yaml.Node{}
+		if err := value.Encode(vv.values[i]); err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, key, value)
+	}
+
+	return node, nil
+}
+
+// MarshalXML renders the view as an element named after the view, with a child
+// element for each field in view order. Attributes of start are kept.
+func (vv *viewValue) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: vv.name}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for i, label := range vv.labels {
+		err := xmlElement(e, label, reflect.ValueOf(vv.values[i]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// String renders the view as "label: value" lines in view order.
+func (vv *viewValue) String() string {
+	var buf strings.Builder
+
+	for i, label := range vv.labels {
+		fmt.Fprintf(&buf, "%s: %v\n", label, vv.values[i])
+	}
+
+	return buf.String()
+}
+
+// viewList is the projection of a slice or array of values by a View.
+type viewList []*viewValue
+
+var _ fmt.Stringer = (viewList)(nil)
+
+// String renders each view in the list as text, separated by blank lines.
+func (vl viewList) String() string {
+	s := make([]string, 0, len(vl))
+	for _, vv := range vl {
+		s = append(s, vv.String())
+	}
+
+	return strings.Join(s, "\n")
+}
@@ -0,0 +1,303 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type viewTestAuthor struct {
+	Name  string
+	Email string
+}
+
+type viewTestPost struct {
+	ID     int
+	Title  string
+	Body   string
+	Author *viewTestAuthor
+}
+
+func newViewTestRenderer() *Renderer {
+	r := Base.NewWith("json", "yaml", "xml", "text")
+
+	RegisterView[viewTestPost](r,
+		&View{
+			Name: "summary",
+			Fields: []ViewField{
+				{Field: "Title", Label: "title"},
+				{Field: "ID", Label: "id"},
+			},
+		},
+		&View{
+			Name: "Author",
+			Fields: []ViewField{
+				{Field: "Title"},
+				{Field: "Author.Name", Label: "author"},
+			},
+		},
+	)
+	RegisterView[map[string]any](r, &View{
+		Name:   "summary",
+		Fields: []ViewField{{Field: "name"}, {Field: "missing"}},
+	})
+
+	return r
+}
+
+func TestRenderer_RenderView(t *testing.T) {
+	post := &viewTestPost{
+		ID:     42,
+		Title:  "Hello",
+		Body:   "World",
+		Author: &viewTestAuthor{Name: "John", Email: "john@example.com"},
+	}
+
+	tests := []struct {
+		name      string
+		format    string
+		view      string
+		pretty    bool
+		value     any
+		want      string
+		wantErr   string
+		wantErrIs []error
+	}{
+		{
+			name:   "json",
+			format: "json",
+			view:   "summary",
+			value:  post,
+			want:   `{"title":"Hello","id":42}` + "\n",
+		},
+		{
+			name:   "pretty json",
+			format: "json",
+			view:   "summary",
+			pretty: true,
+			value:  post,
+			want:   "{\n  \"title\": \"Hello\",\n  \"id\": 42\n}\n",
+		},
+		{
+			name:   "yaml",
+			format: "yaml",
+			view:   "summary",
+			value:  post,
+			want:   "title: Hello\nid: 42\n",
+		},
+		{
+			name:   "xml",
+			format: "xml",
+			view:   "summary",
+			value:  post,
+			want:   "<summary><title>Hello</title><id>42</id></summary>",
+		},
+		{
+			name:   "text",
+			format: "text",
+			view:   "summary",
+			value:  post,
+			want:   "title: Hello\nid: 42\n",
+		},
+		{
+			name:   "non-pointer value",
+			format: "json",
+			view:   "summary",
+			value:  *post,
+			want:   `{"title":"Hello","id":42}` + "\n",
+		},
+		{
+			name:   "nested field and case-insensitive view name",
+			format: "json",
+			view:   "author",
+			value:  post,
+			want:   `{"Title":"Hello","author":"John"}` + "\n",
+		},
+		{
+			name:   "nested field with nil pointer",
+			format: "json",
+			view:   "author",
+			value:  &viewTestPost{Title: "Hello"},
+			want:   `{"Title":"Hello","author":null}` + "\n",
+		},
+		{
+			name:   "slice",
+			format: "json",
+			view:   "summary",
+			value:  []*viewTestPost{post, {ID: 43, Title: "Bye"}},
+			want: `[{"title":"Hello","id":42},{"title":"Bye","id":43}]` +
+				"\n",
+		},
+		{
+			name:   "slice as yaml",
+			format: "yaml",
+			view:   "summary",
+			value:  []viewTestPost{*post, {ID: 43, Title: "Bye"}},
+			want:   "- title: Hello\n  id: 42\n- title: Bye\n  id: 43\n",
+		},
+		{
+			name:   "slice as text",
+			format: "text",
+			view:   "summary",
+			value:  []viewTestPost{*post, {ID: 43, Title: "Bye"}},
+			want:   "title: Hello\nid: 42\n\ntitle: Bye\nid: 43\n",
+		},
+		{
+			name:   "map",
+			format: "json",
+			view:   "summary",
+			value:  map[string]any{"name": "foo", "other": "bar"},
+			want:   `{"name":"foo","missing":null}` + "\n",
+		},
+		{
+			name:      "unknown view",
+			format:    "json",
+			view:      "nope",
+			value:     post,
+			wantErr:   "render: unknown view: nope: *render.viewTestPost",
+			wantErrIs: []error{Err, ErrUnknownView},
+		},
+		{
+			name:      "unregistered type",
+			format:    "json",
+			view:      "summary",
+			value:     struct{}{},
+			wantErr:   "render: unknown view: summary: struct {}",
+			wantErrIs: []error{Err, ErrUnknownView},
+		},
+		{
+			name:      "nil value",
+			format:    "json",
+			view:      "summary",
+			value:     nil,
+			wantErr:   "render: unknown view: summary: <nil>",
+			wantErrIs: []error{Err, ErrUnknownView},
+		},
+		{
+			name:      "unsupported format",
+			format:    "binary",
+			view:      "summary",
+			value:     post,
+			wantErr:   "render: unsupported format: binary",
+			wantErrIs: []error{Err, ErrUnsupportedFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newViewTestRenderer()
+			var buf bytes.Buffer
+
+			err := r.RenderView(&buf, tt.format, tt.view, tt.pretty, tt.value)
+			got := buf.String()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderer_RenderView_invalidField(t *testing.T) {
+	r := Base.NewWith("json")
+	RegisterView[viewTestPost](r, &View{
+		Name:   "broken",
+		Fields: []ViewField{{Field: "Nope"}},
+	})
+
+	var buf bytes.Buffer
+	err := r.RenderView(&buf, "json", "broken", false, &viewTestPost{})
+
+	assert.EqualError(t, err,
+		"render: failed: view broken: "+
+			`no field "Nope" in type render.viewTestPost`,
+	)
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.Empty(t, buf.String())
+}
+
+type viewTestUser struct {
+	Name     string
+	Password string `json:"pw" render:"redact"`
+	Token    string
+}
+
+func TestRenderer_RenderView_redactor(t *testing.T) {
+	r := Base.NewWith("json")
+	r.Redactor = &Redactor{Fields: []string{"token"}}
+	RegisterView[viewTestUser](r, &View{
+		Name: "login",
+		Fields: []ViewField{
+			{Field: "Name"},
+			{Field: "Password", Label: "pw"},
+			{Field: "Token"},
+		},
+	})
+	user := &viewTestUser{Name: "a", Password: "hunter2", Token: "secret"}
+
+	var buf bytes.Buffer
+	err := r.RenderView(&buf, "json", "login", false, user)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"Name":"a","pw":"[REDACTED]","Token":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	err = r.RenderView(&buf, "json", "login", false, []viewTestUser{*user})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`[{"Name":"a","pw":"[REDACTED]","Token":"[REDACTED]"}]`+"\n",
+		buf.String(),
+	)
+	assert.Equal(t, "hunter2", user.Password)
+}
+
+func TestRenderer_RenderView_escapeHTML(t *testing.T) {
+	r := newViewTestRenderer()
+	post := &viewTestPost{ID: 1, Title: "<a>&b"}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "escapes HTML by default",
+			format: "json",
+			want:   `{"title":"\u003ca\u003e\u0026b","id":1}` + "\n",
+		},
+		{
+			name:   "escape_html parameter disabled",
+			format: "json;escape_html=false",
+			want:   `{"title":"<a>&b","id":1}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := r.RenderView(&buf, tt.format, "summary", false, post)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_NewWith_keepsViews(t *testing.T) {
+	r := newViewTestRenderer().NewWith("json")
+
+	var buf bytes.Buffer
+	err := r.RenderView(&buf, "json", "summary", false, &viewTestPost{ID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"title":"","id":1}`+"\n", buf.String())
+}
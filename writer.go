@@ -0,0 +1,70 @@
+package render
+
+import (
+	"errors"
+	"io"
+)
+
+// maxWriteRetries is the maximum number of consecutive writes which make no
+// progress that fullWriter tolerates, before giving up with io.ErrShortWrite.
+const maxWriteRetries = 16
+
+// fullWriter wraps a io.Writer, and ensures that all bytes given to Write are
+// written to the underlying writer. Partial writes, either reported by a
+// io.ErrShortWrite error, or by writing less bytes than given without an
+// error, are retried with the remaining bytes.
+//
+// This is useful for writers like pipes and network connections, which may
+// accept only part of a write.
+type fullWriter struct {
+	w io.Writer
+}
+
+var _ io.Writer = (*fullWriter)(nil)
+
+func (fw *fullWriter) Write(p []byte) (int, error) {
+	var written, retries int
+
+	for written < len(p) {
+		n, err := fw.w.Write(p[written:])
+		written += n
+
+		if err != nil && !errors.Is(err, io.ErrShortWrite) {
+			return written, err
+		}
+
+		if n > 0 {
+			retries = 0
+
+			continue
+		}
+
+		retries++
+		if retries > maxWriteRetries {
+			return written, io.ErrShortWrite
+		}
+	}
+
+	return written, nil
+}
+
+// countWriter wraps a io.Writer, and counts the number of bytes written to it.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+var _ io.Writer = (*countWriter)(nil)
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+
+	return n, err
+}
+
+// Flush flushes the underlying writer if it has a Flush method, so streamed
+// output is still delivered incrementally.
+func (cw *countWriter) Flush() error {
+	return flush(cw.w)
+}
@@ -0,0 +1,177 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockShortWriter writes at most max bytes per call to Write, returning err
+// when less bytes than given were written.
+type mockShortWriter struct {
+	max   int
+	err   error
+	calls int
+	buf   bytes.Buffer
+}
+
+var _ io.Writer = (*mockShortWriter)(nil)
+
+func (msw *mockShortWriter) Write(p []byte) (int, error) {
+	msw.calls++
+
+	if len(p) <= msw.max {
+		return msw.buf.Write(p)
+	}
+
+	n, _ := msw.buf.Write(p[:msw.max])
+
+	return n, msw.err
+}
+
+func Test_fullWriter_Write(t *testing.T) {
+	tests := []struct {
+		name      string
+		writer    *mockShortWriter
+		input     string
+		want      string
+		wantN     int
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "single full write",
+			writer:    &mockShortWriter{max: 100},
+			input:     "hello world",
+			want:      "hello world",
+			wantN:     11,
+			wantCalls: 1,
+		},
+		{
+			name:      "short writes without error",
+			writer:    &mockShortWriter{max: 4},
+			input:     "hello world",
+			want:      "hello world",
+			wantN:     11,
+			wantCalls: 3,
+		},
+		{
+			name:      "short writes with io.ErrShortWrite",
+			writer:    &mockShortWriter{max: 5, err: io.ErrShortWrite},
+			input:     "hello world",
+			want:      "hello world",
+			wantN:     11,
+			wantCalls: 3,
+		},
+		{
+			name: "short write with other error",
+			writer: &mockShortWriter{
+				max: 5,
+				err: errors.New("write error!!1"),
+			},
+			input:     "hello world",
+			want:      "hello",
+			wantN:     5,
+			wantCalls: 1,
+			wantErr:   errors.New("write error!!1"),
+		},
+		{
+			name:      "no progress",
+			writer:    &mockShortWriter{max: 0},
+			input:     "hello world",
+			want:      "",
+			wantN:     0,
+			wantCalls: maxWriteRetries + 1,
+			wantErr:   io.ErrShortWrite,
+		},
+		{
+			name:      "empty input",
+			writer:    &mockShortWriter{max: 0},
+			input:     "",
+			want:      "",
+			wantN:     0,
+			wantCalls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fw := &fullWriter{w: tt.writer}
+
+			n, err := fw.Write([]byte(tt.input))
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantN, n)
+			assert.Equal(t, tt.want, tt.writer.buf.String())
+			assert.Equal(t, tt.wantCalls, tt.writer.calls)
+		})
+	}
+}
+
+func TestShortWrites(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler Handler
+		value   any
+		want    string
+	}{
+		{
+			name:    "text string",
+			handler: &Text{},
+			value:   "hello world",
+			want:    "hello world",
+		},
+		{
+			name:    "text number",
+			handler: &Text{},
+			value:   1234567890,
+			want:    "1234567890",
+		},
+		{
+			name:    "text io.Reader",
+			handler: &Text{},
+			value:   &mockReader{value: "hello world"},
+			want:    "hello world",
+		},
+		{
+			name:    "text io.WriterTo",
+			handler: &Text{},
+			value:   &mockWriterTo{value: "hello world"},
+			want:    "hello world",
+		},
+		{
+			name:    "binary",
+			handler: &Binary{},
+			value:   &mockBinaryMarshaler{data: []byte("hello world")},
+			want:    "hello world",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &mockShortWriter{max: 3, err: io.ErrShortWrite}
+
+			err := tt.handler.Render(w, tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, w.buf.String())
+		})
+	}
+}
+
+func Test_countWriter(t *testing.T) {
+	w := &streamTestFlusher{}
+	cw := &countWriter{w: w}
+
+	n, err := cw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	_, err = io.WriteString(cw, " world")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), cw.n)
+
+	assert.NoError(t, cw.Flush())
+	assert.Equal(t, []string{"hello world"}, w.flushed)
+}
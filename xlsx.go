@@ -0,0 +1,317 @@
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XLSXDefaultSheetName is the default name of the worksheet used by XLSX
+// instances if no SheetName value is set on the XLSX instance itself.
+var XLSXDefaultSheetName = "Sheet1"
+
+// XLSX is a Handler that renders structs, and slices or arrays of structs, as a
+// single sheet Excel workbook in the Office Open XML (.xlsx) format.
+//
+// The first row of the sheet contains the column headers, followed by one row
+// per struct. Each exported field is rendered as a column, with the header
+// taken from the "xlsx" struct tag if present, or the field name otherwise.
+// Fields tagged with `xlsx:"-"` are skipped.
+//
+// Cells are formatted based on the type of the field. Booleans and numbers are
+// written as native boolean and numeric cells, time.Time values as date cells,
+// and all other values as text, using the String method if available.
+//
+// If the value is not struct based, a ErrCannotRender error will be returned.
+type XLSX struct {
+	// SheetName is the name of the worksheet. If empty, XLSXDefaultSheetName
+	// will be used.
+	SheetName string
+}
+
+var (
+	_ Handler            = (*XLSX)(nil)
+	_ FormatsHandler     = (*XLSX)(nil)
+	_ DescribedHandler   = (*XLSX)(nil)
+	_ ContentTypeHandler = (*XLSX)(nil)
+)
+
+// Render writes v as a XLSX workbook to w.
+func (x *XLSX) Render(w io.Writer, v any) error {
+	t, rows, ok := structRows(v)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	name := x.SheetName
+	if name == "" {
+		name = XLSXDefaultSheetName
+	}
+	if !xlsxValidSheetName(name) {
+		return fmt.Errorf("%w: invalid sheet name: %q", ErrFailed, name)
+	}
+
+	sheet := xlsxSheet(structFields(t, "xlsx"), rows)
+
+	var workbook bytes.Buffer
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(
+		`<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">` +
+			`<sheets><sheet name="`,
+	)
+	xlsxEscape(&workbook, name)
+	workbook.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRootRels)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+		{"xl/worksheets/sheet1.xml", sheet},
+	}
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:   f.name,
+			Method: zip.Deflate,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+		if _, err = fw.Write(f.data); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// Formats returns a list of format strings that this Handler supports.
+func (x *XLSX) Formats() []string {
+	return []string{"xlsx", "excel"}
+}
+
+// Description returns a short human-readable description of the format.
+func (x *XLSX) Description() string {
+	return "Excel spreadsheet"
+}
+
+// ContentType returns the MIME type of the format.
+func (x *XLSX) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+const (
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS  = "http://schemas.openxmlformats.org/officeDocument/2006/" +
+		"relationships"
+
+	// Cell style indexes within xlsxStyles.
+	xlsxStyleHeader = 1
+	xlsxStyleDate   = 2
+)
+
+const xlsxContentTypes = xml.Header +
+	`<Types xmlns="http://schemas.openxmlformats.org/package/2006/` +
+	`content-types">` +
+	`<Default Extension="rels" ContentType="application/` +
+	`vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/` +
+	`vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/` +
+	`vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/` +
+	`vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/` +
+	`relationships">` +
+	`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" ` +
+	`Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/` +
+	`relationships">` +
+	`<Relationship Id="rId1" Type="` + xlsxRelNS + `/worksheet" ` +
+	`Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="` + xlsxRelNS + `/styles" ` +
+	`Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header +
+	`<styleSheet xmlns="` + xlsxMainNS + `">` +
+	`<fonts count="2">` +
+	`<font><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="11"/><name val="Calibri"/></font>` +
+	`</fonts>` +
+	`<fills count="2">` +
+	`<fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill>` +
+	`</fills>` +
+	`<borders count="1">` +
+	`<border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`</borders>` +
+	`<cellStyleXfs count="1">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0"/>` +
+	`</cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" ` +
+	`applyFont="1"/>` +
+	`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" ` +
+	`applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1">` +
+	`<cellStyle name="Normal" xfId="0" builtinId="0"/>` +
+	`</cellStyles>` +
+	`</styleSheet>`
+
+// xlsxEpoch is the epoch used by Excel's date serial numbers.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxSheet returns the worksheet XML document for the given fields and rows.
+func xlsxSheet(fields []structField, rows []reflect.Value) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="` + xlsxMainNS + `"><sheetData>`)
+
+	buf.WriteString(`<row r="1">`)
+	for i, f := range fields {
+		xlsxStringCell(&buf, xlsxRef(i, 1), f.name, xlsxStyleHeader)
+	}
+	buf.WriteString(`</row>`)
+
+	for r, row := range rows {
+		n := r + 2
+		fmt.Fprintf(&buf, `<row r="%d">`, n)
+		for i, f := range fields {
+			xlsxCell(&buf, xlsxRef(i, n), f.value(row))
+		}
+		buf.WriteString(`</row>`)
+	}
+
+	buf.WriteString(`</sheetData></worksheet>`)
+
+	return buf.Bytes()
+}
+
+// xlsxCell writes a cell for the given value, picking the cell type and style
+// based on the type of the value. Nil and zero time values produce no cell.
+func xlsxCell(buf *bytes.Buffer, ref string, rv reflect.Value) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return
+	}
+
+	v := rv.Interface()
+	if t, ok := v.(time.Time); ok {
+		if t.IsZero() {
+			return
+		}
+		fmt.Fprintf(
+			buf, `<c r="%s" s="%d"><v>%s</v></c>`,
+			ref, xlsxStyleDate, xlsxNumber(xlsxDateSerial(t)),
+		)
+
+		return
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		xlsxStringCell(buf, ref, s.String(), 0)
+
+		return
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		b := "0"
+		if rv.Bool() {
+			b = "1"
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="b"><v>%s</v></c>`, ref, b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		fmt.Fprintf(buf, `<c r="%s"><v>%d</v></c>`, ref, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(buf, `<c r="%s"><v>%d</v></c>`, ref, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			xlsxStringCell(buf, ref, fmt.Sprint(f), 0)
+
+			return
+		}
+		fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, xlsxNumber(f))
+	case reflect.String:
+		xlsxStringCell(buf, ref, rv.String(), 0)
+	default:
+		xlsxStringCell(buf, ref, fmt.Sprint(v), 0)
+	}
+}
+
+// xlsxStringCell writes an inline string cell with the given style.
+func xlsxStringCell(buf *bytes.Buffer, ref, s string, style int) {
+	fmt.Fprintf(buf, `<c r="%s" t="inlineStr"`, ref)
+	if style != 0 {
+		fmt.Fprintf(buf, ` s="%d"`, style)
+	}
+	buf.WriteString(`><is><t xml:space="preserve">`)
+	xlsxEscape(buf, s)
+	buf.WriteString(`</t></is></c>`)
+}
+
+func xlsxEscape(buf *bytes.Buffer, s string) {
+	// Writing to a bytes.Buffer never fails.
+	_ = xml.EscapeText(buf, []byte(s))
+}
+
+// xlsxRef returns the A1-style reference for the given zero-based column and
+// one-based row.
+func xlsxRef(col, row int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+
+	return name + strconv.Itoa(row)
+}
+
+// xlsxDateSerial returns the Excel date serial number for the wall clock time
+// of t, in the location of t.
+func xlsxDateSerial(t time.Time) float64 {
+	wall := time.Date(
+		t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		time.UTC,
+	)
+
+	return wall.Sub(xlsxEpoch).Hours() / 24
+}
+
+func xlsxNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func xlsxValidSheetName(name string) bool {
+	return len([]rune(name)) <= 31 && !strings.ContainsAny(name, `[]:*?/\`)
+}
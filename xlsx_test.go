@@ -0,0 +1,201 @@
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xlsxTestRow struct {
+	Name    string `xlsx:"Full Name"`
+	Age     int
+	Score   float64
+	Active  bool
+	Joined  time.Time
+	Note    *string
+	Skipped string `xlsx:"-"`
+	private string
+}
+
+func readXLSX(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(data)
+	}
+
+	return files
+}
+
+func TestXLSX_Render(t *testing.T) {
+	note := "a < b & c"
+	joined := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		sheetName     string
+		value         any
+		wantSheetName string
+		wantSheet     string
+		wantErr       string
+		wantErrIs     []error
+	}{
+		{
+			name: "slice of structs",
+			value: []xlsxTestRow{
+				{
+					Name:   "John",
+					Age:    30,
+					Score:  9.5,
+					Active: true,
+					Joined: joined,
+					Note:   &note,
+				},
+				{Name: "Jane", private: "hidden"},
+			},
+			wantSheetName: "Sheet1",
+			wantSheet: `<row r="1">` +
+				`<c r="A1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Full Name</t></is></c>` +
+				`<c r="B1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Age</t></is></c>` +
+				`<c r="C1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Score</t></is></c>` +
+				`<c r="D1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Active</t></is></c>` +
+				`<c r="E1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Joined</t></is></c>` +
+				`<c r="F1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Note</t></is></c>` +
+				`</row>` +
+				`<row r="2">` +
+				`<c r="A2" t="inlineStr"><is>` +
+				`<t xml:space="preserve">John</t></is></c>` +
+				`<c r="B2"><v>30</v></c>` +
+				`<c r="C2"><v>9.5</v></c>` +
+				`<c r="D2" t="b"><v>1</v></c>` +
+				`<c r="E2" s="2"><v>45376.5</v></c>` +
+				`<c r="F2" t="inlineStr"><is>` +
+				`<t xml:space="preserve">a &lt; b &amp; c</t></is></c>` +
+				`</row>` +
+				`<row r="3">` +
+				`<c r="A3" t="inlineStr"><is>` +
+				`<t xml:space="preserve">Jane</t></is></c>` +
+				`<c r="B3"><v>0</v></c>` +
+				`<c r="C3"><v>0</v></c>` +
+				`<c r="D3" t="b"><v>0</v></c>` +
+				`</row>`,
+		},
+		{
+			name:          "single struct pointer with custom sheet name",
+			sheetName:     "Users",
+			value:         &struct{ Name string }{Name: "John"},
+			wantSheetName: "Users",
+			wantSheet: `<row r="1">` +
+				`<c r="A1" t="inlineStr" s="1"><is>` +
+				`<t xml:space="preserve">Name</t></is></c>` +
+				`</row>` +
+				`<row r="2">` +
+				`<c r="A2" t="inlineStr"><is>` +
+				`<t xml:space="preserve">John</t></is></c>` +
+				`</row>`,
+		},
+		{
+			name:      "not struct based",
+			value:     map[string]int{"age": 30},
+			wantErr:   "render: cannot render: map[string]int",
+			wantErrIs: []error{Err, ErrCannotRender},
+		},
+		{
+			name:      "invalid sheet name",
+			sheetName: "foo/bar",
+			value:     []xlsxTestRow{},
+			wantErr:   `render: failed: invalid sheet name: "foo/bar"`,
+			wantErrIs: []error{Err, ErrFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XLSX{SheetName: tt.sheetName}
+			var buf bytes.Buffer
+
+			err := x.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			for _, e := range tt.wantErrIs {
+				assert.ErrorIs(t, err, e)
+			}
+
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+				require.NoError(t, err)
+
+				files := readXLSX(t, buf.Bytes())
+				assert.Len(t, files, 6)
+				assert.Contains(t, files, "[Content_Types].xml")
+				assert.Contains(t, files, "_rels/.rels")
+				assert.Contains(t, files, "xl/_rels/workbook.xml.rels")
+				assert.Contains(t, files, "xl/styles.xml")
+				assert.Contains(t, files["xl/workbook.xml"],
+					`<sheet name="`+tt.wantSheetName+`" sheetId="1"`,
+				)
+				assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+					"<sheetData>"+tt.wantSheet+"</sheetData>",
+				)
+			}
+		})
+	}
+}
+
+func TestXLSX_Render_writeError(t *testing.T) {
+	x := &XLSX{}
+	w := &mockWriter{WriteErr: errors.New("write error!!1")}
+
+	err := x.Render(w, []xlsxTestRow{{Name: "John"}})
+
+	assert.EqualError(t, err, "render: failed: write error!!1")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestXLSX_Formats(t *testing.T) {
+	h := &XLSX{}
+
+	assert.Equal(t, []string{"xlsx", "excel"}, h.Formats())
+}
+
+func Test_xlsxRef(t *testing.T) {
+	tests := []struct {
+		col  int
+		row  int
+		want string
+	}{
+		{col: 0, row: 1, want: "A1"},
+		{col: 25, row: 2, want: "Z2"},
+		{col: 26, row: 3, want: "AA3"},
+		{col: 51, row: 4, want: "AZ4"},
+		{col: 52, row: 5, want: "BA5"},
+		{col: 701, row: 6, want: "ZZ6"},
+		{col: 702, row: 7, want: "AAA7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, xlsxRef(tt.col, tt.row))
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package render
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
 )
 
 // XMLDefualtIndent is the default indentation string used by XML instances when
@@ -11,6 +17,12 @@ import (
 var XMLDefualtIndent = "  "
 
 // XML is a Renderer that marshals a value to XML.
+//
+// Maps with string keys, which encoding/xml cannot marshal, are rendered as
+// an element named by Root, with a child element for each entry named by its
+// key, in sorted key order. Nested maps are rendered the same way, slice and
+// array values as repeated elements, and nil values are omitted. Keys which
+// are not valid XML names fail to render with a ErrFailed error.
 type XML struct {
 	// Prefix is the prefix added to each level of indentation when pretty
 	// rendering.
@@ -19,17 +31,66 @@ type XML struct {
 	// Indent is the string added to each level of indentation when pretty
 	// rendering. If empty, XMLDefualtIndent be used.
 	Indent string
+
+	// Root is the name of the root element when rendering maps. If empty,
+	// "root" is used.
+	Root string
+
+	// Namespace is the default namespace declared on the root element with an
+	// xmlns attribute, if not empty. Slices and arrays are rendered as a
+	// sequence of root elements, each with the namespaces declared.
+	Namespace string
+
+	// Namespaces maps prefixes to namespace URIs, which are declared on the
+	// root element with xmlns:prefix attributes, in sorted prefix order.
+	Namespaces map[string]string
+
+	// SelfClosing renders empty elements as self-closing tags, like "<foo/>",
+	// instead of "<foo></foo>".
+	SelfClosing bool
+
+	// CDATA lists the names of elements whose text content is wrapped in a
+	// CDATA section, like "<body><![CDATA[<p>Hi</p>]]></body>", instead of
+	// being escaped. Names are matched against element names as rendered,
+	// including any namespace prefix. Struct fields can also be rendered as
+	// CDATA by encoding/xml itself with the ",cdata" tag option.
+	CDATA []string
+
+	// TrailingNewline writes a newline after the rendered XML document, for
+	// consistency with JSON and YAML output, which always end with a newline.
+	// It does not apply to encoders from NewEncoder. To ensure all formats
+	// end with a newline, use the TrailingNewline post-processor instead.
+	TrailingNewline bool
 }
 
 var (
-	_ Handler        = (*XML)(nil)
-	_ PrettyHandler  = (*XML)(nil)
-	_ FormatsHandler = (*XML)(nil)
+	_ Handler            = (*XML)(nil)
+	_ PrettyHandler      = (*XML)(nil)
+	_ FormatsHandler     = (*XML)(nil)
+	_ DescribedHandler   = (*XML)(nil)
+	_ ContentTypeHandler = (*XML)(nil)
+	_ OptionsHandler     = (*XML)(nil)
+	_ EncoderHandler     = (*XML)(nil)
+	_ ParseHandler       = (*XML)(nil)
 )
 
 // Render marshals the given value to XML.
 func (x *XML) Render(w io.Writer, v any) error {
-	err := xml.NewEncoder(w).Encode(v)
+	return x.render(w, v, false)
+}
+
+// RenderPretty marshals the given value to XML with line breaks and
+// indentation.
+func (x *XML) RenderPretty(w io.Writer, v any) error {
+	return x.render(w, v, true)
+}
+
+// render marshals v to w, with indentation if pretty is true.
+func (x *XML) render(w io.Writer, v any, pretty bool) error {
+	err := x.NewEncoder(w, pretty).Encode(v)
+	if err == nil && x.TrailingNewline {
+		_, err = io.WriteString(w, "\n")
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
@@ -37,19 +98,424 @@ func (x *XML) Render(w io.Writer, v any) error {
 	return nil
 }
 
-// RenderPretty marshals the given value to XML with line breaks and
-// indentation.
-func (x *XML) RenderPretty(w io.Writer, v any) error {
-	prefix := x.Prefix
-	indent := x.Indent
-	if indent == "" {
-		indent = XMLDefualtIndent
+// NewEncoder returns a xml.Encoder which writes values to w, with indentation
+// configured if pretty is true.
+func (x *XML) NewEncoder(w io.Writer, pretty bool) ValueEncoder {
+	e := &xmlEncoder{x: x}
+
+	out := w
+	if x.SelfClosing || len(x.CDATA) > 0 {
+		e.w = w
+		e.buf = &bytes.Buffer{}
+		out = e.buf
 	}
 
-	enc := xml.NewEncoder(w)
-	enc.Indent(prefix, indent)
+	e.Encoder = xml.NewEncoder(out)
+	if pretty {
+		indent := x.Indent
+		if indent == "" {
+			indent = XMLDefualtIndent
+		}
+		e.Indent(x.Prefix, indent)
+	}
+
+	return e
+}
+
+// xmlEncoder wraps a xml.Encoder, rendering maps, empty elements, and CDATA
+// sections like the XML handler. If buf is set, values are encoded into it
+// before being written to w.
+type xmlEncoder struct {
+	*xml.Encoder
+	x   *XML
+	w   io.Writer
+	buf *bytes.Buffer
+}
+
+func (e *xmlEncoder) Encode(v any) error {
+	err := e.x.encode(e.Encoder, v)
+	if err != nil || e.buf == nil {
+		return err
+	}
+
+	b := e.buf.Bytes()
+	if len(e.x.CDATA) > 0 {
+		names := make(map[string]bool, len(e.x.CDATA))
+		for _, name := range e.x.CDATA {
+			names[name] = true
+		}
+		b = xmlCDATA(b, names)
+	}
+	if e.x.SelfClosing {
+		b = xmlSelfClose(b)
+	}
+
+	_, err = e.w.Write(b)
+	e.buf.Reset()
+
+	return err
+}
+
+// encode writes v with enc, rendering maps as XML elements, and declaring the
+// configured namespaces on the root element.
+func (x *XML) encode(enc *xml.Encoder, v any) error {
+	v = x.value(v)
+
+	attrs := x.namespaceAttrs()
+	if len(attrs) == 0 || v == nil {
+		return enc.Encode(v)
+	}
+
+	return xmlEncodeRoot(enc, reflect.ValueOf(v), attrs)
+}
+
+// xmlEncodeRoot writes rv with enc like Encode does, with attrs added to the
+// root element. Slices and arrays are written as a sequence of root elements,
+// each with attrs added.
+func xmlEncodeRoot(enc *xml.Encoder, rv reflect.Value, attrs []xml.Attr) error {
+	ev := rv
+	for ev.Kind() == reflect.Pointer || ev.Kind() == reflect.Interface {
+		if ev.IsNil() {
+			return nil
+		}
+		ev = ev.Elem()
+	}
+
+	if (ev.Kind() == reflect.Slice || ev.Kind() == reflect.Array) &&
+		ev.Type().Elem().Kind() != reflect.Uint8 &&
+		!rv.Type().Implements(xmlMarshalerType) {
+		for i := 0; i < ev.Len(); i++ {
+			if err := xmlEncodeRoot(enc, ev.Index(i), attrs); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// An explicit start element takes precedence over the name given by a
+	// XMLName field, so it must be resolved the same way Encode does.
+	start := xml.StartElement{Name: xmlRootName(ev), Attr: attrs}
+
+	return enc.EncodeElement(rv.Interface(), start)
+}
+
+// xmlRootName returns the name Encode uses for the root element of rv, which
+// is taken from the tag or value of its XMLName field if it is a struct, and
+// from the name of its type otherwise.
+func xmlRootName(rv reflect.Value) xml.Name {
+	if rv.Kind() == reflect.Struct {
+		if f, ok := rv.Type().FieldByName("XMLName"); ok {
+			tag, _, _ := strings.Cut(f.Tag.Get("xml"), ",")
+			if tag != "" {
+				if space, local, ok := strings.Cut(tag, " "); ok {
+					return xml.Name{Space: space, Local: local}
+				}
+
+				return xml.Name{Local: tag}
+			}
+
+			fv, err := rv.FieldByIndexErr(f.Index)
+			if err == nil && fv.CanInterface() {
+				name, ok := fv.Interface().(xml.Name)
+				if ok && name.Local != "" {
+					return name
+				}
+			}
+		}
+	}
+
+	return xml.Name{Local: rv.Type().Name()}
+}
+
+// namespaceAttrs returns the xmlns attributes declaring the configured
+// namespaces.
+func (x *XML) namespaceAttrs() []xml.Attr {
+	var attrs []xml.Attr
+	if x.Namespace != "" {
+		attrs = append(attrs, xml.Attr{
+			Name:  xml.Name{Local: "xmlns"},
+			Value: x.Namespace,
+		})
+	}
+
+	prefixes := make([]string, 0, len(x.Namespaces))
+	for p := range x.Namespaces {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+
+	for _, p := range prefixes {
+		attrs = append(attrs, xml.Attr{
+			Name:  xml.Name{Local: "xmlns:" + p},
+			Value: x.Namespaces[p],
+		})
+	}
+
+	return attrs
+}
+
+// value returns v wrapped in a xml.Marshaler if it is a map, or v as is.
+func (x *XML) value(v any) any {
+	rv := indirect(reflect.ValueOf(v))
+	if !xmlIsMap(rv) {
+		return v
+	}
+
+	root := x.Root
+	if root == "" {
+		root = "root"
+	}
+
+	return &xmlMap{name: root, v: rv}
+}
+
+// xmlMap renders a map with string keys as XML elements.
+type xmlMap struct {
+	name string
+	v    reflect.Value
+}
+
+// MarshalXML renders the map as an element, named by the name of the xmlMap
+// if set, with a child element for each entry in sorted key order.
+func (m *xmlMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if m.name != "" {
+		start.Name = xml.Name{Local: m.name}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, m.v.Len())
+	for _, k := range m.v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
 
-	err := enc.Encode(v)
+	for _, k := range keys {
+		if !xmlIsName(k) {
+			return fmt.Errorf("xml: invalid element name: %q", k)
+		}
+
+		kv := reflect.ValueOf(k).Convert(m.v.Type().Key())
+		if err := xmlElement(e, k, m.v.MapIndex(kv)); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// xmlElement renders rv as an element with the given name, rendering maps as
+// nested elements, and slices and arrays as repeated elements. Nil values are
+// omitted.
+func xmlElement(e *xml.Encoder, name string, rv reflect.Value) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	ev := indirect(rv)
+	switch {
+	case !ev.IsValid():
+		return nil
+	case xmlIsMap(ev):
+		return e.EncodeElement(&xmlMap{v: ev}, start)
+	case (ev.Kind() == reflect.Slice || ev.Kind() == reflect.Array) &&
+		ev.Type().Elem().Kind() != reflect.Uint8 &&
+		!ev.Type().Implements(xmlMarshalerType):
+		for i := 0; i < ev.Len(); i++ {
+			if err := xmlElement(e, name, ev.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return e.EncodeElement(rv.Interface(), start)
+}
+
+// xmlSelfClose returns the XML document b with all start tags which are
+// directly followed by their end tag replaced with self-closing tags. Comments,
+// CDATA sections, and processing instructions are kept as is.
+func xmlSelfClose(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+
+	// name is the name of the last start tag, if it ends at the end of out.
+	var name []byte
+	nameEnd := -1
+
+	xmlSegments(b, func(seg []byte, markup bool) {
+		switch {
+		case !markup:
+		case xmlIsEndTag(seg):
+			if nameEnd == len(out) && bytes.Equal(xmlTagName(seg), name) {
+				out = append(out[:len(out)-1], '/', '>')
+				nameEnd = -1
+
+				return
+			}
+		case xmlIsStartTag(seg):
+			out = append(out, seg...)
+			name, nameEnd = xmlTagName(seg), len(out)
+
+			return
+		}
+
+		out = append(out, seg...)
+	})
+
+	return out
+}
+
+// xmlCDATA returns the XML document b with the text content of all elements
+// with one of the given names wrapped in CDATA sections instead of being
+// escaped. Whitespace only text is kept as is.
+func xmlCDATA(b []byte, names map[string]bool) []byte {
+	out := make([]byte, 0, len(b))
+
+	var stack []string
+	xmlSegments(b, func(seg []byte, markup bool) {
+		switch {
+		case !markup:
+			if len(stack) > 0 && len(bytes.TrimSpace(seg)) > 0 &&
+				names[stack[len(stack)-1]] {
+				if text, ok := xmlUnescape(seg); ok {
+					out = append(out, "<![CDATA["...)
+					out = append(out, strings.ReplaceAll(
+						text, "]]>", "]]]]><![CDATA[>",
+					)...)
+					out = append(out, "]]>"...)
+
+					return
+				}
+			}
+		case xmlIsEndTag(seg):
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xmlIsStartTag(seg):
+			stack = append(stack, string(xmlTagName(seg)))
+		}
+
+		out = append(out, seg...)
+	})
+
+	return out
+}
+
+// xmlUnescape returns the unescaped text of the escaped XML character data b.
+func xmlUnescape(b []byte) (string, bool) {
+	doc := append(append([]byte("<x>"), b...), "</x>"...)
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return text.String(), true
+		} else if err != nil {
+			return "", false
+		}
+		if cd, ok := tok.(xml.CharData); ok {
+			text.Write(cd)
+		}
+	}
+}
+
+// xmlSegments splits the XML document b into markup and text segments, and
+// calls fn with each in order. Markup segments are tags, comments, CDATA
+// sections, and processing instructions.
+func xmlSegments(b []byte, fn func(seg []byte, markup bool)) {
+	for i := 0; i < len(b); {
+		if b[i] != '<' {
+			j := bytes.IndexByte(b[i:], '<')
+			if j < 0 {
+				j = len(b) - i
+			}
+			fn(b[i:i+j], false)
+			i += j
+
+			continue
+		}
+
+		end := len(b)
+		for _, delim := range [][2]string{
+			{"<!--", "-->"}, {"<![CDATA[", "]]>"}, {"<?", "?>"}, {"<", ">"},
+		} {
+			if bytes.HasPrefix(b[i:], []byte(delim[0])) {
+				if j := bytes.Index(b[i:], []byte(delim[1])); j >= 0 {
+					end = i + j + len(delim[1])
+				}
+
+				break
+			}
+		}
+
+		fn(b[i:end], true)
+		i = end
+	}
+}
+
+// xmlIsStartTag reports if the markup segment seg is a start tag, which is not
+// self-closing.
+func xmlIsStartTag(seg []byte) bool {
+	return len(seg) > 2 && seg[len(seg)-1] == '>' &&
+		seg[1] != '/' && seg[1] != '!' && seg[1] != '?' &&
+		!bytes.HasSuffix(seg, []byte("/>"))
+}
+
+// xmlIsEndTag reports if the markup segment seg is an end tag.
+func xmlIsEndTag(seg []byte) bool {
+	return bytes.HasPrefix(seg, []byte("</")) && seg[len(seg)-1] == '>'
+}
+
+// xmlTagName returns the element name of the start or end tag seg.
+func xmlTagName(seg []byte) []byte {
+	name := bytes.TrimPrefix(seg[1:len(seg)-1], []byte("/"))
+	if j := bytes.IndexAny(name, " \t\r\n"); j >= 0 {
+		name = name[:j]
+	}
+
+	return name
+}
+
+// xmlIsName reports if s is a valid XML name, which can be used as the name
+// of an element.
+func xmlIsName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c == '_' || c == ':' || unicode.IsLetter(c):
+		case i == 0:
+			return false
+		case c == '-' || c == '.' || c == 0xB7 || unicode.IsDigit(c) ||
+			unicode.Is(unicode.Mn, c) || unicode.Is(unicode.Mc, c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// xmlIsMap reports if rv is a map with string keys which does not implement
+// xml.Marshaler.
+func xmlIsMap(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Map &&
+		rv.Type().Key().Kind() == reflect.String &&
+		!rv.Type().Implements(xmlMarshalerType)
+}
+
+// Parse decodes a XML element from r into v. As XML cannot be decoded into a
+// plain any value, a ErrCannotRender error is returned if v is a *any.
+func (x *XML) Parse(r io.Reader, v any) error {
+	if _, ok := v.(*any); ok {
+		return fmt.Errorf("%w: %T", ErrCannotRender, v)
+	}
+
+	err := xml.NewDecoder(r).Decode(v)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
@@ -61,3 +527,56 @@ func (x *XML) RenderPretty(w io.Writer, v any) error {
 func (x *XML) Formats() []string {
 	return []string{"xml"}
 }
+
+// WithOptions returns a copy of the XML handler with the Prefix and Indent
+// options applied. The "root" and "namespace" parameters set the Root and
+// Namespace fields, and the "self_closing" and "trailing_newline" parameters
+// set the SelfClosing and TrailingNewline fields, if they are valid booleans.
+// The "cdata" parameter sets the CDATA field to a comma-separated list of
+// element names, like "xml;cdata=body,summary".
+func (x *XML) WithOptions(opts *Options) Handler {
+	root := opts.Params["root"]
+	ns := opts.Params["namespace"]
+	cdata := opts.Params["cdata"]
+	selfClosing, hasSelfClosing := opts.boolParam("self_closing")
+	newline, hasNewline := opts.boolParam("trailing_newline")
+	if opts.Prefix == "" && opts.Indent == "" && root == "" && ns == "" &&
+		cdata == "" && !hasSelfClosing && !hasNewline {
+		return x
+	}
+
+	c := *x
+	if opts.Prefix != "" {
+		c.Prefix = opts.Prefix
+	}
+	if opts.Indent != "" {
+		c.Indent = opts.Indent
+	}
+	if root != "" {
+		c.Root = root
+	}
+	if ns != "" {
+		c.Namespace = ns
+	}
+	if cdata != "" {
+		c.CDATA = strings.Split(cdata, ",")
+	}
+	if hasSelfClosing {
+		c.SelfClosing = selfClosing
+	}
+	if hasNewline {
+		c.TrailingNewline = newline
+	}
+
+	return &c
+}
+
+// Description returns a short human-readable description of the format.
+func (x *XML) Description() string {
+	return "XML, machine readable"
+}
+
+// ContentType returns the MIME type of the format.
+func (x *XML) ContentType() string {
+	return "application/xml"
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/xml"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockXMLMarshaler struct {
@@ -175,8 +177,518 @@ func TestXML_RenderPretty(t *testing.T) {
 	}
 }
 
+func TestXML_Render_maps(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		pretty  bool
+		value   any
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "map",
+			value: map[string]any{"name": "John", "age": 30},
+			want:  "<root><age>30</age><name>John</name></root>",
+		},
+		{
+			name:  "custom root",
+			root:  "user",
+			value: map[string]string{"name": "John"},
+			want:  "<user><name>John</name></user>",
+		},
+		{
+			name: "nested maps and slices",
+			value: &map[string]any{
+				"user": map[string]any{
+					"tags":  []string{"a", "b"},
+					"roles": []any{map[string]int{"id": 1}, nil},
+				},
+				"none": nil,
+			},
+			want: "<root><user><roles><id>1</id></roles>" +
+				"<tags>a</tags><tags>b</tags></user></root>",
+		},
+		{
+			name: "structs in maps",
+			value: map[string]any{
+				"user": struct {
+					Name string `xml:"name,attr"`
+				}{Name: "John"},
+			},
+			want: `<root><user name="John"></user></root>`,
+		},
+		{
+			name:   "pretty",
+			pretty: true,
+			value:  map[string]any{"a": map[string]int{"b": 1}},
+			want:   "<root>\n  <a>\n    <b>1</b>\n  </a>\n</root>",
+		},
+		{
+			name:    "unsupported value",
+			value:   map[string]any{"a": make(chan int)},
+			wantErr: "render: failed: xml: unsupported type: chan int",
+		},
+		{
+			name:  "key with unicode letters, digits and punctuation",
+			value: map[string]int{"ñame_1.x-y": 1},
+			want:  "<root><ñame_1.x-y>1</ñame_1.x-y></root>",
+		},
+		{
+			name:    "key with space",
+			value:   map[string]int{"bad key": 1},
+			wantErr: `render: failed: xml: invalid element name: "bad key"`,
+		},
+		{
+			name:    "key starting with a digit",
+			value:   map[string]any{"a": map[string]int{"1a": 1}},
+			wantErr: `render: failed: xml: invalid element name: "1a"`,
+		},
+		{
+			name:    "empty key",
+			value:   map[string]int{"": 1},
+			wantErr: `render: failed: xml: invalid element name: ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XML{Root: tt.root}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = x.RenderPretty(&buf, tt.value)
+			} else {
+				err = x.Render(&buf, tt.value)
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrFailed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_xmlMapRootParam(t *testing.T) {
+	got, err := Base.String(
+		"xml;root=config", false, map[string]any{"debug": true},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "<config><debug>true</debug></config>", got)
+}
+
+type xmlNamed struct {
+	XMLName xml.Name `xml:"person"`
+	Name    string   `xml:"name"`
+}
+
+func TestXML_Namespaces(t *testing.T) {
+	type user struct {
+		XMLName xml.Name `xml:"user"`
+		Name    string   `xml:"name"`
+	}
+	type plain struct {
+		Name string `xml:"name"`
+	}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		namespaces map[string]string
+		value      any
+		want       string
+	}{
+		{
+			name:  "no namespaces",
+			value: user{Name: "John"},
+			want:  "<user><name>John</name></user>",
+		},
+		{
+			name:      "default namespace",
+			namespace: "urn:example",
+			value:     user{Name: "John"},
+			want:      `<user xmlns="urn:example"><name>John</name></user>`,
+		},
+		{
+			name:      "prefixes",
+			namespace: "urn:example",
+			namespaces: map[string]string{
+				"xsi": "http://www.w3.org/2001/XMLSchema-instance",
+				"ex":  "urn:ex",
+			},
+			value: &user{Name: "John"},
+			want: `<user xmlns="urn:example" xmlns:ex="urn:ex" ` +
+				`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+				"<name>John</name></user>",
+		},
+		{
+			name:      "struct without XMLName",
+			namespace: "urn:example",
+			value:     plain{Name: "John"},
+			want:      `<plain xmlns="urn:example"><name>John</name></plain>`,
+		},
+		{
+			name:      "XMLName tag differs from type name",
+			namespace: "urn:example",
+			value:     xmlNamed{Name: "John"},
+			want:      `<person xmlns="urn:example"><name>John</name></person>`,
+		},
+		{
+			name:      "XMLName value",
+			namespace: "urn:example",
+			value: struct {
+				XMLName xml.Name
+				Name    string `xml:"name"`
+			}{XMLName: xml.Name{Local: "member"}, Name: "John"},
+			want: `<member xmlns="urn:example"><name>John</name></member>`,
+		},
+		{
+			name:      "slice",
+			namespace: "urn:example",
+			value:     []any{xmlNamed{Name: "John"}, nil, &plain{Name: "Jane"}},
+			want: `<person xmlns="urn:example"><name>John</name></person>` +
+				`<plain xmlns="urn:example"><name>Jane</name></plain>`,
+		},
+		{
+			name:      "map",
+			namespace: "urn:example",
+			value:     map[string]string{"name": "John"},
+			want:      `<root xmlns="urn:example"><name>John</name></root>`,
+		},
+		{
+			name:      "view",
+			namespace: "urn:example",
+			value: &viewValue{
+				name: "user", labels: []string{"n"}, values: []any{1},
+			},
+			want: `<user xmlns="urn:example"><n>1</n></user>`,
+		},
+		{
+			name:      "nil",
+			namespace: "urn:example",
+			value:     nil,
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XML{Namespace: tt.namespace, Namespaces: tt.namespaces}
+
+			var buf bytes.Buffer
+			err := x.Render(&buf, tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestXML_SelfClosing(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      string   `xml:"id,attr"`
+		Name    string   `xml:"name"`
+		Tags    []string `xml:"tags>tag"`
+		Note    string   `xml:",comment"`
+	}
+
+	tests := []struct {
+		name        string
+		selfClosing bool
+		pretty      bool
+		value       any
+		want        string
+	}{
+		{
+			name:  "disabled",
+			value: item{ID: "1"},
+			want:  `<item id="1"><name></name><tags></tags></item>`,
+		},
+		{
+			name:        "enabled",
+			selfClosing: true,
+			value:       item{ID: "1", Tags: []string{"", "a"}},
+			want: `<item id="1"><name/>` +
+				"<tags><tag/><tag>a</tag></tags></item>",
+		},
+		{
+			name:        "pretty",
+			selfClosing: true,
+			pretty:      true,
+			value:       item{ID: "1"},
+			want:        "<item id=\"1\">\n  <name/>\n  <tags/>\n</item>",
+		},
+		{
+			name:        "comments are kept",
+			selfClosing: true,
+			value:       item{ID: "1", Name: "a", Note: "<b></b>"},
+			want: `<item id="1"><name>a</name><tags/>` +
+				"<!--<b></b>--></item>",
+		},
+		{
+			name:        "escaped text",
+			selfClosing: true,
+			value:       map[string]string{"a": "<b></b>", "c": ""},
+			want:        "<root><a>&lt;b&gt;&lt;/b&gt;</a><c/></root>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XML{SelfClosing: tt.selfClosing}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = x.RenderPretty(&buf, tt.value)
+			} else {
+				err = x.Render(&buf, tt.value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestXML_CDATA(t *testing.T) {
+	type post struct {
+		XMLName xml.Name `xml:"post"`
+		Title   string   `xml:"title"`
+		Body    string   `xml:"body"`
+		Tags    []string `xml:"tags>tag"`
+	}
+
+	tests := []struct {
+		name        string
+		cdata       []string
+		selfClosing bool
+		pretty      bool
+		value       any
+		want        string
+	}{
+		{
+			name:  "disabled",
+			value: post{Title: "a & b", Body: "<p>Hi</p>"},
+			want: "<post><title>a &amp; b</title>" +
+				"<body>&lt;p&gt;Hi&lt;/p&gt;</body><tags></tags></post>",
+		},
+		{
+			name:  "enabled",
+			cdata: []string{"body"},
+			value: post{Title: "a & b", Body: "<p>Hi</p>"},
+			want: "<post><title>a &amp; b</title>" +
+				"<body><![CDATA[<p>Hi</p>]]></body><tags></tags></post>",
+		},
+		{
+			name:  "multiple elements",
+			cdata: []string{"title", "tag"},
+			value: post{Title: "a & b", Tags: []string{"<x>", "y"}},
+			want: "<post><title><![CDATA[a & b]]></title><body></body>" +
+				"<tags><tag><![CDATA[<x>]]></tag>" +
+				"<tag><![CDATA[y]]></tag></tags></post>",
+		},
+		{
+			name:  "end delimiter is split",
+			cdata: []string{"body"},
+			value: post{Body: "a]]>b"},
+			want: "<post><title></title>" +
+				"<body><![CDATA[a]]]]><![CDATA[>b]]></body>" +
+				"<tags></tags></post>",
+		},
+		{
+			name:  "whitespace only text is kept",
+			cdata: []string{"post"},
+			value: post{Body: "a"},
+			want: "<post>\n  <title></title>\n  <body>a</body>\n" +
+				"  <tags></tags>\n</post>",
+			pretty: true,
+		},
+		{
+			name:        "with self closing",
+			cdata:       []string{"body"},
+			selfClosing: true,
+			value:       post{Body: "<br/>"},
+			want: "<post><title/><body><![CDATA[<br/>]]></body>" +
+				"<tags/></post>",
+		},
+		{
+			name:  "maps",
+			cdata: []string{"b"},
+			value: map[string]any{"a": "<1>", "b": "<2>"},
+			want: "<root><a>&lt;1&gt;</a>" +
+				"<b><![CDATA[<2>]]></b></root>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XML{CDATA: tt.cdata, SelfClosing: tt.selfClosing}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = x.RenderPretty(&buf, tt.value)
+			} else {
+				err = x.Render(&buf, tt.value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_xmlCDATAParam(t *testing.T) {
+	value := map[string]string{"a": "<1>", "b": "<2>", "c": "<3>"}
+
+	got, err := Base.String("xml;cdata=a,c", false, value)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"<root><a><![CDATA[<1>]]></a><b>&lt;2&gt;</b>"+
+			"<c><![CDATA[<3>]]></c></root>",
+		got,
+	)
+}
+
+func TestXML_TrailingNewline(t *testing.T) {
+	value := map[string]int{"a": 1}
+
+	tests := []struct {
+		name    string
+		newline bool
+		pretty  bool
+		want    string
+	}{
+		{
+			name: "disabled",
+			want: "<root><a>1</a></root>",
+		},
+		{
+			name:    "enabled",
+			newline: true,
+			want:    "<root><a>1</a></root>\n",
+		},
+		{
+			name:    "enabled pretty",
+			newline: true,
+			pretty:  true,
+			want:    "<root>\n  <a>1</a>\n</root>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &XML{TrailingNewline: tt.newline}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = x.RenderPretty(&buf, value)
+			} else {
+				err = x.Render(&buf, value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("write error", func(t *testing.T) {
+		x := &XML{TrailingNewline: true, SelfClosing: true}
+		w := &mockWriter{WriteErr: errors.New("write error")}
+
+		err := x.Render(w, value)
+
+		assert.EqualError(t, err, "render: failed: write error")
+		assert.ErrorIs(t, err, ErrFailed)
+	})
+}
+
+func TestRenderer_Render_xmlTrailingNewline(t *testing.T) {
+	r := Base.NewWith("json", "xml")
+	value := map[string]int{"a": 1}
+
+	got, err := r.String("xml;trailing_newline", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "<root><a>1</a></root>\n", got)
+
+	r.PostProcessors = []PostProcessor{TrailingNewline()}
+	got, err = r.String("xml", false, value)
+	require.NoError(t, err)
+	assert.Equal(t, "<root><a>1</a></root>\n", got)
+}
+
 func TestXML_Formats(t *testing.T) {
 	h := &XML{}
 
 	assert.Equal(t, []string{"xml"}, h.Formats())
 }
+
+func TestXML_WithOptions(t *testing.T) {
+	h := &XML{Prefix: "//", Indent: "  "}
+
+	assert.Same(t, h, h.WithOptions(&Options{Pretty: true}))
+	assert.Equal(t,
+		&XML{Prefix: "//", Indent: "\t"},
+		h.WithOptions(&Options{Indent: "\t"}),
+	)
+	assert.Equal(t,
+		&XML{Prefix: "> ", Indent: "  "},
+		h.WithOptions(&Options{Prefix: "> "}),
+	)
+	assert.Equal(t, &XML{Prefix: "//", Indent: "  "}, h)
+}
+
+func TestXML_NewEncoder(t *testing.T) {
+	type item struct {
+		XMLName struct{} `xml:"item"`
+		Name    string   `xml:"name"`
+	}
+	h := &XML{}
+
+	var buf bytes.Buffer
+	enc := h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode(item{Name: "a"}))
+	require.NoError(t, enc.Encode(item{Name: "b"}))
+	assert.Equal(t,
+		"<item><name>a</name></item><item><name>b</name></item>",
+		buf.String(),
+	)
+
+	buf.Reset()
+	enc = h.NewEncoder(&buf, true)
+	require.NoError(t, enc.Encode(item{Name: "a"}))
+	assert.Equal(t, "<item>\n  <name>a</name>\n</item>", buf.String())
+
+	buf.Reset()
+	enc = h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode(map[string]string{"name": "a"}))
+	assert.Equal(t, "<root><name>a</name></root>", buf.String())
+}
+
+func TestXML_Parse(t *testing.T) {
+	h := &XML{}
+
+	var got struct {
+		XMLName xml.Name `xml:"item"`
+		Name    string   `xml:"name"`
+	}
+	err := h.Parse(strings.NewReader("<item><name>a</name></item>"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.Name)
+
+	err = h.Parse(strings.NewReader("<item>"), &got)
+	assert.EqualError(t, err, "render: failed: XML syntax error on line 1: "+
+		"unexpected EOF")
+	assert.ErrorIs(t, err, ErrFailed)
+
+	var v any
+	err = h.Parse(strings.NewReader("<item/>"), &v)
+	assert.EqualError(t, err, "render: cannot render: *interface {}")
+	assert.ErrorIs(t, err, ErrCannotRender)
+}
@@ -1,8 +1,14 @@
 package render
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,28 +16,732 @@ import (
 var YAMLDefaultIndent = 2
 
 // YAML is a Handler that marshals the given value to YAML.
+//
+// Map keys are always sorted, so output is byte-stable across runs.
 type YAML struct {
 	// Indent controls how many spaces will be used for indenting nested blocks
 	// in the output YAML. When Indent is zero, YAMLDefaultIndent will be used.
 	Indent int
+
+	// Flow renders compact output in single-line flow style, like
+	// "{age: 30, tags: [a, b]}", instead of the block style used for pretty
+	// output. By default compact and pretty output are both block style.
+	// Flow is only supported by the default engine, and ignored otherwise.
+	Flow bool
+
+	// Null controls how nil values are rendered. By default they are rendered
+	// as "null". It is only supported by the default engine.
+	Null YAMLNull
+
+	// Quote controls when string values are quoted. By default they are only
+	// quoted when required. Map keys are never forced to be quoted. It is only
+	// supported by the default engine.
+	Quote YAMLQuote
+
+	// Anchors renders maps and lists which are shared between multiple
+	// pointers, maps, or slices in full only once with an anchor like "&a1",
+	// and as aliases like "*a1" wherever they are repeated. Repeats are
+	// detected by identity, so equal values which are not shared are rendered
+	// in full. This shrinks large documents with a lot of repetition. It is
+	// only supported by the default engine.
+	Anchors bool
+
+	// LineWidth is the preferred maximum width of output lines. String values
+	// which would exceed it are folded over multiple lines at spaces, in
+	// folded block style (">-"). If zero, strings are always kept on a single
+	// line. It is only supported by the default engine, and ignored with Flow.
+	LineWidth int
+
+	// MultiDocument renders slice and array values as a stream of YAML
+	// documents separated by "---", one for each element, instead of a single
+	// YAML sequence. This is how Kubernetes style tooling expects lists of
+	// manifests.
+	MultiDocument bool
+
+	// UseJSONTags names struct fields by their json struct tag, as in JSON
+	// output, instead of by their yaml struct tag or lowercased field name.
+	// Fields tagged with `json:"-"` are skipped, and fields with the
+	// "omitempty" option are skipped if empty. This allows rendering structs
+	// which are only tagged for JSON with the expected keys.
+	UseJSONTags bool
+
+	// Color syntax-highlights keys, strings, numbers, booleans, and nulls with
+	// ANSI escape sequences when pretty rendering, for readability in
+	// terminals. It does not apply to encoders from NewEncoder.
+	Color bool
+
+	// Engine is the YAML implementation used to encode and decode values. If
+	// nil, gopkg.in/yaml.v3 is used.
+	Engine YAMLEngine
+}
+
+// YAMLNull is a style for rendering nil values with the YAML handler.
+type YAMLNull int
+
+const (
+	// YAMLNullKeyword renders nil values as "null".
+	YAMLNullKeyword YAMLNull = iota
+
+	// YAMLNullTilde renders nil values as "~".
+	YAMLNullTilde
+
+	// YAMLNullEmpty renders nil values as nothing, like "key:".
+	YAMLNullEmpty
+)
+
+// YAMLQuote is a style for quoting string values with the YAML handler.
+type YAMLQuote int
+
+const (
+	// YAMLQuoteMinimal only quotes strings which would otherwise be parsed as
+	// another type, or are not valid as plain YAML scalars.
+	YAMLQuoteMinimal YAMLQuote = iota
+
+	// YAMLQuoteSingle quotes all string values with single quotes.
+	YAMLQuoteSingle
+
+	// YAMLQuoteDouble quotes all string values with double quotes.
+	YAMLQuoteDouble
+)
+
+var (
+	yamlNullParams = map[string]YAMLNull{
+		"null":  YAMLNullKeyword,
+		"~":     YAMLNullTilde,
+		"empty": YAMLNullEmpty,
+	}
+	yamlQuoteParams = map[string]YAMLQuote{
+		"minimal": YAMLQuoteMinimal,
+		"single":  YAMLQuoteSingle,
+		"double":  YAMLQuoteDouble,
+	}
+)
+
+// YAMLEngine is a YAML implementation used by the YAML handler. It allows
+// replacing gopkg.in/yaml.v3 with alternatives like goccy/go-yaml, which
+// differ in their Marshaler interfaces and edge case behavior, usually with a
+// small adapter type:
+//
+//	type goccyEngine struct{}
+//
+//	func (goccyEngine) NewEncoder(w io.Writer, indent int) render.YAMLEncoder {
+//		return goyaml.NewEncoder(w, goyaml.Indent(indent))
+//	}
+//
+//	func (goccyEngine) NewDecoder(r io.Reader) render.YAMLDecoder {
+//		return goyaml.NewDecoder(r)
+//	}
+type YAMLEngine interface {
+	// NewEncoder returns a YAMLEncoder which writes to w, indenting nested
+	// blocks with the given number of spaces.
+	NewEncoder(w io.Writer, indent int) YAMLEncoder
+
+	// NewDecoder returns a YAMLDecoder which reads from r.
+	NewDecoder(r io.Reader) YAMLDecoder
+}
+
+// YAMLEncoder is the interface of a YAML encoder returned by a YAMLEngine, as
+// implemented by *yaml.Encoder.
+type YAMLEncoder interface {
+	// Encode writes the YAML encoding of v as a YAML document, separated from
+	// any previous documents by "---".
+	Encode(v any) error
+
+	// Close flushes any remaining output.
+	Close() error
+}
+
+// YAMLDecoder is the interface of a YAML decoder returned by a YAMLEngine, as
+// implemented by *yaml.Decoder.
+type YAMLDecoder interface {
+	// Decode reads the next YAML document into v.
+	Decode(v any) error
+}
+
+// stdYAMLEngine is a YAMLEngine using gopkg.in/yaml.v3.
+type stdYAMLEngine struct{}
+
+func (stdYAMLEngine) NewEncoder(w io.Writer, indent int) YAMLEncoder {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(indent)
+
+	return enc
+}
+
+func (stdYAMLEngine) NewDecoder(r io.Reader) YAMLDecoder {
+	return yaml.NewDecoder(r)
 }
 
 var (
-	_ Handler        = (*YAML)(nil)
-	_ FormatsHandler = (*YAML)(nil)
+	_ Handler            = (*YAML)(nil)
+	_ PrettyHandler      = (*YAML)(nil)
+	_ FormatsHandler     = (*YAML)(nil)
+	_ DescribedHandler   = (*YAML)(nil)
+	_ ContentTypeHandler = (*YAML)(nil)
+	_ OptionsHandler     = (*YAML)(nil)
+	_ CommentHandler     = (*YAML)(nil)
+	_ EncoderHandler     = (*YAML)(nil)
+	_ ParseHandler       = (*YAML)(nil)
 )
 
-// Render marshals the given value to YAML.
+// Render marshals the given value to YAML, in flow style if Flow is set.
+//
+// The yaml.v3 package panics on values it cannot marshal, like channels and
+// functions. Such panics are recovered and returned as ErrFailed errors.
 func (y *YAML) Render(w io.Writer, v any) error {
+	return y.render(w, v, false)
+}
+
+// RenderPretty marshals the given value to indented block style YAML.
+func (y *YAML) RenderPretty(w io.Writer, v any) error {
+	return y.render(w, v, true)
+}
+
+// render marshals v to w, as separate documents if MultiDocument is set and v
+// is a slice or array.
+func (y *YAML) render(w io.Writer, v any, pretty bool) error {
+	if pretty && y.Color {
+		var buf bytes.Buffer
+		c := *y
+		c.Color = false
+		if err := c.render(&buf, v, pretty); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(yamlColorize(buf.Bytes())); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+
+		return nil
+	}
+
+	enc := y.NewEncoder(w, pretty)
+
+	rv := reflect.ValueOf(v)
+	if !y.MultiDocument || !yamlDocuments(rv) {
+		return enc.Encode(v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// yamlDocuments reports if rv is a slice or array which is rendered as a
+// stream of documents with MultiDocument.
+func yamlDocuments(rv reflect.Value) bool {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	return rv.Type().Elem().Kind() != reflect.Uint8 &&
+		!rv.Type().Implements(yamlMarshalerType)
+}
+
+// NewEncoder returns an encoder which writes values to w as separate YAML
+// documents. Values are written in flow style if Flow is set and pretty is
+// false, and in indented block style otherwise.
+func (y *YAML) NewEncoder(w io.Writer, pretty bool) ValueEncoder {
 	indent := y.Indent
 	if indent == 0 {
 		indent = YAMLDefaultIndent
 	}
 
-	enc := yaml.NewEncoder(w)
-	enc.SetIndent(indent)
+	e := &yamlEncoder{
+		enc:      y.engine().NewEncoder(w, indent),
+		jsonTags: y.UseJSONTags,
+	}
+	if y.Engine == nil {
+		e.flow = y.Flow && !pretty
+		e.null = y.Null
+		e.quote = y.Quote
+		e.anchors = y.Anchors
+
+		if y.LineWidth > 0 && !e.flow {
+			e.folder = &yamlFolder{w: w, width: y.LineWidth, indent: indent}
+			e.enc = stdYAMLEngine{}.NewEncoder(&e.folder.buf, indent)
+		}
+	}
+
+	return e
+}
+
+// engine returns the YAMLEngine of the handler, or the default engine if none
+// is set.
+func (y *YAML) engine() YAMLEngine {
+	if y.Engine == nil {
+		return stdYAMLEngine{}
+	}
+
+	return y.Engine
+}
+
+// yamlEncoder wraps a YAMLEncoder, wrapping errors with ErrFailed, and
+// recovering panics from marshaling into ErrFailed errors.
+type yamlEncoder struct {
+	enc      YAMLEncoder
+	jsonTags bool
+	flow     bool
+	null     YAMLNull
+	quote    YAMLQuote
+	anchors  bool
+	folder   *yamlFolder
+}
+
+func (e *yamlEncoder) Encode(v any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr, ok := r.(error); ok {
+				err = fmt.Errorf("%w: %w", ErrFailed, rerr)
+			} else {
+				err = fmt.Errorf("%w: %v", ErrFailed, r)
+			}
+		}
+	}()
+
+	if e.jsonTags {
+		v = (&valueTransformer{}).apply(v)
+	}
+	if e.flow || e.null != YAMLNullKeyword || e.quote != YAMLQuoteMinimal ||
+		e.anchors || e.folder != nil {
+		var n yaml.Node
+		if err = n.Encode(v); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailed, err)
+		}
+		e.style(&n, false)
+		if e.anchors {
+			(&yamlAnchorer{}).anchor(&n, v)
+		}
+		if e.folder != nil {
+			e.folder.mark(&n)
+		}
+		v = &n
+	}
+
+	err = e.enc.Encode(v)
+	if err == nil && e.folder != nil {
+		err = e.folder.flush()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+func (e *yamlEncoder) Close() error {
+	err := e.enc.Close()
+	if err == nil && e.folder != nil {
+		err = e.folder.flush()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailed, err)
+	}
+
+	return nil
+}
+
+// style applies the flow, null, and quote styles of the encoder to n and all
+// nodes nested within it. The key argument reports if n is a mapping key.
+func (e *yamlEncoder) style(n *yaml.Node, key bool) {
+	switch n.Kind { //nolint:exhaustive
+	case yaml.MappingNode, yaml.SequenceNode:
+		if e.flow {
+			n.Style |= yaml.FlowStyle
+		}
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null":
+			switch e.null {
+			case YAMLNullTilde:
+				n.Value = "~"
+			case YAMLNullEmpty:
+				n.Value = ""
+			}
+		case "!!str":
+			switch {
+			case key:
+			case e.quote == YAMLQuoteSingle:
+				n.Style = yaml.SingleQuotedStyle
+			case e.quote == YAMLQuoteDouble:
+				n.Style = yaml.DoubleQuotedStyle
+			}
+		}
+	}
+
+	for i, c := range n.Content {
+		e.style(c, n.Kind == yaml.MappingNode && i%2 == 0)
+	}
+}
+
+// yamlAnchorer replaces mapping and sequence nodes rendered from pointers,
+// maps, and slices which were already rendered earlier in the document, with
+// aliases of their first occurrence.
+type yamlAnchorer struct {
+	seen     map[yamlRef]*yaml.Node
+	anchored map[*yaml.Node]bool
+	count    int
+}
 
-	err := enc.Encode(v)
+// yamlRef identifies the value referenced by a pointer, map, or slice.
+type yamlRef struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// anchor walks the node n together with the value v it was encoded from,
+// replacing nodes of repeated references with aliases. Nodes which cannot be
+// matched to the value they were encoded from, like the output of marshalers,
+// are left as is.
+func (a *yamlAnchorer) anchor(n *yaml.Node, v any) {
+	a.seen = map[yamlRef]*yaml.Node{}
+	a.anchored = map[*yaml.Node]bool{}
+	a.walk(&n, reflect.ValueOf(v), 0)
+	if len(a.anchored) > 0 {
+		a.name(n)
+	}
+}
+
+// name names the anchors of all anchored nodes within n in document order, and
+// sets the names of the aliases referring to them.
+func (a *yamlAnchorer) name(n *yaml.Node) {
+	switch {
+	case n.Kind == yaml.AliasNode:
+		n.Value = n.Alias.Anchor
+	case a.anchored[n]:
+		a.count++
+		n.Anchor = "a" + strconv.Itoa(a.count)
+	}
+
+	for _, c := range n.Content {
+		a.name(c)
+	}
+}
+
+func (a *yamlAnchorer) walk(slot **yaml.Node, rv reflect.Value, depth int) {
+	n := *slot
+	if !rv.IsValid() || depth > maxDepth {
+		return
+	}
+
+	if (n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode) &&
+		len(n.Content) > 0 {
+		if ref, ok := newYAMLRef(rv); ok {
+			if first, ok := a.seen[ref]; ok {
+				a.anchored[first] = true
+				*slot = &yaml.Node{Kind: yaml.AliasNode, Alias: first}
+
+				return
+			}
+			a.seen[ref] = n
+		}
+	}
+
+	if rv.Kind() != reflect.Interface &&
+		(rv.Type().Implements(yamlMarshalerType) ||
+			rv.Type().Implements(textMarshalerType)) {
+		return
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface:
+		if !rv.IsNil() {
+			a.walk(slot, rv.Elem(), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		if n.Kind != yaml.SequenceNode || len(n.Content) != rv.Len() {
+			return
+		}
+		for i := range n.Content {
+			a.walk(&n.Content[i], rv.Index(i), depth+1)
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if mv := yamlMapIndex(rv, n.Content[i]); mv.IsValid() {
+				a.walk(&n.Content[i+1], mv, depth+1)
+			}
+		}
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		fields, inline := yamlFields(rv.Type())
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			var fv reflect.Value
+			if index, ok := fields[n.Content[i].Value]; ok {
+				fv, _ = rv.FieldByIndexErr(index)
+			} else if inline != nil {
+				if m, err := rv.FieldByIndexErr(inline); err == nil {
+					fv = yamlMapIndex(m, n.Content[i])
+				}
+			}
+			if fv.IsValid() {
+				a.walk(&n.Content[i+1], fv, depth+1)
+			}
+		}
+	}
+}
+
+// newYAMLRef returns the yamlRef of rv, if it is a non-nil pointer, map, or
+// slice.
+func newYAMLRef(rv reflect.Value) (yamlRef, bool) {
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Map:
+		if !rv.IsNil() {
+			return yamlRef{ptr: rv.Pointer(), typ: rv.Type()}, true
+		}
+	case reflect.Slice:
+		if !rv.IsNil() {
+			return yamlRef{
+				ptr: rv.Pointer(), typ: rv.Type(), len: rv.Len(),
+			}, true
+		}
+	}
+
+	return yamlRef{}, false
+}
+
+// yamlMapIndex returns the value of the map m for the key encoded as the node
+// key, or the zero Value if it cannot be found.
+func yamlMapIndex(m reflect.Value, key *yaml.Node) reflect.Value {
+	if m.Kind() != reflect.Map || m.IsNil() {
+		return reflect.Value{}
+	}
+
+	k := reflect.New(m.Type().Key())
+	if err := key.Decode(k.Interface()); err != nil {
+		return reflect.Value{}
+	}
+	if !k.Elem().Type().Comparable() {
+		return reflect.Value{}
+	}
+
+	return m.MapIndex(k.Elem())
+}
+
+// yamlFields returns the index of each field of the struct type t by the key
+// it is encoded with, including fields of inlined structs, and the index of
+// the inlined map field, if there is one.
+func yamlFields(t reflect.Type) (map[string][]int, []int) {
+	fields := map[string][]int{}
+	var inline []int
+
+	var add func(t reflect.Type, index []int)
+	add = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() && !f.Anonymous {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if name == "-" {
+				continue
+			}
+			idx := append(index[:len(index):len(index)], i)
+
+			inlined := false
+			for _, opt := range strings.Split(opts, ",") {
+				inlined = inlined || opt == "inline"
+			}
+			if inlined {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				switch ft.Kind() { //nolint:exhaustive
+				case reflect.Struct:
+					add(ft, idx)
+				case reflect.Map:
+					inline = idx
+				}
+
+				continue
+			}
+
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			fields[name] = idx
+		}
+	}
+	add(t, nil)
+
+	return fields, inline
+}
+
+// yamlFolder folds long string values over multiple lines. As yaml.v3 does not
+// support setting the line width, string values are replaced with unique
+// placeholders before encoding, which are then replaced with the folded values
+// in the encoded output.
+type yamlFolder struct {
+	w      io.Writer
+	buf    bytes.Buffer
+	width  int
+	indent int
+	folds  []yamlFold
+}
+
+// yamlFold is a string value which was replaced with a placeholder.
+type yamlFold struct {
+	placeholder string
+	value       string
+	style       yaml.Style
+}
+
+// mark replaces all string values within n which may need to be folded with
+// placeholders.
+func (f *yamlFolder) mark(n *yaml.Node) {
+	prefix := "render-fold-"
+	for i := 0; yamlContains(n, prefix); i++ {
+		prefix = "render-fold-" + strconv.Itoa(i) + "-"
+	}
+
+	var walk func(n *yaml.Node, key bool)
+	walk = func(n *yaml.Node, key bool) {
+		if !key && yamlFoldable(n) {
+			f.folds = append(f.folds, yamlFold{
+				placeholder: prefix + strconv.Itoa(len(f.folds)),
+				value:       n.Value,
+				style:       n.Style,
+			})
+			n.Value = f.folds[len(f.folds)-1].placeholder
+			n.Style = 0
+		}
+		for i, c := range n.Content {
+			walk(c, n.Kind == yaml.MappingNode && i%2 == 0)
+		}
+	}
+	walk(n, false)
+}
+
+// flush replaces all placeholders in the encoded output with their values,
+// folded if they exceed the line width, and writes it.
+func (f *yamlFolder) flush() error {
+	out := f.buf.String()
+	f.buf.Reset()
+
+	for _, fold := range f.folds {
+		i := strings.Index(out, fold.placeholder+"\n")
+		if i < 0 {
+			continue
+		}
+
+		start := strings.LastIndexByte(out[:i], '\n') + 1
+		col := utf8.RuneCountInString(out[start:i])
+
+		var lines []string
+		if col+utf8.RuneCountInString(fold.value) > f.width {
+			lines = f.wrap(fold.value, yamlIndent(out[start:i])+f.indent)
+		}
+
+		var repl string
+		if len(lines) > 1 {
+			pad := strings.Repeat(" ", yamlIndent(out[start:i])+f.indent)
+			repl = ">-\n" + pad + strings.Join(lines, "\n"+pad)
+		} else {
+			b, err := yaml.Marshal(&yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!str",
+				Value: fold.value,
+				Style: fold.style,
+			})
+			if err != nil {
+				return err
+			}
+			repl = strings.TrimSuffix(string(b), "\n")
+		}
+
+		out = out[:i] + repl + out[i+len(fold.placeholder):]
+	}
+	f.folds = nil
+
+	_, err := io.WriteString(f.w, out)
+
+	return err
+}
+
+// wrap splits s into lines no wider than the line width when indented by
+// indent columns, where possible. Lines are only split at single spaces.
+func (f *yamlFolder) wrap(s string, indent int) []string {
+	avail := f.width - indent
+	words := strings.Split(s, " ")
+
+	var lines []string
+	line := words[0]
+	for i := 1; i < len(words); i++ {
+		w := words[i]
+		if words[i-1] != "" && w != "" &&
+			utf8.RuneCountInString(line)+1+utf8.RuneCountInString(w) > avail {
+			lines = append(lines, line)
+			line = w
+
+			continue
+		}
+		line += " " + w
+	}
+
+	return append(lines, line)
+}
+
+// yamlFoldable reports if n is a string value which can be folded. Only
+// single line strings containing spaces, which do not start or end with
+// whitespace, and only contain printable characters, can be folded.
+func yamlFoldable(n *yaml.Node) bool {
+	if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!str" ||
+		!strings.Contains(n.Value, " ") ||
+		strings.TrimSpace(n.Value) != n.Value {
+		return false
+	}
+
+	for _, r := range n.Value {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// yamlContains reports if the value of n or any node within it contains s.
+func yamlContains(n *yaml.Node, s string) bool {
+	if strings.Contains(n.Value, s) {
+		return true
+	}
+	for _, c := range n.Content {
+		if yamlContains(c, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// yamlIndent returns the indentation of the block which a value following the
+// given line prefix belongs to, including any sequence entry indicators.
+func yamlIndent(prefix string) int {
+	n := len(prefix) - len(strings.TrimLeft(prefix, " "))
+	rest := prefix[n:]
+	for strings.HasPrefix(rest, "- ") {
+		trimmed := strings.TrimLeft(rest[1:], " ")
+		n += len(rest) - len(trimmed)
+		rest = trimmed
+	}
+
+	return n
+}
+
+// Parse decodes the first YAML document from r into v.
+func (y *YAML) Parse(r io.Reader, v any) error {
+	err := y.engine().NewDecoder(r).Decode(v)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailed, err)
 	}
@@ -43,3 +753,76 @@ func (y *YAML) Render(w io.Writer, v any) error {
 func (y *YAML) Formats() []string {
 	return []string{"yaml", "yml"}
 }
+
+// WithOptions returns a copy of the YAML handler with the Indent option
+// applied, if it only contains spaces. The "flow", "multi_document",
+// "json_tags", "anchors", and "color" parameters set the Flow, MultiDocument,
+// UseJSONTags, Anchors, and Color fields, if they are valid booleans.
+//
+// The "null" parameter sets the Null field to YAMLNullKeyword, YAMLNullTilde,
+// or YAMLNullEmpty for the values "null", "~", or "empty". The "quote"
+// parameter sets the Quote field to YAMLQuoteMinimal, YAMLQuoteSingle, or
+// YAMLQuoteDouble for the values "minimal", "single", or "double". The
+// "line_width" parameter sets the LineWidth field, if it is a valid integer.
+func (y *YAML) WithOptions(opts *Options) Handler {
+	hasIndent := opts.Indent != "" && strings.Trim(opts.Indent, " ") == ""
+	flow, hasFlow := opts.boolParam("flow")
+	multiDoc, hasMultiDoc := opts.boolParam("multi_document")
+	jsonTags, hasJSONTags := opts.boolParam("json_tags")
+	anchors, hasAnchors := opts.boolParam("anchors")
+	color, hasColor := opts.boolParam("color")
+	null, hasNull := yamlNullParams[opts.Params["null"]]
+	quote, hasQuote := yamlQuoteParams[opts.Params["quote"]]
+	width, err := strconv.Atoi(opts.Params["line_width"])
+	hasWidth := err == nil && width >= 0
+	if !hasIndent && !hasFlow && !hasMultiDoc && !hasJSONTags &&
+		!hasAnchors && !hasColor && !hasNull && !hasQuote && !hasWidth {
+		return y
+	}
+
+	c := *y
+	if hasIndent {
+		c.Indent = len(opts.Indent)
+	}
+	if hasFlow {
+		c.Flow = flow
+	}
+	if hasMultiDoc {
+		c.MultiDocument = multiDoc
+	}
+	if hasJSONTags {
+		c.UseJSONTags = jsonTags
+	}
+	if hasAnchors {
+		c.Anchors = anchors
+	}
+	if hasColor {
+		c.Color = color
+	}
+	if hasNull {
+		c.Null = null
+	}
+	if hasQuote {
+		c.Quote = quote
+	}
+	if hasWidth {
+		c.LineWidth = width
+	}
+
+	return &c
+}
+
+// Description returns a short human-readable description of the format.
+func (y *YAML) Description() string {
+	return "YAML, human and machine readable"
+}
+
+// ContentType returns the MIME type of the format.
+func (y *YAML) ContentType() string {
+	return "application/yaml"
+}
+
+// CommentPrefix returns the string which starts a line comment in YAML.
+func (y *YAML) CommentPrefix() string {
+	return "# "
+}
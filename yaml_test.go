@@ -3,9 +3,13 @@ package render
 import (
 	"bytes"
 	"errors"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,6 +24,39 @@ func (m *mockYAMLMarshaler) MarshalYAML() (any, error) {
 	return m.val, m.err
 }
 
+// mockYAMLEngine is a YAMLEngine which wraps yaml.v3, upper casing all encoded
+// output, and recording indents and decoded values.
+type mockYAMLEngine struct {
+	indents []int
+	decoded []any
+}
+
+var _ YAMLEngine = (*mockYAMLEngine)(nil)
+
+func (m *mockYAMLEngine) NewEncoder(w io.Writer, indent int) YAMLEncoder {
+	m.indents = append(m.indents, indent)
+	enc := yaml.NewEncoder(&mockUpperWriter{w: w})
+	enc.SetIndent(indent)
+
+	return enc
+}
+
+func (m *mockYAMLEngine) NewDecoder(r io.Reader) YAMLDecoder {
+	return &mockYAMLDecoder{engine: m, dec: yaml.NewDecoder(r)}
+}
+
+type mockYAMLDecoder struct {
+	engine *mockYAMLEngine
+	dec    *yaml.Decoder
+}
+
+func (m *mockYAMLDecoder) Decode(v any) error {
+	err := m.dec.Decode(v)
+	m.engine.decoded = append(m.engine.decoded, v)
+
+	return err
+}
+
 func TestYAML_Render(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -28,7 +65,6 @@ func TestYAML_Render(t *testing.T) {
 		want      string
 		wantErr   string
 		wantErrIs []error
-		wantPanic string
 	}{
 		{
 			name:  "simple object default indent",
@@ -72,7 +108,8 @@ func TestYAML_Render(t *testing.T) {
 			name:      "invalid value",
 			indent:    0,
 			value:     make(chan int),
-			wantPanic: "cannot marshal type: chan int",
+			wantErr:   "render: failed: cannot marshal type: chan int",
+			wantErrIs: []error{Err, ErrFailed},
 		},
 	}
 	for _, tt := range tests {
@@ -82,22 +119,9 @@ func TestYAML_Render(t *testing.T) {
 			}
 
 			var buf bytes.Buffer
-			var err error
-			var panicRes any
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						panicRes = r
-					}
-				}()
-				err = j.Render(&buf, tt.value)
-			}()
-
+			err := j.Render(&buf, tt.value)
 			got := buf.String()
 
-			if tt.wantPanic != "" {
-				assert.Equal(t, tt.wantPanic, panicRes)
-			}
 			if tt.wantErr != "" {
 				assert.EqualError(t, err, tt.wantErr)
 			}
@@ -105,8 +129,7 @@ func TestYAML_Render(t *testing.T) {
 				assert.ErrorIs(t, err, e)
 			}
 
-			if tt.wantPanic == "" &&
-				tt.wantErr == "" && len(tt.wantErrIs) == 0 {
+			if tt.wantErr == "" && len(tt.wantErrIs) == 0 {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want, got)
 			}
@@ -114,8 +137,616 @@ func TestYAML_Render(t *testing.T) {
 	}
 }
 
+func TestYAML_Flow(t *testing.T) {
+	value := map[string]any{
+		"age":  30,
+		"name": "Doe, John",
+		"tags": []string{"a", "b"},
+		"meta": map[string]any{"k": "{v}"},
+	}
+
+	tests := []struct {
+		name   string
+		flow   bool
+		pretty bool
+		value  any
+		want   string
+	}{
+		{
+			name:  "compact without flow",
+			value: value,
+			want: "age: 30\nmeta:\n  k: '{v}'\nname: Doe, John\n" +
+				"tags:\n  - a\n  - b\n",
+		},
+		{
+			name:  "compact with flow",
+			flow:  true,
+			value: value,
+			want: "{age: 30, meta: {k: '{v}'}, name: 'Doe, John', " +
+				"tags: [a, b]}\n",
+		},
+		{
+			name:   "pretty with flow",
+			flow:   true,
+			pretty: true,
+			value:  map[string]any{"age": 30},
+			want:   "age: 30\n",
+		},
+		{
+			name:  "scalar with flow",
+			flow:  true,
+			value: "hello",
+			want:  "hello\n",
+		},
+		{
+			name:  "yaml.Marshaler with flow",
+			flow:  true,
+			value: &mockYAMLMarshaler{val: []int{1, 2}},
+			want:  "[1, 2]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &YAML{Flow: tt.flow}
+
+			var buf bytes.Buffer
+			var err error
+			if tt.pretty {
+				err = h.RenderPretty(&buf, tt.value)
+			} else {
+				err = h.Render(&buf, tt.value)
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestYAML_Flow_errors(t *testing.T) {
+	h := &YAML{Flow: true}
+
+	var buf bytes.Buffer
+	err := h.Render(&buf, &mockYAMLMarshaler{err: errors.New("mock error")})
+
+	assert.EqualError(t, err, "render: failed: mock error")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestYAML_MultiDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr string
+	}{
+		{
+			name: "slice",
+			value: []map[string]any{
+				{"kind": "Service", "name": "web"},
+				{"kind": "Deployment", "name": "web"},
+			},
+			want: "kind: Service\nname: web\n---\n" +
+				"kind: Deployment\nname: web\n",
+		},
+		{
+			name:  "array",
+			value: [2]string{"a", "b"},
+			want:  "a\n---\nb\n",
+		},
+		{
+			name:  "empty slice",
+			value: []int{},
+			want:  "",
+		},
+		{
+			name:  "bytes",
+			value: []byte("hi"),
+			want:  "- 104\n- 105\n",
+		},
+		{
+			name:  "non-slice",
+			value: map[string]int{"age": 30},
+			want:  "age: 30\n",
+		},
+		{
+			name:    "element error",
+			value:   []any{1, make(chan int)},
+			wantErr: "render: failed: cannot marshal type: chan int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &YAML{MultiDocument: true}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrFailed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_yamlMultiDocumentParam(t *testing.T) {
+	got, err := Base.String("yaml;multi_document", false, []int{1, 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1\n---\n2\n", got)
+}
+
+func TestYAML_Color(t *testing.T) {
+	value := map[string]any{"a": []any{"b", 1, true, nil}}
+	h := &YAML{Color: true}
+
+	var buf bytes.Buffer
+	err := h.RenderPretty(&buf, value)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"\x1b[1;34ma\x1b[0m:\n"+
+			"  - \x1b[32mb\x1b[0m\n"+
+			"  - \x1b[36m1\x1b[0m\n"+
+			"  - \x1b[33mtrue\x1b[0m\n"+
+			"  - \x1b[90mnull\x1b[0m\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	err = h.Render(&buf, value)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n  - b\n  - 1\n  - true\n  - null\n", buf.String())
+}
+
+func TestRenderer_Render_yamlColorParam(t *testing.T) {
+	got, err := Base.String("yaml;color", true, map[string]int{"a": 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b[1;34ma\x1b[0m: \x1b[36m1\x1b[0m\n", got)
+}
+
+func TestYAML_UseJSONTags(t *testing.T) {
+	type item struct {
+		ID       int    `json:"id"`
+		FullName string `json:"full_name" yaml:"name"`
+		Note     string `json:"note,omitempty"`
+		Secret   string `json:"-"`
+		Plain    bool
+	}
+	value := map[string]any{
+		"items": []item{{ID: 1, FullName: "John", Secret: "x"}},
+	}
+
+	tests := []struct {
+		name     string
+		jsonTags bool
+		flow     bool
+		want     string
+	}{
+		{
+			name: "without json tags",
+			want: "items:\n  - id: 1\n    name: John\n    note: \"\"\n" +
+				"    secret: x\n    plain: false\n",
+		},
+		{
+			name:     "with json tags",
+			jsonTags: true,
+			want: "items:\n  - id: 1\n    full_name: John\n" +
+				"    Plain: false\n",
+		},
+		{
+			name:     "with json tags and flow",
+			jsonTags: true,
+			flow:     true,
+			want:     "{items: [{id: 1, full_name: John, Plain: false}]}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &YAML{UseJSONTags: tt.jsonTags, Flow: tt.flow}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestYAML_NullAndQuote(t *testing.T) {
+	value := map[string]any{
+		"name":  "John",
+		"bio":   "line 1\nline 2",
+		"count": 2,
+		"none":  nil,
+		"tags":  []any{"a", nil},
+	}
+
+	tests := []struct {
+		name  string
+		null  YAMLNull
+		quote YAMLQuote
+		flow  bool
+		want  string
+	}{
+		{
+			name: "defaults",
+			want: "bio: |-\n  line 1\n  line 2\ncount: 2\nname: John\n" +
+				"none: null\ntags:\n  - a\n  - null\n",
+		},
+		{
+			name: "null tilde",
+			null: YAMLNullTilde,
+			want: "bio: |-\n  line 1\n  line 2\ncount: 2\nname: John\n" +
+				"none: ~\ntags:\n  - a\n  - ~\n",
+		},
+		{
+			name: "null empty",
+			null: YAMLNullEmpty,
+			want: "bio: |-\n  line 1\n  line 2\ncount: 2\nname: John\n" +
+				"none:\ntags:\n  - a\n  -\n",
+		},
+		{
+			name:  "quote single",
+			quote: YAMLQuoteSingle,
+			want: "bio: 'line 1\n\n  line 2'\ncount: 2\nname: 'John'\n" +
+				"none: null\ntags:\n  - 'a'\n  - null\n",
+		},
+		{
+			name:  "quote double",
+			quote: YAMLQuoteDouble,
+			want: "bio: \"line 1\\nline 2\"\ncount: 2\nname: \"John\"\n" +
+				"none: null\ntags:\n  - \"a\"\n  - null\n",
+		},
+		{
+			name:  "flow with null tilde and quote double",
+			null:  YAMLNullTilde,
+			quote: YAMLQuoteDouble,
+			flow:  true,
+			want: "{bio: \"line 1\\nline 2\", count: 2, name: \"John\", " +
+				"none: ~, tags: [\"a\", ~]}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &YAML{Null: tt.null, Quote: tt.quote, Flow: tt.flow}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_Render_yamlNullAndQuoteParams(t *testing.T) {
+	got, err := Base.String(
+		"yaml;null=~;quote=double", false, map[string]any{"a": "b", "c": nil},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"b\"\nc: ~\n", got)
+}
+
+func TestYAML_Anchors(t *testing.T) {
+	type labels struct {
+		App  string `yaml:"app"`
+		Tier string `yaml:"tier"`
+	}
+	type service struct {
+		Name   string            `yaml:"name"`
+		Labels *labels           `yaml:"labels"`
+		Ports  []int             `yaml:"ports,flow"`
+		Extra  map[string][]int  `yaml:",inline"`
+		Meta   map[string]string `yaml:"meta,omitempty"`
+	}
+	shared := &labels{App: "web", Tier: "frontend"}
+	ports := []int{80, 443}
+	meta := map[string]string{"owner": "ops"}
+
+	tests := []struct {
+		name    string
+		anchors bool
+		flow    bool
+		value   any
+		want    string
+	}{
+		{
+			name: "without anchors",
+			value: map[string]any{
+				"a": shared,
+				"b": shared,
+			},
+			want: "a:\n  app: web\n  tier: frontend\n" +
+				"b:\n  app: web\n  tier: frontend\n",
+		},
+		{
+			name:    "shared pointer",
+			anchors: true,
+			value: map[string]any{
+				"a": shared,
+				"b": shared,
+			},
+			want: "a: &a1\n  app: web\n  tier: frontend\nb: *a1\n",
+		},
+		{
+			name:    "nested repeats",
+			anchors: true,
+			value: []any{
+				map[string]any{"labels": shared, "ports": ports},
+				map[string]any{"labels": shared, "ports": []int{8080}},
+				ports,
+			},
+			want: "- labels: &a1\n    app: web\n    tier: frontend\n" +
+				"  ports: &a2\n    - 80\n    - 443\n" +
+				"- labels: *a1\n  ports:\n    - 8080\n" +
+				"- *a2\n",
+		},
+		{
+			name:    "equal values which are not shared",
+			anchors: true,
+			value: []any{
+				&labels{App: "web", Tier: "frontend"},
+				&labels{App: "web", Tier: "frontend"},
+				[]int{80, 443},
+				[]int{80, 443},
+				map[string]int{"a": 1},
+				map[string]int{"a": 1},
+			},
+			want: "- app: web\n  tier: frontend\n" +
+				"- app: web\n  tier: frontend\n" +
+				"- - 80\n  - 443\n- - 80\n  - 443\n" +
+				"- a: 1\n- a: 1\n",
+		},
+		{
+			name:    "struct fields",
+			anchors: true,
+			value: []service{
+				{
+					Name: "a", Labels: shared, Ports: ports,
+					Extra: map[string][]int{"x": ports}, Meta: meta,
+				},
+				{Name: "b", Labels: shared, Ports: ports[:1], Meta: meta},
+			},
+			want: "- name: a\n  labels: &a1\n    app: web\n" +
+				"    tier: frontend\n  ports: &a2 [80, 443]\n" +
+				"  meta: &a3\n    owner: ops\n  x: *a2\n" +
+				"- name: b\n  labels: *a1\n  ports: [80]\n  meta: *a3\n",
+		},
+		{
+			name:    "repeated scalars and empty values",
+			anchors: true,
+			value: map[string]any{
+				"a": "x", "b": "x", "c": []int{}, "d": []int{},
+			},
+			want: "a: x\nb: x\nc: []\nd: []\n",
+		},
+		{
+			name:    "flow",
+			anchors: true,
+			flow:    true,
+			value:   []any{shared, shared},
+			want:    "[&a1 {app: web, tier: frontend}, *a1]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &YAML{Anchors: tt.anchors, Flow: tt.flow}
+
+			var buf bytes.Buffer
+			err := h.Render(&buf, tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+
+			var got, want any
+			require.NoError(t, h.Parse(&buf, &got))
+			b, err := yaml.Marshal(tt.value)
+			require.NoError(t, err)
+			require.NoError(t, yaml.Unmarshal(b, &want))
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestYAML_LineWidth(t *testing.T) {
+	long := "the quick brown fox jumps over the lazy dog"
+
+	tests := []struct {
+		name  string
+		h     *YAML
+		value any
+		want  string
+	}{
+		{
+			name:  "without line width",
+			h:     &YAML{},
+			value: map[string]string{"text": long},
+			want:  "text: " + long + "\n",
+		},
+		{
+			name:  "folds long values",
+			h:     &YAML{LineWidth: 20},
+			value: map[string]string{"text": long, "short": "a b"},
+			want: "short: a b\ntext: >-\n  the quick brown\n" +
+				"  fox jumps over the\n  lazy dog\n",
+		},
+		{
+			name: "nested in sequences",
+			h:    &YAML{LineWidth: 24, Indent: 4},
+			value: []any{
+				[]any{map[string]any{"text": long}},
+			},
+			want: "- - text: >-\n        the quick brown\n" +
+				"        fox jumps over\n        the lazy dog\n",
+		},
+		{
+			name:  "keeps values which fit",
+			h:     &YAML{LineWidth: 80},
+			value: map[string]string{"text": long},
+			want:  "text: " + long + "\n",
+		},
+		{
+			name:  "keeps quoting of values which fit",
+			h:     &YAML{LineWidth: 80},
+			value: map[string]string{"a": "yes no", "b": "true"},
+			want:  "a: yes no\nb: \"true\"\n",
+		},
+		{
+			name:  "keeps quote style of values which fit",
+			h:     &YAML{LineWidth: 80, Quote: YAMLQuoteSingle},
+			value: map[string]string{"a": "x y"},
+			want:  "a: 'x y'\n",
+		},
+		{
+			name:  "does not split at multiple spaces",
+			h:     &YAML{LineWidth: 10},
+			value: map[string]string{"a": "aaaa  bbbb cccc"},
+			want:  "a: >-\n  aaaa  bbbb\n  cccc\n",
+		},
+		{
+			name:  "does not fold keys",
+			h:     &YAML{LineWidth: 10},
+			value: map[string]int{long: 1},
+			want:  long + ": 1\n",
+		},
+		{
+			name:  "placeholder collision",
+			h:     &YAML{LineWidth: 10},
+			value: map[string]string{"render-fold-0": long},
+			want: "render-fold-0: >-\n  the\n  quick\n  brown\n  fox\n" +
+				"  jumps\n  over the\n  lazy dog\n",
+		},
+		{
+			name:  "ignored with flow",
+			h:     &YAML{LineWidth: 10, Flow: true},
+			value: map[string]string{"a": long},
+			want:  "{a: " + long + "}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.h.Render(&buf, tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+
+			got := reflect.New(reflect.TypeOf(tt.value))
+			require.NoError(t, tt.h.Parse(&buf, got.Interface()))
+			assert.Equal(t, tt.value, got.Elem().Interface())
+		})
+	}
+}
+
+func TestYAML_LineWidth_encoder(t *testing.T) {
+	h := &YAML{LineWidth: 12}
+
+	var buf bytes.Buffer
+	enc := h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode("hello big world"))
+	require.NoError(t, enc.Encode("short"))
+	require.NoError(t, enc.(io.Closer).Close())
+
+	assert.Equal(t, ">-\n  hello big\n  world\n---\nshort\n", buf.String())
+}
+
+func TestYAML_Engine(t *testing.T) {
+	engine := &mockYAMLEngine{}
+	h := &YAML{Engine: engine, Indent: 4, Flow: true}
+	value := map[string]any{"a": map[string]string{"b": "c"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, h.Render(&buf, value))
+	assert.Equal(t, "A:\n    B: C\n", buf.String())
+
+	buf.Reset()
+	enc := h.NewEncoder(&buf, true)
+	require.NoError(t, enc.Encode("x"))
+	require.NoError(t, enc.Encode("z"))
+	require.NoError(t, enc.(io.Closer).Close())
+	assert.Equal(t, "X\n---\nZ\n", buf.String())
+	assert.Equal(t, []int{4, 4}, engine.indents)
+
+	var got map[string]int
+	require.NoError(t, h.Parse(strings.NewReader("a: 1\n"), &got))
+	assert.Equal(t, map[string]int{"a": 1}, got)
+	assert.Equal(t, []any{&got}, engine.decoded)
+}
+
 func TestYAML_Formats(t *testing.T) {
 	h := &YAML{}
 
 	assert.Equal(t, []string{"yaml", "yml"}, h.Formats())
 }
+
+func TestYAML_CommentPrefix(t *testing.T) {
+	h := &YAML{}
+
+	assert.Equal(t, "# ", h.CommentPrefix())
+}
+
+func TestYAML_WithOptions(t *testing.T) {
+	h := &YAML{Indent: 2}
+
+	assert.Same(t, h, h.WithOptions(&Options{Pretty: true}))
+	assert.Same(t, h, h.WithOptions(&Options{Indent: "\t"}))
+	assert.Same(t, h, h.WithOptions(&Options{Indent: " \t"}))
+	assert.Equal(t, &YAML{Indent: 4}, h.WithOptions(&Options{Indent: "    "}))
+	assert.Equal(t,
+		&YAML{Indent: 2, Flow: true},
+		h.WithOptions(&Options{Params: map[string]string{"flow": "true"}}),
+	)
+	assert.Equal(t,
+		&YAML{Indent: 2, UseJSONTags: true},
+		h.WithOptions(
+			&Options{Params: map[string]string{"json_tags": "true"}},
+		),
+	)
+	assert.Equal(t, &YAML{Indent: 2}, h)
+}
+
+func TestYAML_NewEncoder(t *testing.T) {
+	h := &YAML{Indent: 4}
+
+	var buf bytes.Buffer
+	enc := h.NewEncoder(&buf, false)
+	require.NoError(t, enc.Encode(map[string]any{"a": map[string]int{"b": 1}}))
+	require.NoError(t, enc.Encode("foo"))
+	require.NoError(t, enc.(io.Closer).Close())
+
+	assert.Equal(t, "a:\n    b: 1\n---\nfoo\n", buf.String())
+}
+
+func TestYAML_NewEncoder_recoversPanics(t *testing.T) {
+	var buf bytes.Buffer
+	enc := (&YAML{}).NewEncoder(&buf, false)
+
+	err := enc.Encode(map[string]any{"a": func() {}})
+
+	assert.EqualError(t, err, "render: failed: cannot marshal type: func()")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestYAML_Parse(t *testing.T) {
+	h := &YAML{}
+
+	var got struct {
+		Name string `yaml:"name"`
+	}
+	err := h.Parse(strings.NewReader("name: foo\n---\nname: bar\n"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Name)
+
+	err = h.Parse(strings.NewReader(""), &got)
+	assert.EqualError(t, err, "render: failed: EOF")
+	assert.ErrorIs(t, err, ErrFailed)
+}